@@ -22,12 +22,97 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ConfigClient interface {
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
 	UpdateDeviceConfig0(ctx context.Context, in *DeviceConfig0, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	// 以update_mask部分更新设备配置，update_mask为空时替换整个配置。expected_revision不为0时，
+	// 只有desired配置当前的修订版本号与之相等时才进行更新，否则返回409错误。
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	PatchDeviceConfig0(ctx context.Context, in *PatchDeviceConfigRequest0, opts ...grpc.CallOption) (*ConfigServiceReply, error)
 	CreateConfigUpdateStream0(ctx context.Context, opts ...grpc.CallOption) (Config_CreateConfigUpdateStream0Client, error)
+	// 上传的配置与desired配置不一致时，按照设备类别配置的initial_config_policy决定生效的配置，冲突记录在配置历史中
 	CreateInitialConfigSaveStream0(ctx context.Context, opts ...grpc.CallOption) (Config_CreateInitialConfigSaveStream0Client, error)
+	GetDeviceConfig0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceConfig0, error)
+	ListDeviceConfigs0(ctx context.Context, in *ListDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply0, error)
+	BatchGetDeviceConfigs0(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...grpc.CallOption) (*BatchGetDeviceConfigsReply0, error)
+	ListDeviceConfigRevisions0(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*ListDeviceConfigRevisionsReply0, error)
+	DiffDeviceConfigRevisions0(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffDeviceConfigRevisionsReply, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	RollbackDeviceConfig0(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	GetDeviceShadow0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceShadow0, error)
+	// 设置设备的标签，批量更新配置时可以依据标签选择设备
+	SetDeviceLabels0(ctx context.Context, in *DeviceLabels, opts ...grpc.CallOption) (*DeviceLabels, error)
+	GetDeviceLabels0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceLabels, error)
+	// 将同一配置下发给设备类别下被selector选中的全部设备，配置更新在后台以限定的速率依次下发，
+	// 返回的批量更新任务可以通过GetBulkUpdateJob查询下发进度
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	BulkUpdateDeviceConfig0(ctx context.Context, in *BulkUpdateDeviceConfigRequest0, opts ...grpc.CallOption) (*BulkUpdateJob, error)
+	// 创建灰度发布，在start_time将配置依次下发给各个阶段的设备，每个阶段的设备确认配置更新且没有产生新的警告信息后
+	// 才进入下一阶段，否则灰度发布自动暂停
+	CreateConfigRollout0(ctx context.Context, in *CreateConfigRolloutRequest0, opts ...grpc.CallOption) (*ConfigRollout, error)
+	// 设置设备类别的配置模板，首次出现且没有任何已保存配置的设备会以模板(设备id替换为该设备的id)
+	// 作为desired配置，并通过配置更新流下发给设备
+	SetConfigTemplate0(ctx context.Context, in *DeviceConfig0, opts ...grpc.CallOption) (*DeviceConfig0, error)
+	GetConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*DeviceConfig0, error)
+	// 删除设备类别的配置模板，此后首次出现的设备不再下发模板配置
+	DeleteConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	// 按照从旧到新的顺序分页查询设备类别下配置变更的审计记录，包括通过http、grpc下发的配置更新以及设备确认接收的配置更新，
+	// 调用者身份取自认证网关注入的x-actor请求头或者Basic认证的用户名
+	ListConfigAuditRecords0(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...grpc.CallOption) (*ListConfigAuditRecordsReply0, error)
+	// 按照设备id的顺序分页查询上报配置满足全部查询条件的设备配置，查询条件为顶层标量字段的等值条件，例如status==false，
+	// 查询利用设备保存配置时维护的二级索引，不遍历全部设备配置
+	SearchDeviceConfigs0(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply0, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
 	UpdateDeviceConfig1(ctx context.Context, in *DeviceConfig1, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	// 以update_mask部分更新设备配置，update_mask为空时替换整个配置。expected_revision不为0时，
+	// 只有desired配置当前的修订版本号与之相等时才进行更新，否则返回409错误。
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	PatchDeviceConfig1(ctx context.Context, in *PatchDeviceConfigRequest1, opts ...grpc.CallOption) (*ConfigServiceReply, error)
 	CreateConfigUpdateStream1(ctx context.Context, opts ...grpc.CallOption) (Config_CreateConfigUpdateStream1Client, error)
+	// 上传的配置与desired配置不一致时，按照设备类别配置的initial_config_policy决定生效的配置，冲突记录在配置历史中
 	CreateInitialConfigSaveStream1(ctx context.Context, opts ...grpc.CallOption) (Config_CreateInitialConfigSaveStream1Client, error)
+	GetDeviceConfig1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceConfig1, error)
+	ListDeviceConfigs1(ctx context.Context, in *ListDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply1, error)
+	BatchGetDeviceConfigs1(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...grpc.CallOption) (*BatchGetDeviceConfigsReply1, error)
+	ListDeviceConfigRevisions1(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*ListDeviceConfigRevisionsReply1, error)
+	DiffDeviceConfigRevisions1(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffDeviceConfigRevisionsReply, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	RollbackDeviceConfig1(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	GetDeviceShadow1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceShadow1, error)
+	// 设置设备的标签，批量更新配置时可以依据标签选择设备
+	SetDeviceLabels1(ctx context.Context, in *DeviceLabels, opts ...grpc.CallOption) (*DeviceLabels, error)
+	GetDeviceLabels1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceLabels, error)
+	// 将同一配置下发给设备类别下被selector选中的全部设备，配置更新在后台以限定的速率依次下发，
+	// 返回的批量更新任务可以通过GetBulkUpdateJob查询下发进度
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	BulkUpdateDeviceConfig1(ctx context.Context, in *BulkUpdateDeviceConfigRequest1, opts ...grpc.CallOption) (*BulkUpdateJob, error)
+	// 创建灰度发布，在start_time将配置依次下发给各个阶段的设备，每个阶段的设备确认配置更新且没有产生新的警告信息后
+	// 才进入下一阶段，否则灰度发布自动暂停
+	CreateConfigRollout1(ctx context.Context, in *CreateConfigRolloutRequest1, opts ...grpc.CallOption) (*ConfigRollout, error)
+	// 设置设备类别的配置模板，首次出现且没有任何已保存配置的设备会以模板(设备id替换为该设备的id)
+	// 作为desired配置，并通过配置更新流下发给设备
+	SetConfigTemplate1(ctx context.Context, in *DeviceConfig1, opts ...grpc.CallOption) (*DeviceConfig1, error)
+	GetConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*DeviceConfig1, error)
+	// 删除设备类别的配置模板，此后首次出现的设备不再下发模板配置
+	DeleteConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error)
+	// 按照从旧到新的顺序分页查询设备类别下配置变更的审计记录，包括通过http、grpc下发的配置更新以及设备确认接收的配置更新，
+	// 调用者身份取自认证网关注入的x-actor请求头或者Basic认证的用户名
+	ListConfigAuditRecords1(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...grpc.CallOption) (*ListConfigAuditRecordsReply1, error)
+	// 按照设备id的顺序分页查询上报配置满足全部查询条件的设备配置，查询条件为顶层标量字段的等值条件，例如status==false，
+	// 查询利用设备保存配置时维护的二级索引，不遍历全部设备配置
+	SearchDeviceConfigs1(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply1, error)
+	GetConfigUpdateStatus(ctx context.Context, in *GetConfigUpdateStatusRequest, opts ...grpc.CallOption) (*ConfigUpdateStatus, error)
+	GetBulkUpdateJob(ctx context.Context, in *GetBulkUpdateJobRequest, opts ...grpc.CallOption) (*BulkUpdateJob, error)
+	GetConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error)
+	// 取消尚未结束的灰度发布，已经下发的配置更新不会被撤回
+	CancelConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error)
+	// 继续已经暂停的灰度发布，暂停时所处的阶段视为通过
+	ResumeConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error)
 }
 
 type configClient struct {
@@ -47,6 +132,15 @@ func (c *configClient) UpdateDeviceConfig0(ctx context.Context, in *DeviceConfig
 	return out, nil
 }
 
+func (c *configClient) PatchDeviceConfig0(ctx context.Context, in *PatchDeviceConfigRequest0, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/PatchDeviceConfig0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configClient) CreateConfigUpdateStream0(ctx context.Context, opts ...grpc.CallOption) (Config_CreateConfigUpdateStream0Client, error) {
 	stream, err := c.cc.NewStream(ctx, &Config_ServiceDesc.Streams[0], "/api.dataCollection.v1.Config/CreateConfigUpdateStream0", opts...)
 	if err != nil {
@@ -112,6 +206,150 @@ func (x *configCreateInitialConfigSaveStream0Client) CloseAndRecv() (*ConfigServ
 	return m, nil
 }
 
+func (c *configClient) GetDeviceConfig0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceConfig0, error) {
+	out := new(DeviceConfig0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceConfig0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ListDeviceConfigs0(ctx context.Context, in *ListDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply0, error) {
+	out := new(ListDeviceConfigsReply0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigs0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) BatchGetDeviceConfigs0(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...grpc.CallOption) (*BatchGetDeviceConfigsReply0, error) {
+	out := new(BatchGetDeviceConfigsReply0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/BatchGetDeviceConfigs0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ListDeviceConfigRevisions0(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*ListDeviceConfigRevisionsReply0, error) {
+	out := new(ListDeviceConfigRevisionsReply0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigRevisions0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) DiffDeviceConfigRevisions0(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffDeviceConfigRevisionsReply, error) {
+	out := new(DiffDeviceConfigRevisionsReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) RollbackDeviceConfig0(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/RollbackDeviceConfig0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetDeviceShadow0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceShadow0, error) {
+	out := new(DeviceShadow0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceShadow0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) SetDeviceLabels0(ctx context.Context, in *DeviceLabels, opts ...grpc.CallOption) (*DeviceLabels, error) {
+	out := new(DeviceLabels)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SetDeviceLabels0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetDeviceLabels0(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceLabels, error) {
+	out := new(DeviceLabels)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceLabels0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) BulkUpdateDeviceConfig0(ctx context.Context, in *BulkUpdateDeviceConfigRequest0, opts ...grpc.CallOption) (*BulkUpdateJob, error) {
+	out := new(BulkUpdateJob)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) CreateConfigRollout0(ctx context.Context, in *CreateConfigRolloutRequest0, opts ...grpc.CallOption) (*ConfigRollout, error) {
+	out := new(ConfigRollout)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/CreateConfigRollout0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) SetConfigTemplate0(ctx context.Context, in *DeviceConfig0, opts ...grpc.CallOption) (*DeviceConfig0, error) {
+	out := new(DeviceConfig0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SetConfigTemplate0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*DeviceConfig0, error) {
+	out := new(DeviceConfig0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetConfigTemplate0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) DeleteConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/DeleteConfigTemplate0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ListConfigAuditRecords0(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...grpc.CallOption) (*ListConfigAuditRecordsReply0, error) {
+	out := new(ListConfigAuditRecordsReply0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListConfigAuditRecords0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) SearchDeviceConfigs0(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply0, error) {
+	out := new(ListDeviceConfigsReply0)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SearchDeviceConfigs0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configClient) UpdateDeviceConfig1(ctx context.Context, in *DeviceConfig1, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
 	out := new(ConfigServiceReply)
 	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/UpdateDeviceConfig1", in, out, opts...)
@@ -121,6 +359,15 @@ func (c *configClient) UpdateDeviceConfig1(ctx context.Context, in *DeviceConfig
 	return out, nil
 }
 
+func (c *configClient) PatchDeviceConfig1(ctx context.Context, in *PatchDeviceConfigRequest1, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/PatchDeviceConfig1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configClient) CreateConfigUpdateStream1(ctx context.Context, opts ...grpc.CallOption) (Config_CreateConfigUpdateStream1Client, error) {
 	stream, err := c.cc.NewStream(ctx, &Config_ServiceDesc.Streams[2], "/api.dataCollection.v1.Config/CreateConfigUpdateStream1", opts...)
 	if err != nil {
@@ -186,192 +433,1285 @@ func (x *configCreateInitialConfigSaveStream1Client) CloseAndRecv() (*ConfigServ
 	return m, nil
 }
 
-// ConfigServer is the server API for Config service.
-// All implementations must embed UnimplementedConfigServer
-// for forward compatibility
-type ConfigServer interface {
-	UpdateDeviceConfig0(context.Context, *DeviceConfig0) (*ConfigServiceReply, error)
-	CreateConfigUpdateStream0(Config_CreateConfigUpdateStream0Server) error
-	CreateInitialConfigSaveStream0(Config_CreateInitialConfigSaveStream0Server) error
-	UpdateDeviceConfig1(context.Context, *DeviceConfig1) (*ConfigServiceReply, error)
-	CreateConfigUpdateStream1(Config_CreateConfigUpdateStream1Server) error
-	CreateInitialConfigSaveStream1(Config_CreateInitialConfigSaveStream1Server) error
-	mustEmbedUnimplementedConfigServer()
+func (c *configClient) GetDeviceConfig1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceConfig1, error) {
+	out := new(DeviceConfig1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceConfig1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedConfigServer must be embedded to have forward compatible implementations.
-type UnimplementedConfigServer struct {
+func (c *configClient) ListDeviceConfigs1(ctx context.Context, in *ListDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply1, error) {
+	out := new(ListDeviceConfigsReply1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigs1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedConfigServer) UpdateDeviceConfig0(context.Context, *DeviceConfig0) (*ConfigServiceReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceConfig0 not implemented")
-}
-func (UnimplementedConfigServer) CreateConfigUpdateStream0(Config_CreateConfigUpdateStream0Server) error {
-	return status.Errorf(codes.Unimplemented, "method CreateConfigUpdateStream0 not implemented")
-}
-func (UnimplementedConfigServer) CreateInitialConfigSaveStream0(Config_CreateInitialConfigSaveStream0Server) error {
-	return status.Errorf(codes.Unimplemented, "method CreateInitialConfigSaveStream0 not implemented")
-}
-func (UnimplementedConfigServer) UpdateDeviceConfig1(context.Context, *DeviceConfig1) (*ConfigServiceReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceConfig1 not implemented")
-}
-func (UnimplementedConfigServer) CreateConfigUpdateStream1(Config_CreateConfigUpdateStream1Server) error {
-	return status.Errorf(codes.Unimplemented, "method CreateConfigUpdateStream1 not implemented")
-}
-func (UnimplementedConfigServer) CreateInitialConfigSaveStream1(Config_CreateInitialConfigSaveStream1Server) error {
-	return status.Errorf(codes.Unimplemented, "method CreateInitialConfigSaveStream1 not implemented")
+func (c *configClient) BatchGetDeviceConfigs1(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...grpc.CallOption) (*BatchGetDeviceConfigsReply1, error) {
+	out := new(BatchGetDeviceConfigsReply1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/BatchGetDeviceConfigs1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedConfigServer) mustEmbedUnimplementedConfigServer() {}
 
-// UnsafeConfigServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ConfigServer will
-// result in compilation errors.
-type UnsafeConfigServer interface {
-	mustEmbedUnimplementedConfigServer()
+func (c *configClient) ListDeviceConfigRevisions1(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*ListDeviceConfigRevisionsReply1, error) {
+	out := new(ListDeviceConfigRevisionsReply1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigRevisions1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterConfigServer(s grpc.ServiceRegistrar, srv ConfigServer) {
-	s.RegisterService(&Config_ServiceDesc, srv)
+func (c *configClient) DiffDeviceConfigRevisions1(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...grpc.CallOption) (*DiffDeviceConfigRevisionsReply, error) {
+	out := new(DiffDeviceConfigRevisionsReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func _Config_UpdateDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeviceConfig0)
-	if err := dec(in); err != nil {
+func (c *configClient) RollbackDeviceConfig1(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/RollbackDeviceConfig1", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ConfigServer).UpdateDeviceConfig0(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.dataCollection.v1.Config/UpdateDeviceConfig0",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConfigServer).UpdateDeviceConfig0(ctx, req.(*DeviceConfig0))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Config_CreateConfigUpdateStream0_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ConfigServer).CreateConfigUpdateStream0(&configCreateConfigUpdateStream0Server{stream})
+func (c *configClient) GetDeviceShadow1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceShadow1, error) {
+	out := new(DeviceShadow1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceShadow1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type Config_CreateConfigUpdateStream0Server interface {
-	Send(*DeviceConfig0) error
-	Recv() (*ConfigUpdateReply, error)
-	grpc.ServerStream
+func (c *configClient) SetDeviceLabels1(ctx context.Context, in *DeviceLabels, opts ...grpc.CallOption) (*DeviceLabels, error) {
+	out := new(DeviceLabels)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SetDeviceLabels1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type configCreateConfigUpdateStream0Server struct {
-	grpc.ServerStream
+func (c *configClient) GetDeviceLabels1(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*DeviceLabels, error) {
+	out := new(DeviceLabels)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetDeviceLabels1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *configCreateConfigUpdateStream0Server) Send(m *DeviceConfig0) error {
-	return x.ServerStream.SendMsg(m)
+func (c *configClient) BulkUpdateDeviceConfig1(ctx context.Context, in *BulkUpdateDeviceConfigRequest1, opts ...grpc.CallOption) (*BulkUpdateJob, error) {
+	out := new(BulkUpdateJob)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *configCreateConfigUpdateStream0Server) Recv() (*ConfigUpdateReply, error) {
-	m := new(ConfigUpdateReply)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (c *configClient) CreateConfigRollout1(ctx context.Context, in *CreateConfigRolloutRequest1, opts ...grpc.CallOption) (*ConfigRollout, error) {
+	out := new(ConfigRollout)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/CreateConfigRollout1", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func _Config_CreateInitialConfigSaveStream0_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ConfigServer).CreateInitialConfigSaveStream0(&configCreateInitialConfigSaveStream0Server{stream})
+func (c *configClient) SetConfigTemplate1(ctx context.Context, in *DeviceConfig1, opts ...grpc.CallOption) (*DeviceConfig1, error) {
+	out := new(DeviceConfig1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SetConfigTemplate1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type Config_CreateInitialConfigSaveStream0Server interface {
-	SendAndClose(*ConfigServiceReply) error
-	Recv() (*DeviceConfig0, error)
-	grpc.ServerStream
+func (c *configClient) GetConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*DeviceConfig1, error) {
+	out := new(DeviceConfig1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetConfigTemplate1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type configCreateInitialConfigSaveStream0Server struct {
-	grpc.ServerStream
+func (c *configClient) DeleteConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...grpc.CallOption) (*ConfigServiceReply, error) {
+	out := new(ConfigServiceReply)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/DeleteConfigTemplate1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *configCreateInitialConfigSaveStream0Server) SendAndClose(m *ConfigServiceReply) error {
-	return x.ServerStream.SendMsg(m)
+func (c *configClient) ListConfigAuditRecords1(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...grpc.CallOption) (*ListConfigAuditRecordsReply1, error) {
+	out := new(ListConfigAuditRecordsReply1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ListConfigAuditRecords1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *configCreateInitialConfigSaveStream0Server) Recv() (*DeviceConfig0, error) {
-	m := new(DeviceConfig0)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (c *configClient) SearchDeviceConfigs1(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...grpc.CallOption) (*ListDeviceConfigsReply1, error) {
+	out := new(ListDeviceConfigsReply1)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/SearchDeviceConfigs1", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func _Config_UpdateDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeviceConfig1)
-	if err := dec(in); err != nil {
+func (c *configClient) GetConfigUpdateStatus(ctx context.Context, in *GetConfigUpdateStatusRequest, opts ...grpc.CallOption) (*ConfigUpdateStatus, error) {
+	out := new(ConfigUpdateStatus)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetConfigUpdateStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetBulkUpdateJob(ctx context.Context, in *GetBulkUpdateJobRequest, opts ...grpc.CallOption) (*BulkUpdateJob, error) {
+	out := new(BulkUpdateJob)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetBulkUpdateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error) {
+	out := new(ConfigRollout)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/GetConfigRollout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) CancelConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error) {
+	out := new(ConfigRollout)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/CancelConfigRollout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ResumeConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*ConfigRollout, error) {
+	out := new(ConfigRollout)
+	err := c.cc.Invoke(ctx, "/api.dataCollection.v1.Config/ResumeConfigRollout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConfigServer is the server API for Config service.
+// All implementations must embed UnimplementedConfigServer
+// for forward compatibility
+type ConfigServer interface {
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	UpdateDeviceConfig0(context.Context, *DeviceConfig0) (*ConfigServiceReply, error)
+	// 以update_mask部分更新设备配置，update_mask为空时替换整个配置。expected_revision不为0时，
+	// 只有desired配置当前的修订版本号与之相等时才进行更新，否则返回409错误。
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	PatchDeviceConfig0(context.Context, *PatchDeviceConfigRequest0) (*ConfigServiceReply, error)
+	CreateConfigUpdateStream0(Config_CreateConfigUpdateStream0Server) error
+	// 上传的配置与desired配置不一致时，按照设备类别配置的initial_config_policy决定生效的配置，冲突记录在配置历史中
+	CreateInitialConfigSaveStream0(Config_CreateInitialConfigSaveStream0Server) error
+	GetDeviceConfig0(context.Context, *GetDeviceConfigRequest) (*DeviceConfig0, error)
+	ListDeviceConfigs0(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply0, error)
+	BatchGetDeviceConfigs0(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply0, error)
+	ListDeviceConfigRevisions0(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply0, error)
+	DiffDeviceConfigRevisions0(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	RollbackDeviceConfig0(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error)
+	GetDeviceShadow0(context.Context, *GetDeviceConfigRequest) (*DeviceShadow0, error)
+	// 设置设备的标签，批量更新配置时可以依据标签选择设备
+	SetDeviceLabels0(context.Context, *DeviceLabels) (*DeviceLabels, error)
+	GetDeviceLabels0(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error)
+	// 将同一配置下发给设备类别下被selector选中的全部设备，配置更新在后台以限定的速率依次下发，
+	// 返回的批量更新任务可以通过GetBulkUpdateJob查询下发进度
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	BulkUpdateDeviceConfig0(context.Context, *BulkUpdateDeviceConfigRequest0) (*BulkUpdateJob, error)
+	// 创建灰度发布，在start_time将配置依次下发给各个阶段的设备，每个阶段的设备确认配置更新且没有产生新的警告信息后
+	// 才进入下一阶段，否则灰度发布自动暂停
+	CreateConfigRollout0(context.Context, *CreateConfigRolloutRequest0) (*ConfigRollout, error)
+	// 设置设备类别的配置模板，首次出现且没有任何已保存配置的设备会以模板(设备id替换为该设备的id)
+	// 作为desired配置，并通过配置更新流下发给设备
+	SetConfigTemplate0(context.Context, *DeviceConfig0) (*DeviceConfig0, error)
+	GetConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*DeviceConfig0, error)
+	// 删除设备类别的配置模板，此后首次出现的设备不再下发模板配置
+	DeleteConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error)
+	// 按照从旧到新的顺序分页查询设备类别下配置变更的审计记录，包括通过http、grpc下发的配置更新以及设备确认接收的配置更新，
+	// 调用者身份取自认证网关注入的x-actor请求头或者Basic认证的用户名
+	ListConfigAuditRecords0(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply0, error)
+	// 按照设备id的顺序分页查询上报配置满足全部查询条件的设备配置，查询条件为顶层标量字段的等值条件，例如status==false，
+	// 查询利用设备保存配置时维护的二级索引，不遍历全部设备配置
+	SearchDeviceConfigs0(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply0, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	UpdateDeviceConfig1(context.Context, *DeviceConfig1) (*ConfigServiceReply, error)
+	// 以update_mask部分更新设备配置，update_mask为空时替换整个配置。expected_revision不为0时，
+	// 只有desired配置当前的修订版本号与之相等时才进行更新，否则返回409错误。
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	PatchDeviceConfig1(context.Context, *PatchDeviceConfigRequest1) (*ConfigServiceReply, error)
+	CreateConfigUpdateStream1(Config_CreateConfigUpdateStream1Server) error
+	// 上传的配置与desired配置不一致时，按照设备类别配置的initial_config_policy决定生效的配置，冲突记录在配置历史中
+	CreateInitialConfigSaveStream1(Config_CreateInitialConfigSaveStream1Server) error
+	GetDeviceConfig1(context.Context, *GetDeviceConfigRequest) (*DeviceConfig1, error)
+	ListDeviceConfigs1(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply1, error)
+	BatchGetDeviceConfigs1(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply1, error)
+	ListDeviceConfigRevisions1(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply1, error)
+	DiffDeviceConfigRevisions1(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error)
+	// 请求头中包含x-wait-for-ack时，阻塞至设备确认配置更新或者等待超时，其值为等待的超时时间，例如10s
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	RollbackDeviceConfig1(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error)
+	GetDeviceShadow1(context.Context, *GetDeviceConfigRequest) (*DeviceShadow1, error)
+	// 设置设备的标签，批量更新配置时可以依据标签选择设备
+	SetDeviceLabels1(context.Context, *DeviceLabels) (*DeviceLabels, error)
+	GetDeviceLabels1(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error)
+	// 将同一配置下发给设备类别下被selector选中的全部设备，配置更新在后台以限定的速率依次下发，
+	// 返回的批量更新任务可以通过GetBulkUpdateJob查询下发进度
+	// 请求头中包含x-expires-at时，配置更新在该时间之前未下发给设备则不再下发并标记为expired，其值为RFC3339格式的时间
+	BulkUpdateDeviceConfig1(context.Context, *BulkUpdateDeviceConfigRequest1) (*BulkUpdateJob, error)
+	// 创建灰度发布，在start_time将配置依次下发给各个阶段的设备，每个阶段的设备确认配置更新且没有产生新的警告信息后
+	// 才进入下一阶段，否则灰度发布自动暂停
+	CreateConfigRollout1(context.Context, *CreateConfigRolloutRequest1) (*ConfigRollout, error)
+	// 设置设备类别的配置模板，首次出现且没有任何已保存配置的设备会以模板(设备id替换为该设备的id)
+	// 作为desired配置，并通过配置更新流下发给设备
+	SetConfigTemplate1(context.Context, *DeviceConfig1) (*DeviceConfig1, error)
+	GetConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*DeviceConfig1, error)
+	// 删除设备类别的配置模板，此后首次出现的设备不再下发模板配置
+	DeleteConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error)
+	// 按照从旧到新的顺序分页查询设备类别下配置变更的审计记录，包括通过http、grpc下发的配置更新以及设备确认接收的配置更新，
+	// 调用者身份取自认证网关注入的x-actor请求头或者Basic认证的用户名
+	ListConfigAuditRecords1(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply1, error)
+	// 按照设备id的顺序分页查询上报配置满足全部查询条件的设备配置，查询条件为顶层标量字段的等值条件，例如status==false，
+	// 查询利用设备保存配置时维护的二级索引，不遍历全部设备配置
+	SearchDeviceConfigs1(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply1, error)
+	GetConfigUpdateStatus(context.Context, *GetConfigUpdateStatusRequest) (*ConfigUpdateStatus, error)
+	GetBulkUpdateJob(context.Context, *GetBulkUpdateJobRequest) (*BulkUpdateJob, error)
+	GetConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	// 取消尚未结束的灰度发布，已经下发的配置更新不会被撤回
+	CancelConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	// 继续已经暂停的灰度发布，暂停时所处的阶段视为通过
+	ResumeConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	mustEmbedUnimplementedConfigServer()
+}
+
+// UnimplementedConfigServer must be embedded to have forward compatible implementations.
+type UnimplementedConfigServer struct {
+}
+
+func (UnimplementedConfigServer) UpdateDeviceConfig0(context.Context, *DeviceConfig0) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceConfig0 not implemented")
+}
+func (UnimplementedConfigServer) PatchDeviceConfig0(context.Context, *PatchDeviceConfigRequest0) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchDeviceConfig0 not implemented")
+}
+func (UnimplementedConfigServer) CreateConfigUpdateStream0(Config_CreateConfigUpdateStream0Server) error {
+	return status.Errorf(codes.Unimplemented, "method CreateConfigUpdateStream0 not implemented")
+}
+func (UnimplementedConfigServer) CreateInitialConfigSaveStream0(Config_CreateInitialConfigSaveStream0Server) error {
+	return status.Errorf(codes.Unimplemented, "method CreateInitialConfigSaveStream0 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceConfig0(context.Context, *GetDeviceConfigRequest) (*DeviceConfig0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceConfig0 not implemented")
+}
+func (UnimplementedConfigServer) ListDeviceConfigs0(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceConfigs0 not implemented")
+}
+func (UnimplementedConfigServer) BatchGetDeviceConfigs0(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetDeviceConfigs0 not implemented")
+}
+func (UnimplementedConfigServer) ListDeviceConfigRevisions0(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceConfigRevisions0 not implemented")
+}
+func (UnimplementedConfigServer) DiffDeviceConfigRevisions0(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffDeviceConfigRevisions0 not implemented")
+}
+func (UnimplementedConfigServer) RollbackDeviceConfig0(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackDeviceConfig0 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceShadow0(context.Context, *GetDeviceConfigRequest) (*DeviceShadow0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceShadow0 not implemented")
+}
+func (UnimplementedConfigServer) SetDeviceLabels0(context.Context, *DeviceLabels) (*DeviceLabels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceLabels0 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceLabels0(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceLabels0 not implemented")
+}
+func (UnimplementedConfigServer) BulkUpdateDeviceConfig0(context.Context, *BulkUpdateDeviceConfigRequest0) (*BulkUpdateJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateDeviceConfig0 not implemented")
+}
+func (UnimplementedConfigServer) CreateConfigRollout0(context.Context, *CreateConfigRolloutRequest0) (*ConfigRollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConfigRollout0 not implemented")
+}
+func (UnimplementedConfigServer) SetConfigTemplate0(context.Context, *DeviceConfig0) (*DeviceConfig0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfigTemplate0 not implemented")
+}
+func (UnimplementedConfigServer) GetConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*DeviceConfig0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigTemplate0 not implemented")
+}
+func (UnimplementedConfigServer) DeleteConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigTemplate0 not implemented")
+}
+func (UnimplementedConfigServer) ListConfigAuditRecords0(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigAuditRecords0 not implemented")
+}
+func (UnimplementedConfigServer) SearchDeviceConfigs0(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply0, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchDeviceConfigs0 not implemented")
+}
+func (UnimplementedConfigServer) UpdateDeviceConfig1(context.Context, *DeviceConfig1) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceConfig1 not implemented")
+}
+func (UnimplementedConfigServer) PatchDeviceConfig1(context.Context, *PatchDeviceConfigRequest1) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchDeviceConfig1 not implemented")
+}
+func (UnimplementedConfigServer) CreateConfigUpdateStream1(Config_CreateConfigUpdateStream1Server) error {
+	return status.Errorf(codes.Unimplemented, "method CreateConfigUpdateStream1 not implemented")
+}
+func (UnimplementedConfigServer) CreateInitialConfigSaveStream1(Config_CreateInitialConfigSaveStream1Server) error {
+	return status.Errorf(codes.Unimplemented, "method CreateInitialConfigSaveStream1 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceConfig1(context.Context, *GetDeviceConfigRequest) (*DeviceConfig1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceConfig1 not implemented")
+}
+func (UnimplementedConfigServer) ListDeviceConfigs1(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceConfigs1 not implemented")
+}
+func (UnimplementedConfigServer) BatchGetDeviceConfigs1(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetDeviceConfigs1 not implemented")
+}
+func (UnimplementedConfigServer) ListDeviceConfigRevisions1(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceConfigRevisions1 not implemented")
+}
+func (UnimplementedConfigServer) DiffDeviceConfigRevisions1(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffDeviceConfigRevisions1 not implemented")
+}
+func (UnimplementedConfigServer) RollbackDeviceConfig1(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackDeviceConfig1 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceShadow1(context.Context, *GetDeviceConfigRequest) (*DeviceShadow1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceShadow1 not implemented")
+}
+func (UnimplementedConfigServer) SetDeviceLabels1(context.Context, *DeviceLabels) (*DeviceLabels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceLabels1 not implemented")
+}
+func (UnimplementedConfigServer) GetDeviceLabels1(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceLabels1 not implemented")
+}
+func (UnimplementedConfigServer) BulkUpdateDeviceConfig1(context.Context, *BulkUpdateDeviceConfigRequest1) (*BulkUpdateJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateDeviceConfig1 not implemented")
+}
+func (UnimplementedConfigServer) CreateConfigRollout1(context.Context, *CreateConfigRolloutRequest1) (*ConfigRollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConfigRollout1 not implemented")
+}
+func (UnimplementedConfigServer) SetConfigTemplate1(context.Context, *DeviceConfig1) (*DeviceConfig1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfigTemplate1 not implemented")
+}
+func (UnimplementedConfigServer) GetConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*DeviceConfig1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigTemplate1 not implemented")
+}
+func (UnimplementedConfigServer) DeleteConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigTemplate1 not implemented")
+}
+func (UnimplementedConfigServer) ListConfigAuditRecords1(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigAuditRecords1 not implemented")
+}
+func (UnimplementedConfigServer) SearchDeviceConfigs1(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchDeviceConfigs1 not implemented")
+}
+func (UnimplementedConfigServer) GetConfigUpdateStatus(context.Context, *GetConfigUpdateStatusRequest) (*ConfigUpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigUpdateStatus not implemented")
+}
+func (UnimplementedConfigServer) GetBulkUpdateJob(context.Context, *GetBulkUpdateJobRequest) (*BulkUpdateJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkUpdateJob not implemented")
+}
+func (UnimplementedConfigServer) GetConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigRollout not implemented")
+}
+func (UnimplementedConfigServer) CancelConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelConfigRollout not implemented")
+}
+func (UnimplementedConfigServer) ResumeConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeConfigRollout not implemented")
+}
+func (UnimplementedConfigServer) mustEmbedUnimplementedConfigServer() {}
+
+// UnsafeConfigServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConfigServer will
+// result in compilation errors.
+type UnsafeConfigServer interface {
+	mustEmbedUnimplementedConfigServer()
+}
+
+func RegisterConfigServer(s grpc.ServiceRegistrar, srv ConfigServer) {
+	s.RegisterService(&Config_ServiceDesc, srv)
+}
+
+func _Config_UpdateDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceConfig0)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).UpdateDeviceConfig0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/UpdateDeviceConfig0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).UpdateDeviceConfig0(ctx, req.(*DeviceConfig0))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_PatchDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDeviceConfigRequest0)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).PatchDeviceConfig0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/PatchDeviceConfig0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).PatchDeviceConfig0(ctx, req.(*PatchDeviceConfigRequest0))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_CreateConfigUpdateStream0_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigServer).CreateConfigUpdateStream0(&configCreateConfigUpdateStream0Server{stream})
+}
+
+type Config_CreateConfigUpdateStream0Server interface {
+	Send(*DeviceConfig0) error
+	Recv() (*ConfigUpdateReply, error)
+	grpc.ServerStream
+}
+
+type configCreateConfigUpdateStream0Server struct {
+	grpc.ServerStream
+}
+
+func (x *configCreateConfigUpdateStream0Server) Send(m *DeviceConfig0) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configCreateConfigUpdateStream0Server) Recv() (*ConfigUpdateReply, error) {
+	m := new(ConfigUpdateReply)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Config_CreateInitialConfigSaveStream0_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigServer).CreateInitialConfigSaveStream0(&configCreateInitialConfigSaveStream0Server{stream})
+}
+
+type Config_CreateInitialConfigSaveStream0Server interface {
+	SendAndClose(*ConfigServiceReply) error
+	Recv() (*DeviceConfig0, error)
+	grpc.ServerStream
+}
+
+type configCreateInitialConfigSaveStream0Server struct {
+	grpc.ServerStream
+}
+
+func (x *configCreateInitialConfigSaveStream0Server) SendAndClose(m *ConfigServiceReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configCreateInitialConfigSaveStream0Server) Recv() (*DeviceConfig0, error) {
+	m := new(DeviceConfig0)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Config_GetDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceConfig0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceConfig0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceConfig0(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListDeviceConfigs0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListDeviceConfigs0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListDeviceConfigs0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListDeviceConfigs0(ctx, req.(*ListDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_BatchGetDeviceConfigs0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).BatchGetDeviceConfigs0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/BatchGetDeviceConfigs0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).BatchGetDeviceConfigs0(ctx, req.(*BatchGetDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListDeviceConfigRevisions0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListDeviceConfigRevisions0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListDeviceConfigRevisions0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListDeviceConfigRevisions0(ctx, req.(*ListDeviceConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_DiffDeviceConfigRevisions0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffDeviceConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DiffDeviceConfigRevisions0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DiffDeviceConfigRevisions0(ctx, req.(*DiffDeviceConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_RollbackDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).RollbackDeviceConfig0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/RollbackDeviceConfig0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).RollbackDeviceConfig0(ctx, req.(*RollbackDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetDeviceShadow0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceShadow0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceShadow0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceShadow0(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_SetDeviceLabels0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceLabels)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).SetDeviceLabels0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/SetDeviceLabels0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).SetDeviceLabels0(ctx, req.(*DeviceLabels))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetDeviceLabels0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceLabels0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceLabels0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceLabels0(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_BulkUpdateDeviceConfig0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateDeviceConfigRequest0)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).BulkUpdateDeviceConfig0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).BulkUpdateDeviceConfig0(ctx, req.(*BulkUpdateDeviceConfigRequest0))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_CreateConfigRollout0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConfigRolloutRequest0)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).CreateConfigRollout0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/CreateConfigRollout0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).CreateConfigRollout0(ctx, req.(*CreateConfigRolloutRequest0))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_SetConfigTemplate0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceConfig0)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).SetConfigTemplate0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/SetConfigTemplate0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).SetConfigTemplate0(ctx, req.(*DeviceConfig0))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetConfigTemplate0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetConfigTemplate0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetConfigTemplate0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetConfigTemplate0(ctx, req.(*GetConfigTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_DeleteConfigTemplate0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DeleteConfigTemplate0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/DeleteConfigTemplate0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DeleteConfigTemplate0(ctx, req.(*GetConfigTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListConfigAuditRecords0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigAuditRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListConfigAuditRecords0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListConfigAuditRecords0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListConfigAuditRecords0(ctx, req.(*ListConfigAuditRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_SearchDeviceConfigs0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).SearchDeviceConfigs0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/SearchDeviceConfigs0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).SearchDeviceConfigs0(ctx, req.(*SearchDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_UpdateDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceConfig1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).UpdateDeviceConfig1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/UpdateDeviceConfig1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).UpdateDeviceConfig1(ctx, req.(*DeviceConfig1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_PatchDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDeviceConfigRequest1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).PatchDeviceConfig1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/PatchDeviceConfig1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).PatchDeviceConfig1(ctx, req.(*PatchDeviceConfigRequest1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_CreateConfigUpdateStream1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigServer).CreateConfigUpdateStream1(&configCreateConfigUpdateStream1Server{stream})
+}
+
+type Config_CreateConfigUpdateStream1Server interface {
+	Send(*DeviceConfig1) error
+	Recv() (*ConfigUpdateReply, error)
+	grpc.ServerStream
+}
+
+type configCreateConfigUpdateStream1Server struct {
+	grpc.ServerStream
+}
+
+func (x *configCreateConfigUpdateStream1Server) Send(m *DeviceConfig1) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configCreateConfigUpdateStream1Server) Recv() (*ConfigUpdateReply, error) {
+	m := new(ConfigUpdateReply)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Config_CreateInitialConfigSaveStream1_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigServer).CreateInitialConfigSaveStream1(&configCreateInitialConfigSaveStream1Server{stream})
+}
+
+type Config_CreateInitialConfigSaveStream1Server interface {
+	SendAndClose(*ConfigServiceReply) error
+	Recv() (*DeviceConfig1, error)
+	grpc.ServerStream
+}
+
+type configCreateInitialConfigSaveStream1Server struct {
+	grpc.ServerStream
+}
+
+func (x *configCreateInitialConfigSaveStream1Server) SendAndClose(m *ConfigServiceReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configCreateInitialConfigSaveStream1Server) Recv() (*DeviceConfig1, error) {
+	m := new(DeviceConfig1)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Config_GetDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceConfig1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceConfig1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceConfig1(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListDeviceConfigs1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListDeviceConfigs1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListDeviceConfigs1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListDeviceConfigs1(ctx, req.(*ListDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_BatchGetDeviceConfigs1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).BatchGetDeviceConfigs1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/BatchGetDeviceConfigs1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).BatchGetDeviceConfigs1(ctx, req.(*BatchGetDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListDeviceConfigRevisions1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListDeviceConfigRevisions1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListDeviceConfigRevisions1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListDeviceConfigRevisions1(ctx, req.(*ListDeviceConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_DiffDeviceConfigRevisions1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffDeviceConfigRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DiffDeviceConfigRevisions1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DiffDeviceConfigRevisions1(ctx, req.(*DiffDeviceConfigRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_RollbackDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).RollbackDeviceConfig1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/RollbackDeviceConfig1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).RollbackDeviceConfig1(ctx, req.(*RollbackDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetDeviceShadow1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceShadow1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceShadow1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceShadow1(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_SetDeviceLabels1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceLabels)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ConfigServer).UpdateDeviceConfig1(ctx, in)
+		return srv.(ConfigServer).SetDeviceLabels1(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.dataCollection.v1.Config/UpdateDeviceConfig1",
+		FullMethod: "/api.dataCollection.v1.Config/SetDeviceLabels1",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConfigServer).UpdateDeviceConfig1(ctx, req.(*DeviceConfig1))
+		return srv.(ConfigServer).SetDeviceLabels1(ctx, req.(*DeviceLabels))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Config_CreateConfigUpdateStream1_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ConfigServer).CreateConfigUpdateStream1(&configCreateConfigUpdateStream1Server{stream})
+func _Config_GetDeviceLabels1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDeviceLabels1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetDeviceLabels1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDeviceLabels1(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Config_CreateConfigUpdateStream1Server interface {
-	Send(*DeviceConfig1) error
-	Recv() (*ConfigUpdateReply, error)
-	grpc.ServerStream
+func _Config_BulkUpdateDeviceConfig1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateDeviceConfigRequest1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).BulkUpdateDeviceConfig1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).BulkUpdateDeviceConfig1(ctx, req.(*BulkUpdateDeviceConfigRequest1))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type configCreateConfigUpdateStream1Server struct {
-	grpc.ServerStream
+func _Config_CreateConfigRollout1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConfigRolloutRequest1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).CreateConfigRollout1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/CreateConfigRollout1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).CreateConfigRollout1(ctx, req.(*CreateConfigRolloutRequest1))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *configCreateConfigUpdateStream1Server) Send(m *DeviceConfig1) error {
-	return x.ServerStream.SendMsg(m)
+func _Config_SetConfigTemplate1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceConfig1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).SetConfigTemplate1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/SetConfigTemplate1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).SetConfigTemplate1(ctx, req.(*DeviceConfig1))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *configCreateConfigUpdateStream1Server) Recv() (*ConfigUpdateReply, error) {
-	m := new(ConfigUpdateReply)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Config_GetConfigTemplate1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ConfigServer).GetConfigTemplate1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetConfigTemplate1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetConfigTemplate1(ctx, req.(*GetConfigTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Config_CreateInitialConfigSaveStream1_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ConfigServer).CreateInitialConfigSaveStream1(&configCreateInitialConfigSaveStream1Server{stream})
+func _Config_DeleteConfigTemplate1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DeleteConfigTemplate1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/DeleteConfigTemplate1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DeleteConfigTemplate1(ctx, req.(*GetConfigTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Config_CreateInitialConfigSaveStream1Server interface {
-	SendAndClose(*ConfigServiceReply) error
-	Recv() (*DeviceConfig1, error)
-	grpc.ServerStream
+func _Config_ListConfigAuditRecords1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigAuditRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListConfigAuditRecords1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ListConfigAuditRecords1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListConfigAuditRecords1(ctx, req.(*ListConfigAuditRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type configCreateInitialConfigSaveStream1Server struct {
-	grpc.ServerStream
+func _Config_SearchDeviceConfigs1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchDeviceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).SearchDeviceConfigs1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/SearchDeviceConfigs1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).SearchDeviceConfigs1(ctx, req.(*SearchDeviceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *configCreateInitialConfigSaveStream1Server) SendAndClose(m *ConfigServiceReply) error {
-	return x.ServerStream.SendMsg(m)
+func _Config_GetConfigUpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigUpdateStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetConfigUpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetConfigUpdateStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetConfigUpdateStatus(ctx, req.(*GetConfigUpdateStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *configCreateInitialConfigSaveStream1Server) Recv() (*DeviceConfig1, error) {
-	m := new(DeviceConfig1)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Config_GetBulkUpdateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkUpdateJobRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ConfigServer).GetBulkUpdateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetBulkUpdateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetBulkUpdateJob(ctx, req.(*GetBulkUpdateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/GetConfigRollout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_CancelConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).CancelConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/CancelConfigRollout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).CancelConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ResumeConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ResumeConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.dataCollection.v1.Config/ResumeConfigRollout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ResumeConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 // Config_ServiceDesc is the grpc.ServiceDesc for Config service.
@@ -385,10 +1725,166 @@ var Config_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDeviceConfig0",
 			Handler:    _Config_UpdateDeviceConfig0_Handler,
 		},
+		{
+			MethodName: "PatchDeviceConfig0",
+			Handler:    _Config_PatchDeviceConfig0_Handler,
+		},
+		{
+			MethodName: "GetDeviceConfig0",
+			Handler:    _Config_GetDeviceConfig0_Handler,
+		},
+		{
+			MethodName: "ListDeviceConfigs0",
+			Handler:    _Config_ListDeviceConfigs0_Handler,
+		},
+		{
+			MethodName: "BatchGetDeviceConfigs0",
+			Handler:    _Config_BatchGetDeviceConfigs0_Handler,
+		},
+		{
+			MethodName: "ListDeviceConfigRevisions0",
+			Handler:    _Config_ListDeviceConfigRevisions0_Handler,
+		},
+		{
+			MethodName: "DiffDeviceConfigRevisions0",
+			Handler:    _Config_DiffDeviceConfigRevisions0_Handler,
+		},
+		{
+			MethodName: "RollbackDeviceConfig0",
+			Handler:    _Config_RollbackDeviceConfig0_Handler,
+		},
+		{
+			MethodName: "GetDeviceShadow0",
+			Handler:    _Config_GetDeviceShadow0_Handler,
+		},
+		{
+			MethodName: "SetDeviceLabels0",
+			Handler:    _Config_SetDeviceLabels0_Handler,
+		},
+		{
+			MethodName: "GetDeviceLabels0",
+			Handler:    _Config_GetDeviceLabels0_Handler,
+		},
+		{
+			MethodName: "BulkUpdateDeviceConfig0",
+			Handler:    _Config_BulkUpdateDeviceConfig0_Handler,
+		},
+		{
+			MethodName: "CreateConfigRollout0",
+			Handler:    _Config_CreateConfigRollout0_Handler,
+		},
+		{
+			MethodName: "SetConfigTemplate0",
+			Handler:    _Config_SetConfigTemplate0_Handler,
+		},
+		{
+			MethodName: "GetConfigTemplate0",
+			Handler:    _Config_GetConfigTemplate0_Handler,
+		},
+		{
+			MethodName: "DeleteConfigTemplate0",
+			Handler:    _Config_DeleteConfigTemplate0_Handler,
+		},
+		{
+			MethodName: "ListConfigAuditRecords0",
+			Handler:    _Config_ListConfigAuditRecords0_Handler,
+		},
+		{
+			MethodName: "SearchDeviceConfigs0",
+			Handler:    _Config_SearchDeviceConfigs0_Handler,
+		},
 		{
 			MethodName: "UpdateDeviceConfig1",
 			Handler:    _Config_UpdateDeviceConfig1_Handler,
 		},
+		{
+			MethodName: "PatchDeviceConfig1",
+			Handler:    _Config_PatchDeviceConfig1_Handler,
+		},
+		{
+			MethodName: "GetDeviceConfig1",
+			Handler:    _Config_GetDeviceConfig1_Handler,
+		},
+		{
+			MethodName: "ListDeviceConfigs1",
+			Handler:    _Config_ListDeviceConfigs1_Handler,
+		},
+		{
+			MethodName: "BatchGetDeviceConfigs1",
+			Handler:    _Config_BatchGetDeviceConfigs1_Handler,
+		},
+		{
+			MethodName: "ListDeviceConfigRevisions1",
+			Handler:    _Config_ListDeviceConfigRevisions1_Handler,
+		},
+		{
+			MethodName: "DiffDeviceConfigRevisions1",
+			Handler:    _Config_DiffDeviceConfigRevisions1_Handler,
+		},
+		{
+			MethodName: "RollbackDeviceConfig1",
+			Handler:    _Config_RollbackDeviceConfig1_Handler,
+		},
+		{
+			MethodName: "GetDeviceShadow1",
+			Handler:    _Config_GetDeviceShadow1_Handler,
+		},
+		{
+			MethodName: "SetDeviceLabels1",
+			Handler:    _Config_SetDeviceLabels1_Handler,
+		},
+		{
+			MethodName: "GetDeviceLabels1",
+			Handler:    _Config_GetDeviceLabels1_Handler,
+		},
+		{
+			MethodName: "BulkUpdateDeviceConfig1",
+			Handler:    _Config_BulkUpdateDeviceConfig1_Handler,
+		},
+		{
+			MethodName: "CreateConfigRollout1",
+			Handler:    _Config_CreateConfigRollout1_Handler,
+		},
+		{
+			MethodName: "SetConfigTemplate1",
+			Handler:    _Config_SetConfigTemplate1_Handler,
+		},
+		{
+			MethodName: "GetConfigTemplate1",
+			Handler:    _Config_GetConfigTemplate1_Handler,
+		},
+		{
+			MethodName: "DeleteConfigTemplate1",
+			Handler:    _Config_DeleteConfigTemplate1_Handler,
+		},
+		{
+			MethodName: "ListConfigAuditRecords1",
+			Handler:    _Config_ListConfigAuditRecords1_Handler,
+		},
+		{
+			MethodName: "SearchDeviceConfigs1",
+			Handler:    _Config_SearchDeviceConfigs1_Handler,
+		},
+		{
+			MethodName: "GetConfigUpdateStatus",
+			Handler:    _Config_GetConfigUpdateStatus_Handler,
+		},
+		{
+			MethodName: "GetBulkUpdateJob",
+			Handler:    _Config_GetBulkUpdateJob_Handler,
+		},
+		{
+			MethodName: "GetConfigRollout",
+			Handler:    _Config_GetConfigRollout_Handler,
+		},
+		{
+			MethodName: "CancelConfigRollout",
+			Handler:    _Config_CancelConfigRollout_Handler,
+		},
+		{
+			MethodName: "ResumeConfigRollout",
+			Handler:    _Config_ResumeConfigRollout_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -18,6 +18,45 @@ var _ = binding.EncodeURL
 const _ = http.SupportPackageIsVersion1
 
 type ConfigHTTPServer interface {
+	BatchGetDeviceConfigs0(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply0, error)
+	BatchGetDeviceConfigs1(context.Context, *BatchGetDeviceConfigsRequest) (*BatchGetDeviceConfigsReply1, error)
+	BulkUpdateDeviceConfig0(context.Context, *BulkUpdateDeviceConfigRequest0) (*BulkUpdateJob, error)
+	BulkUpdateDeviceConfig1(context.Context, *BulkUpdateDeviceConfigRequest1) (*BulkUpdateJob, error)
+	CancelConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	CreateConfigRollout0(context.Context, *CreateConfigRolloutRequest0) (*ConfigRollout, error)
+	CreateConfigRollout1(context.Context, *CreateConfigRolloutRequest1) (*ConfigRollout, error)
+	DeleteConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error)
+	DeleteConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*ConfigServiceReply, error)
+	DiffDeviceConfigRevisions0(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error)
+	DiffDeviceConfigRevisions1(context.Context, *DiffDeviceConfigRevisionsRequest) (*DiffDeviceConfigRevisionsReply, error)
+	GetBulkUpdateJob(context.Context, *GetBulkUpdateJobRequest) (*BulkUpdateJob, error)
+	GetConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	GetConfigTemplate0(context.Context, *GetConfigTemplateRequest) (*DeviceConfig0, error)
+	GetConfigTemplate1(context.Context, *GetConfigTemplateRequest) (*DeviceConfig1, error)
+	GetConfigUpdateStatus(context.Context, *GetConfigUpdateStatusRequest) (*ConfigUpdateStatus, error)
+	GetDeviceConfig0(context.Context, *GetDeviceConfigRequest) (*DeviceConfig0, error)
+	GetDeviceConfig1(context.Context, *GetDeviceConfigRequest) (*DeviceConfig1, error)
+	GetDeviceLabels0(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error)
+	GetDeviceLabels1(context.Context, *GetDeviceConfigRequest) (*DeviceLabels, error)
+	GetDeviceShadow0(context.Context, *GetDeviceConfigRequest) (*DeviceShadow0, error)
+	GetDeviceShadow1(context.Context, *GetDeviceConfigRequest) (*DeviceShadow1, error)
+	ListConfigAuditRecords0(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply0, error)
+	ListConfigAuditRecords1(context.Context, *ListConfigAuditRecordsRequest) (*ListConfigAuditRecordsReply1, error)
+	ListDeviceConfigRevisions0(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply0, error)
+	ListDeviceConfigRevisions1(context.Context, *ListDeviceConfigRevisionsRequest) (*ListDeviceConfigRevisionsReply1, error)
+	ListDeviceConfigs0(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply0, error)
+	ListDeviceConfigs1(context.Context, *ListDeviceConfigsRequest) (*ListDeviceConfigsReply1, error)
+	PatchDeviceConfig0(context.Context, *PatchDeviceConfigRequest0) (*ConfigServiceReply, error)
+	PatchDeviceConfig1(context.Context, *PatchDeviceConfigRequest1) (*ConfigServiceReply, error)
+	ResumeConfigRollout(context.Context, *GetConfigRolloutRequest) (*ConfigRollout, error)
+	RollbackDeviceConfig0(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error)
+	RollbackDeviceConfig1(context.Context, *RollbackDeviceConfigRequest) (*ConfigServiceReply, error)
+	SearchDeviceConfigs0(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply0, error)
+	SearchDeviceConfigs1(context.Context, *SearchDeviceConfigsRequest) (*ListDeviceConfigsReply1, error)
+	SetConfigTemplate0(context.Context, *DeviceConfig0) (*DeviceConfig0, error)
+	SetConfigTemplate1(context.Context, *DeviceConfig1) (*DeviceConfig1, error)
+	SetDeviceLabels0(context.Context, *DeviceLabels) (*DeviceLabels, error)
+	SetDeviceLabels1(context.Context, *DeviceLabels) (*DeviceLabels, error)
 	UpdateDeviceConfig0(context.Context, *DeviceConfig0) (*ConfigServiceReply, error)
 	UpdateDeviceConfig1(context.Context, *DeviceConfig1) (*ConfigServiceReply, error)
 }
@@ -25,7 +64,46 @@ type ConfigHTTPServer interface {
 func RegisterConfigHTTPServer(s *http.Server, srv ConfigHTTPServer) {
 	r := s.Route("/")
 	r.POST("/configs/0", _Config_UpdateDeviceConfig00_HTTP_Handler(srv))
+	r.PATCH("/configs/0/{config.id}", _Config_PatchDeviceConfig00_HTTP_Handler(srv))
+	r.GET("/configs/0/{id}", _Config_GetDeviceConfig00_HTTP_Handler(srv))
+	r.GET("/configs/0", _Config_ListDeviceConfigs00_HTTP_Handler(srv))
+	r.POST("/configs/0/batch", _Config_BatchGetDeviceConfigs00_HTTP_Handler(srv))
+	r.GET("/configs/0/{id}/revisions", _Config_ListDeviceConfigRevisions00_HTTP_Handler(srv))
+	r.GET("/configs/0/{id}/revisions/diff", _Config_DiffDeviceConfigRevisions00_HTTP_Handler(srv))
+	r.POST("/configs/0/{id}/rollback", _Config_RollbackDeviceConfig00_HTTP_Handler(srv))
+	r.GET("/configs/0/{id}/shadow", _Config_GetDeviceShadow00_HTTP_Handler(srv))
+	r.PUT("/configs/0/{id}/labels", _Config_SetDeviceLabels00_HTTP_Handler(srv))
+	r.GET("/configs/0/{id}/labels", _Config_GetDeviceLabels00_HTTP_Handler(srv))
+	r.POST("/configs/0/bulk", _Config_BulkUpdateDeviceConfig00_HTTP_Handler(srv))
+	r.POST("/configs/0/rollouts", _Config_CreateConfigRollout00_HTTP_Handler(srv))
+	r.PUT("/configs/templates/0", _Config_SetConfigTemplate00_HTTP_Handler(srv))
+	r.GET("/configs/templates/0", _Config_GetConfigTemplate00_HTTP_Handler(srv))
+	r.DELETE("/configs/templates/0", _Config_DeleteConfigTemplate00_HTTP_Handler(srv))
+	r.GET("/configs/audit/0", _Config_ListConfigAuditRecords00_HTTP_Handler(srv))
+	r.GET("/configs/search/0", _Config_SearchDeviceConfigs00_HTTP_Handler(srv))
 	r.POST("/configs/1", _Config_UpdateDeviceConfig10_HTTP_Handler(srv))
+	r.PATCH("/configs/1/{config.id}", _Config_PatchDeviceConfig10_HTTP_Handler(srv))
+	r.GET("/configs/1/{id}", _Config_GetDeviceConfig10_HTTP_Handler(srv))
+	r.GET("/configs/1", _Config_ListDeviceConfigs10_HTTP_Handler(srv))
+	r.POST("/configs/1/batch", _Config_BatchGetDeviceConfigs10_HTTP_Handler(srv))
+	r.GET("/configs/1/{id}/revisions", _Config_ListDeviceConfigRevisions10_HTTP_Handler(srv))
+	r.GET("/configs/1/{id}/revisions/diff", _Config_DiffDeviceConfigRevisions10_HTTP_Handler(srv))
+	r.POST("/configs/1/{id}/rollback", _Config_RollbackDeviceConfig10_HTTP_Handler(srv))
+	r.GET("/configs/1/{id}/shadow", _Config_GetDeviceShadow10_HTTP_Handler(srv))
+	r.PUT("/configs/1/{id}/labels", _Config_SetDeviceLabels10_HTTP_Handler(srv))
+	r.GET("/configs/1/{id}/labels", _Config_GetDeviceLabels10_HTTP_Handler(srv))
+	r.POST("/configs/1/bulk", _Config_BulkUpdateDeviceConfig10_HTTP_Handler(srv))
+	r.POST("/configs/1/rollouts", _Config_CreateConfigRollout10_HTTP_Handler(srv))
+	r.PUT("/configs/templates/1", _Config_SetConfigTemplate10_HTTP_Handler(srv))
+	r.GET("/configs/templates/1", _Config_GetConfigTemplate10_HTTP_Handler(srv))
+	r.DELETE("/configs/templates/1", _Config_DeleteConfigTemplate10_HTTP_Handler(srv))
+	r.GET("/configs/audit/1", _Config_ListConfigAuditRecords10_HTTP_Handler(srv))
+	r.GET("/configs/search/1", _Config_SearchDeviceConfigs10_HTTP_Handler(srv))
+	r.GET("/configs/updates/{update_id}", _Config_GetConfigUpdateStatus0_HTTP_Handler(srv))
+	r.GET("/configs/bulk/{job_id}", _Config_GetBulkUpdateJob0_HTTP_Handler(srv))
+	r.GET("/configs/rollouts/{rollout_id}", _Config_GetConfigRollout0_HTTP_Handler(srv))
+	r.POST("/configs/rollouts/{rollout_id}/cancel", _Config_CancelConfigRollout0_HTTP_Handler(srv))
+	r.POST("/configs/rollouts/{rollout_id}/resume", _Config_ResumeConfigRollout0_HTTP_Handler(srv))
 }
 
 func _Config_UpdateDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
@@ -47,15 +125,18 @@ func _Config_UpdateDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx ht
 	}
 }
 
-func _Config_UpdateDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+func _Config_PatchDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in DeviceConfig1
+		var in PatchDeviceConfigRequest0
 		if err := ctx.Bind(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, "/api.dataCollection.v1.Config/UpdateDeviceConfig1")
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/PatchDeviceConfig0")
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.UpdateDeviceConfig1(ctx, req.(*DeviceConfig1))
+			return srv.PatchDeviceConfig0(ctx, req.(*PatchDeviceConfigRequest0))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
@@ -66,17 +147,1364 @@ func _Config_UpdateDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx ht
 	}
 }
 
-type ConfigHTTPClient interface {
-	UpdateDeviceConfig0(ctx context.Context, req *DeviceConfig0, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
-	UpdateDeviceConfig1(ctx context.Context, req *DeviceConfig1, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+func _Config_GetDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceConfig0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceConfig0(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig0)
+		return ctx.Result(200, reply)
+	}
 }
 
-type ConfigHTTPClientImpl struct {
-	cc *http.Client
+func _Config_ListDeviceConfigs00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListDeviceConfigsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigs0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeviceConfigs0(ctx, req.(*ListDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigsReply0)
+		return ctx.Result(200, reply)
+	}
 }
 
-func NewConfigHTTPClient(client *http.Client) ConfigHTTPClient {
-	return &ConfigHTTPClientImpl{client}
+func _Config_BatchGetDeviceConfigs00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BatchGetDeviceConfigsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/BatchGetDeviceConfigs0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchGetDeviceConfigs0(ctx, req.(*BatchGetDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BatchGetDeviceConfigsReply0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ListDeviceConfigRevisions00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListDeviceConfigRevisionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigRevisions0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeviceConfigRevisions0(ctx, req.(*ListDeviceConfigRevisionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigRevisionsReply0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_DiffDeviceConfigRevisions00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DiffDeviceConfigRevisionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DiffDeviceConfigRevisions0(ctx, req.(*DiffDeviceConfigRevisionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DiffDeviceConfigRevisionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_RollbackDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RollbackDeviceConfigRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/RollbackDeviceConfig0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RollbackDeviceConfig0(ctx, req.(*RollbackDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetDeviceShadow00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceShadow0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceShadow0(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceShadow0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SetDeviceLabels00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeviceLabels
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SetDeviceLabels0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetDeviceLabels0(ctx, req.(*DeviceLabels))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceLabels)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetDeviceLabels00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceLabels0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceLabels0(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceLabels)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_BulkUpdateDeviceConfig00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BulkUpdateDeviceConfigRequest0
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BulkUpdateDeviceConfig0(ctx, req.(*BulkUpdateDeviceConfigRequest0))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BulkUpdateJob)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_CreateConfigRollout00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateConfigRolloutRequest0
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/CreateConfigRollout0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateConfigRollout0(ctx, req.(*CreateConfigRolloutRequest0))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigRollout)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SetConfigTemplate00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeviceConfig0
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SetConfigTemplate0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetConfigTemplate0(ctx, req.(*DeviceConfig0))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetConfigTemplate00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigTemplateRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetConfigTemplate0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetConfigTemplate0(ctx, req.(*GetConfigTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_DeleteConfigTemplate00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigTemplateRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/DeleteConfigTemplate0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteConfigTemplate0(ctx, req.(*GetConfigTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ListConfigAuditRecords00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListConfigAuditRecordsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListConfigAuditRecords0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListConfigAuditRecords0(ctx, req.(*ListConfigAuditRecordsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListConfigAuditRecordsReply0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SearchDeviceConfigs00_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SearchDeviceConfigsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SearchDeviceConfigs0")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SearchDeviceConfigs0(ctx, req.(*SearchDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigsReply0)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_UpdateDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeviceConfig1
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/UpdateDeviceConfig1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateDeviceConfig1(ctx, req.(*DeviceConfig1))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_PatchDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PatchDeviceConfigRequest1
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/PatchDeviceConfig1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PatchDeviceConfig1(ctx, req.(*PatchDeviceConfigRequest1))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceConfig1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceConfig1(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ListDeviceConfigs10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListDeviceConfigsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigs1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeviceConfigs1(ctx, req.(*ListDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigsReply1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_BatchGetDeviceConfigs10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BatchGetDeviceConfigsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/BatchGetDeviceConfigs1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchGetDeviceConfigs1(ctx, req.(*BatchGetDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BatchGetDeviceConfigsReply1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ListDeviceConfigRevisions10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListDeviceConfigRevisionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListDeviceConfigRevisions1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeviceConfigRevisions1(ctx, req.(*ListDeviceConfigRevisionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigRevisionsReply1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_DiffDeviceConfigRevisions10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DiffDeviceConfigRevisionsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/DiffDeviceConfigRevisions1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DiffDeviceConfigRevisions1(ctx, req.(*DiffDeviceConfigRevisionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DiffDeviceConfigRevisionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_RollbackDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RollbackDeviceConfigRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/RollbackDeviceConfig1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RollbackDeviceConfig1(ctx, req.(*RollbackDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetDeviceShadow10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceShadow1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceShadow1(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceShadow1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SetDeviceLabels10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeviceLabels
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SetDeviceLabels1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetDeviceLabels1(ctx, req.(*DeviceLabels))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceLabels)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetDeviceLabels10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDeviceConfigRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetDeviceLabels1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDeviceLabels1(ctx, req.(*GetDeviceConfigRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceLabels)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_BulkUpdateDeviceConfig10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BulkUpdateDeviceConfigRequest1
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/BulkUpdateDeviceConfig1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BulkUpdateDeviceConfig1(ctx, req.(*BulkUpdateDeviceConfigRequest1))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BulkUpdateJob)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_CreateConfigRollout10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateConfigRolloutRequest1
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/CreateConfigRollout1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateConfigRollout1(ctx, req.(*CreateConfigRolloutRequest1))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigRollout)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SetConfigTemplate10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeviceConfig1
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SetConfigTemplate1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetConfigTemplate1(ctx, req.(*DeviceConfig1))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetConfigTemplate10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigTemplateRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetConfigTemplate1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetConfigTemplate1(ctx, req.(*GetConfigTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeviceConfig1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_DeleteConfigTemplate10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigTemplateRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/DeleteConfigTemplate1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteConfigTemplate1(ctx, req.(*GetConfigTemplateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigServiceReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ListConfigAuditRecords10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListConfigAuditRecordsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ListConfigAuditRecords1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListConfigAuditRecords1(ctx, req.(*ListConfigAuditRecordsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListConfigAuditRecordsReply1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_SearchDeviceConfigs10_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SearchDeviceConfigsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/SearchDeviceConfigs1")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SearchDeviceConfigs1(ctx, req.(*SearchDeviceConfigsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeviceConfigsReply1)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetConfigUpdateStatus0_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigUpdateStatusRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetConfigUpdateStatus")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetConfigUpdateStatus(ctx, req.(*GetConfigUpdateStatusRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigUpdateStatus)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetBulkUpdateJob0_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetBulkUpdateJobRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetBulkUpdateJob")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBulkUpdateJob(ctx, req.(*GetBulkUpdateJobRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BulkUpdateJob)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_GetConfigRollout0_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigRolloutRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/GetConfigRollout")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigRollout)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_CancelConfigRollout0_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigRolloutRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/CancelConfigRollout")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CancelConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigRollout)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Config_ResumeConfigRollout0_HTTP_Handler(srv ConfigHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConfigRolloutRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, "/api.dataCollection.v1.Config/ResumeConfigRollout")
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResumeConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfigRollout)
+		return ctx.Result(200, reply)
+	}
+}
+
+type ConfigHTTPClient interface {
+	BatchGetDeviceConfigs0(ctx context.Context, req *BatchGetDeviceConfigsRequest, opts ...http.CallOption) (rsp *BatchGetDeviceConfigsReply0, err error)
+	BatchGetDeviceConfigs1(ctx context.Context, req *BatchGetDeviceConfigsRequest, opts ...http.CallOption) (rsp *BatchGetDeviceConfigsReply1, err error)
+	BulkUpdateDeviceConfig0(ctx context.Context, req *BulkUpdateDeviceConfigRequest0, opts ...http.CallOption) (rsp *BulkUpdateJob, err error)
+	BulkUpdateDeviceConfig1(ctx context.Context, req *BulkUpdateDeviceConfigRequest1, opts ...http.CallOption) (rsp *BulkUpdateJob, err error)
+	CancelConfigRollout(ctx context.Context, req *GetConfigRolloutRequest, opts ...http.CallOption) (rsp *ConfigRollout, err error)
+	CreateConfigRollout0(ctx context.Context, req *CreateConfigRolloutRequest0, opts ...http.CallOption) (rsp *ConfigRollout, err error)
+	CreateConfigRollout1(ctx context.Context, req *CreateConfigRolloutRequest1, opts ...http.CallOption) (rsp *ConfigRollout, err error)
+	DeleteConfigTemplate0(ctx context.Context, req *GetConfigTemplateRequest, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	DeleteConfigTemplate1(ctx context.Context, req *GetConfigTemplateRequest, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	DiffDeviceConfigRevisions0(ctx context.Context, req *DiffDeviceConfigRevisionsRequest, opts ...http.CallOption) (rsp *DiffDeviceConfigRevisionsReply, err error)
+	DiffDeviceConfigRevisions1(ctx context.Context, req *DiffDeviceConfigRevisionsRequest, opts ...http.CallOption) (rsp *DiffDeviceConfigRevisionsReply, err error)
+	GetBulkUpdateJob(ctx context.Context, req *GetBulkUpdateJobRequest, opts ...http.CallOption) (rsp *BulkUpdateJob, err error)
+	GetConfigRollout(ctx context.Context, req *GetConfigRolloutRequest, opts ...http.CallOption) (rsp *ConfigRollout, err error)
+	GetConfigTemplate0(ctx context.Context, req *GetConfigTemplateRequest, opts ...http.CallOption) (rsp *DeviceConfig0, err error)
+	GetConfigTemplate1(ctx context.Context, req *GetConfigTemplateRequest, opts ...http.CallOption) (rsp *DeviceConfig1, err error)
+	GetConfigUpdateStatus(ctx context.Context, req *GetConfigUpdateStatusRequest, opts ...http.CallOption) (rsp *ConfigUpdateStatus, err error)
+	GetDeviceConfig0(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceConfig0, err error)
+	GetDeviceConfig1(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceConfig1, err error)
+	GetDeviceLabels0(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceLabels, err error)
+	GetDeviceLabels1(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceLabels, err error)
+	GetDeviceShadow0(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceShadow0, err error)
+	GetDeviceShadow1(ctx context.Context, req *GetDeviceConfigRequest, opts ...http.CallOption) (rsp *DeviceShadow1, err error)
+	ListConfigAuditRecords0(ctx context.Context, req *ListConfigAuditRecordsRequest, opts ...http.CallOption) (rsp *ListConfigAuditRecordsReply0, err error)
+	ListConfigAuditRecords1(ctx context.Context, req *ListConfigAuditRecordsRequest, opts ...http.CallOption) (rsp *ListConfigAuditRecordsReply1, err error)
+	ListDeviceConfigRevisions0(ctx context.Context, req *ListDeviceConfigRevisionsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigRevisionsReply0, err error)
+	ListDeviceConfigRevisions1(ctx context.Context, req *ListDeviceConfigRevisionsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigRevisionsReply1, err error)
+	ListDeviceConfigs0(ctx context.Context, req *ListDeviceConfigsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigsReply0, err error)
+	ListDeviceConfigs1(ctx context.Context, req *ListDeviceConfigsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigsReply1, err error)
+	PatchDeviceConfig0(ctx context.Context, req *PatchDeviceConfigRequest0, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	PatchDeviceConfig1(ctx context.Context, req *PatchDeviceConfigRequest1, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	ResumeConfigRollout(ctx context.Context, req *GetConfigRolloutRequest, opts ...http.CallOption) (rsp *ConfigRollout, err error)
+	RollbackDeviceConfig0(ctx context.Context, req *RollbackDeviceConfigRequest, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	RollbackDeviceConfig1(ctx context.Context, req *RollbackDeviceConfigRequest, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	SearchDeviceConfigs0(ctx context.Context, req *SearchDeviceConfigsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigsReply0, err error)
+	SearchDeviceConfigs1(ctx context.Context, req *SearchDeviceConfigsRequest, opts ...http.CallOption) (rsp *ListDeviceConfigsReply1, err error)
+	SetConfigTemplate0(ctx context.Context, req *DeviceConfig0, opts ...http.CallOption) (rsp *DeviceConfig0, err error)
+	SetConfigTemplate1(ctx context.Context, req *DeviceConfig1, opts ...http.CallOption) (rsp *DeviceConfig1, err error)
+	SetDeviceLabels0(ctx context.Context, req *DeviceLabels, opts ...http.CallOption) (rsp *DeviceLabels, err error)
+	SetDeviceLabels1(ctx context.Context, req *DeviceLabels, opts ...http.CallOption) (rsp *DeviceLabels, err error)
+	UpdateDeviceConfig0(ctx context.Context, req *DeviceConfig0, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+	UpdateDeviceConfig1(ctx context.Context, req *DeviceConfig1, opts ...http.CallOption) (rsp *ConfigServiceReply, err error)
+}
+
+type ConfigHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewConfigHTTPClient(client *http.Client) ConfigHTTPClient {
+	return &ConfigHTTPClientImpl{client}
+}
+
+func (c *ConfigHTTPClientImpl) BatchGetDeviceConfigs0(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...http.CallOption) (*BatchGetDeviceConfigsReply0, error) {
+	var out BatchGetDeviceConfigsReply0
+	pattern := "/configs/0/batch"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/BatchGetDeviceConfigs0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) BatchGetDeviceConfigs1(ctx context.Context, in *BatchGetDeviceConfigsRequest, opts ...http.CallOption) (*BatchGetDeviceConfigsReply1, error) {
+	var out BatchGetDeviceConfigsReply1
+	pattern := "/configs/1/batch"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/BatchGetDeviceConfigs1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) BulkUpdateDeviceConfig0(ctx context.Context, in *BulkUpdateDeviceConfigRequest0, opts ...http.CallOption) (*BulkUpdateJob, error) {
+	var out BulkUpdateJob
+	pattern := "/configs/0/bulk"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/BulkUpdateDeviceConfig0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) BulkUpdateDeviceConfig1(ctx context.Context, in *BulkUpdateDeviceConfigRequest1, opts ...http.CallOption) (*BulkUpdateJob, error) {
+	var out BulkUpdateJob
+	pattern := "/configs/1/bulk"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/BulkUpdateDeviceConfig1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) CancelConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...http.CallOption) (*ConfigRollout, error) {
+	var out ConfigRollout
+	pattern := "/configs/rollouts/{rollout_id}/cancel"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/CancelConfigRollout"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) CreateConfigRollout0(ctx context.Context, in *CreateConfigRolloutRequest0, opts ...http.CallOption) (*ConfigRollout, error) {
+	var out ConfigRollout
+	pattern := "/configs/0/rollouts"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/CreateConfigRollout0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) CreateConfigRollout1(ctx context.Context, in *CreateConfigRolloutRequest1, opts ...http.CallOption) (*ConfigRollout, error) {
+	var out ConfigRollout
+	pattern := "/configs/1/rollouts"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/CreateConfigRollout1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) DeleteConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/templates/0"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/DeleteConfigTemplate0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) DeleteConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/templates/1"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/DeleteConfigTemplate1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) DiffDeviceConfigRevisions0(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...http.CallOption) (*DiffDeviceConfigRevisionsReply, error) {
+	var out DiffDeviceConfigRevisionsReply
+	pattern := "/configs/0/{id}/revisions/diff"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/DiffDeviceConfigRevisions0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) DiffDeviceConfigRevisions1(ctx context.Context, in *DiffDeviceConfigRevisionsRequest, opts ...http.CallOption) (*DiffDeviceConfigRevisionsReply, error) {
+	var out DiffDeviceConfigRevisionsReply
+	pattern := "/configs/1/{id}/revisions/diff"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/DiffDeviceConfigRevisions1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetBulkUpdateJob(ctx context.Context, in *GetBulkUpdateJobRequest, opts ...http.CallOption) (*BulkUpdateJob, error) {
+	var out BulkUpdateJob
+	pattern := "/configs/bulk/{job_id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetBulkUpdateJob"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...http.CallOption) (*ConfigRollout, error) {
+	var out ConfigRollout
+	pattern := "/configs/rollouts/{rollout_id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetConfigRollout"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetConfigTemplate0(ctx context.Context, in *GetConfigTemplateRequest, opts ...http.CallOption) (*DeviceConfig0, error) {
+	var out DeviceConfig0
+	pattern := "/configs/templates/0"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetConfigTemplate0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetConfigTemplate1(ctx context.Context, in *GetConfigTemplateRequest, opts ...http.CallOption) (*DeviceConfig1, error) {
+	var out DeviceConfig1
+	pattern := "/configs/templates/1"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetConfigTemplate1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetConfigUpdateStatus(ctx context.Context, in *GetConfigUpdateStatusRequest, opts ...http.CallOption) (*ConfigUpdateStatus, error) {
+	var out ConfigUpdateStatus
+	pattern := "/configs/updates/{update_id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetConfigUpdateStatus"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceConfig0(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceConfig0, error) {
+	var out DeviceConfig0
+	pattern := "/configs/0/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceConfig0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceConfig1(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceConfig1, error) {
+	var out DeviceConfig1
+	pattern := "/configs/1/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceConfig1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceLabels0(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceLabels, error) {
+	var out DeviceLabels
+	pattern := "/configs/0/{id}/labels"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceLabels0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceLabels1(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceLabels, error) {
+	var out DeviceLabels
+	pattern := "/configs/1/{id}/labels"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceLabels1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceShadow0(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceShadow0, error) {
+	var out DeviceShadow0
+	pattern := "/configs/0/{id}/shadow"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceShadow0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) GetDeviceShadow1(ctx context.Context, in *GetDeviceConfigRequest, opts ...http.CallOption) (*DeviceShadow1, error) {
+	var out DeviceShadow1
+	pattern := "/configs/1/{id}/shadow"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/GetDeviceShadow1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListConfigAuditRecords0(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...http.CallOption) (*ListConfigAuditRecordsReply0, error) {
+	var out ListConfigAuditRecordsReply0
+	pattern := "/configs/audit/0"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListConfigAuditRecords0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListConfigAuditRecords1(ctx context.Context, in *ListConfigAuditRecordsRequest, opts ...http.CallOption) (*ListConfigAuditRecordsReply1, error) {
+	var out ListConfigAuditRecordsReply1
+	pattern := "/configs/audit/1"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListConfigAuditRecords1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListDeviceConfigRevisions0(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...http.CallOption) (*ListDeviceConfigRevisionsReply0, error) {
+	var out ListDeviceConfigRevisionsReply0
+	pattern := "/configs/0/{id}/revisions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListDeviceConfigRevisions0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListDeviceConfigRevisions1(ctx context.Context, in *ListDeviceConfigRevisionsRequest, opts ...http.CallOption) (*ListDeviceConfigRevisionsReply1, error) {
+	var out ListDeviceConfigRevisionsReply1
+	pattern := "/configs/1/{id}/revisions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListDeviceConfigRevisions1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListDeviceConfigs0(ctx context.Context, in *ListDeviceConfigsRequest, opts ...http.CallOption) (*ListDeviceConfigsReply0, error) {
+	var out ListDeviceConfigsReply0
+	pattern := "/configs/0"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListDeviceConfigs0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ListDeviceConfigs1(ctx context.Context, in *ListDeviceConfigsRequest, opts ...http.CallOption) (*ListDeviceConfigsReply1, error) {
+	var out ListDeviceConfigsReply1
+	pattern := "/configs/1"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ListDeviceConfigs1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) PatchDeviceConfig0(ctx context.Context, in *PatchDeviceConfigRequest0, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/0/{config.id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/PatchDeviceConfig0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PATCH", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) PatchDeviceConfig1(ctx context.Context, in *PatchDeviceConfigRequest1, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/1/{config.id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/PatchDeviceConfig1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PATCH", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) ResumeConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...http.CallOption) (*ConfigRollout, error) {
+	var out ConfigRollout
+	pattern := "/configs/rollouts/{rollout_id}/resume"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/ResumeConfigRollout"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) RollbackDeviceConfig0(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/0/{id}/rollback"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/RollbackDeviceConfig0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) RollbackDeviceConfig1(ctx context.Context, in *RollbackDeviceConfigRequest, opts ...http.CallOption) (*ConfigServiceReply, error) {
+	var out ConfigServiceReply
+	pattern := "/configs/1/{id}/rollback"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/RollbackDeviceConfig1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SearchDeviceConfigs0(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...http.CallOption) (*ListDeviceConfigsReply0, error) {
+	var out ListDeviceConfigsReply0
+	pattern := "/configs/search/0"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SearchDeviceConfigs0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SearchDeviceConfigs1(ctx context.Context, in *SearchDeviceConfigsRequest, opts ...http.CallOption) (*ListDeviceConfigsReply1, error) {
+	var out ListDeviceConfigsReply1
+	pattern := "/configs/search/1"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SearchDeviceConfigs1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SetConfigTemplate0(ctx context.Context, in *DeviceConfig0, opts ...http.CallOption) (*DeviceConfig0, error) {
+	var out DeviceConfig0
+	pattern := "/configs/templates/0"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SetConfigTemplate0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SetConfigTemplate1(ctx context.Context, in *DeviceConfig1, opts ...http.CallOption) (*DeviceConfig1, error) {
+	var out DeviceConfig1
+	pattern := "/configs/templates/1"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SetConfigTemplate1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SetDeviceLabels0(ctx context.Context, in *DeviceLabels, opts ...http.CallOption) (*DeviceLabels, error) {
+	var out DeviceLabels
+	pattern := "/configs/0/{id}/labels"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SetDeviceLabels0"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *ConfigHTTPClientImpl) SetDeviceLabels1(ctx context.Context, in *DeviceLabels, opts ...http.CallOption) (*DeviceLabels, error) {
+	var out DeviceLabels
+	pattern := "/configs/1/{id}/labels"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation("/api.dataCollection.v1.Config/SetDeviceLabels1"))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
 }
 
 func (c *ConfigHTTPClientImpl) UpdateDeviceConfig0(ctx context.Context, in *DeviceConfig0, opts ...http.CallOption) (*ConfigServiceReply, error) {
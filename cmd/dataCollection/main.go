@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	util "gitee.com/moyusir/util/logger"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
@@ -54,6 +55,12 @@ func main() {
 	logger := util.NewJsonZapLoggerWarpper(Name, bc.LogLevel)
 	helper := log.NewHelper(logger)
 
+	shutdownTracer, err := monitor.InitTracer(bc.Trace, Name, Version, id)
+	if err != nil {
+		helper.Fatalf("初始化链路追踪时发生了错误:%v", err)
+	}
+	defer shutdownTracer()
+
 	app, cleanup, err := initApp(bc.Server, bc.Data, logger)
 	if err != nil {
 		helper.Fatalf("应用初始化时发生了错误:%v", err)
@@ -29,21 +29,56 @@ func initApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 		cleanup()
 		return nil, nil, err
 	}
-	unionRepo := data.NewRepo(redisData, influxdbData, logger)
-	configUsecase := biz.NewConfigUsecase(unionRepo, logger)
-	deviceConfigUpdater := biz.NewDeviceConfigUpdater(unionRepo, logger)
-	configService, err := service.NewConfigService(configUsecase, deviceConfigUpdater, logger)
+	remoteWriteData, cleanup3, err := data.NewRemoteWriteData(confData, logger)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	httpServer := server.NewHTTPServer(confServer, configService, logger)
-	warningDetectUsecase := biz.NewWarningDetectUsecase(unionRepo, logger)
-	warningDetectService := service.NewWarningDetectService(warningDetectUsecase, deviceConfigUpdater, logger)
-	grpcServer := server.NewGRPCServer(confServer, configService, warningDetectService, logger)
+	configWebhookData, cleanup4, err := data.NewConfigWebhookData(confData, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	configCipher, err := data.NewConfigCipher(confData)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	unionRepo := data.NewRepo(redisData, influxdbData, remoteWriteData, configWebhookData, configCipher, logger)
+	deviceConfigUpdater := biz.NewDeviceConfigUpdater(confData, unionRepo, logger)
+	configUsecase, cleanup5 := biz.NewConfigUsecase(confData, unionRepo, deviceConfigUpdater, logger)
+	rolloutUsecase, cleanup6 := biz.NewRolloutUsecase(confData, unionRepo, configUsecase, logger)
+	configService, err := service.NewConfigService(configUsecase, deviceConfigUpdater, rolloutUsecase, logger)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	deviceReadingCache := biz.NewDeviceReadingCache(confServer)
+	deviceMetricsService := service.NewDeviceMetricsService(confServer, deviceReadingCache, logger)
+	healthUsecase, cleanup7 := biz.NewHealthUsecase(confServer, unionRepo, logger)
+	healthService := service.NewHealthService(healthUsecase, logger)
+	httpServer := server.NewHTTPServer(confServer, configService, deviceMetricsService, healthService, logger)
+	warningDetectUsecase := biz.NewWarningDetectUsecase(confData, unionRepo, deviceReadingCache, logger)
+	warningDetectService := service.NewWarningDetectService(warningDetectUsecase, configUsecase, deviceConfigUpdater, logger)
+	grpcServer := server.NewGRPCServer(confServer, configService, warningDetectService, healthService, logger)
 	app := newApp(logger, httpServer, grpcServer)
 	return app, func() {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"google.golang.org/protobuf/proto"
+)
+
+// backupFormatVersion 备份文件格式的版本号，格式发生不兼容的变化时递增
+const backupFormatVersion = 1
+
+// 单行备份记录的最大长度，设备保留的修订较多时单行可能较长
+const maxBackupLineSize = 64 << 20
+
+// deviceClasses 用户的设备类别号以及相应的设备配置，代码生成时注入
+var deviceClasses = map[int]proto.Message{
+	0: new(v1.DeviceConfig0),
+	1: new(v1.DeviceConfig1),
+}
+
+// backupHeader 备份文件的第一行，记录备份的格式版本以及所属的用户
+type backupHeader struct {
+	Version    int       `json:"version"`
+	Username   string    `json:"username"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+// backupDevice 备份文件中除第一行以外的每一行，保存一个设备的配置备份，设备配置以base64编码的protobuf二进制信息保存
+type backupDevice struct {
+	DeviceClassID   int               `json:"deviceClassID"`
+	DeviceID        string            `json:"deviceID"`
+	Reported        []byte            `json:"reported,omitempty"`
+	Desired         []byte            `json:"desired,omitempty"`
+	DesiredRevision int64             `json:"desiredRevision,omitempty"`
+	Version         int64             `json:"version,omitempty"`
+	Seq             int64             `json:"seq,omitempty"`
+	ClientID        string            `json:"clientID,omitempty"`
+	Revisions       []*backupRevision `json:"revisions,omitempty"`
+}
+
+type backupRevision struct {
+	Version  int64     `json:"version"`
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	ClientID string    `json:"clientID,omitempty"`
+	Config   []byte    `json:"config"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// exportConfigs 将用户全部设备类别(deviceClassID不为-1时为指定设备类别)下的设备配置导出到path，
+// path为-时输出到标准输出
+func exportConfigs(ctx context.Context, uc *biz.ConfigUsecase, path string, deviceClassID int) (err error) {
+	classes, err := selectDeviceClasses(deviceClassID)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+
+	err = encoder.Encode(&backupHeader{Version: backupFormatVersion, Username: conf.Username, ExportedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	var total int
+	for _, classID := range classes {
+		err := uc.ExportDeviceConfigs(ctx, classID, func(backup *biz.DeviceConfigBackup) error {
+			total++
+			return encoder.Encode(newBackupDevice(backup))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "导出了%d个设备的配置\n", total)
+	return nil
+}
+
+// importConfigs 从path(为-时从标准输入)恢复设备配置，republish为true时恢复后将desired配置重新下发给设备
+func importConfigs(ctx context.Context, uc *biz.ConfigUsecase, path string, deviceClassID int, republish bool) error {
+	classes, err := selectDeviceClasses(deviceClassID)
+	if err != nil {
+		return err
+	}
+	selected := make(map[int]bool, len(classes))
+	for _, id := range classes {
+		selected[id] = true
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBackupLineSize)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("备份文件为空")
+	}
+	var header backupHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("解析备份文件头时发生了错误:%v", err)
+	}
+	if header.Version != backupFormatVersion {
+		return fmt.Errorf("不支持的备份文件格式版本:%d", header.Version)
+	}
+	if header.Username != conf.Username {
+		return fmt.Errorf("备份文件属于用户 %s，与当前用户 %s 不一致", header.Username, conf.Username)
+	}
+
+	var imported, republished int
+	for line := 2; scanner.Scan(); line++ {
+		var device backupDevice
+		if err := json.Unmarshal(scanner.Bytes(), &device); err != nil {
+			return fmt.Errorf("解析备份文件第%d行时发生了错误:%v", line, err)
+		}
+		if !selected[device.DeviceClassID] {
+			continue
+		}
+		if err := uc.ImportDeviceConfig(ctx, device.toBiz()); err != nil {
+			return fmt.Errorf("恢复设备 %s 的配置时发生了错误:%v", device.DeviceID, err)
+		}
+		imported++
+
+		if !republish {
+			continue
+		}
+		info := &biz.DeviceGeneralInfo{DeviceClassID: device.DeviceClassID, DeviceID: device.DeviceID}
+		updateID, err := uc.RepublishDeviceConfig(ctx, info, deviceClasses[device.DeviceClassID])
+		if err != nil {
+			// 设备可能已经不再使用，下发失败时继续恢复其余设备
+			fmt.Fprintf(os.Stderr, "重新下发设备 %s 的配置时发生了错误:%v\n", device.DeviceID, err)
+			continue
+		}
+		if updateID != "" {
+			republished++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "恢复了%d个设备的配置，重新下发了%d个设备的配置\n", imported, republished)
+
+	// 恢复时直接写入了设备配置，需要为恢复的设备配置建立索引
+	return rebuildConfigIndexes(ctx, uc, classes)
+}
+
+// selectDeviceClasses 返回需要导出或恢复的设备类别，deviceClassID为-1时返回全部设备类别
+func selectDeviceClasses(deviceClassID int) ([]int, error) {
+	if deviceClassID == -1 {
+		classes := make([]int, 0, len(deviceClasses))
+		for id := range deviceClasses {
+			classes = append(classes, id)
+		}
+		sort.Ints(classes)
+		return classes, nil
+	}
+	if _, ok := deviceClasses[deviceClassID]; !ok {
+		return nil, fmt.Errorf("设备类别 %d 不存在", deviceClassID)
+	}
+	return []int{deviceClassID}, nil
+}
+
+func newBackupDevice(backup *biz.DeviceConfigBackup) *backupDevice {
+	device := &backupDevice{
+		DeviceClassID:   backup.DeviceClassID,
+		DeviceID:        backup.DeviceID,
+		Reported:        backup.Reported,
+		Desired:         backup.Desired,
+		DesiredRevision: backup.DesiredRevision,
+		Version:         backup.Version,
+		Seq:             backup.Seq,
+		ClientID:        backup.ClientID,
+	}
+	for _, r := range backup.Revisions {
+		device.Revisions = append(device.Revisions, &backupRevision{
+			Version:  r.Version,
+			Time:     r.Time,
+			Source:   r.Source,
+			ClientID: r.ClientID,
+			Config:   r.Config,
+			Detail:   r.Detail,
+		})
+	}
+	return device
+}
+
+func (d *backupDevice) toBiz() *biz.DeviceConfigBackup {
+	backup := &biz.DeviceConfigBackup{
+		DeviceClassID:   d.DeviceClassID,
+		DeviceID:        d.DeviceID,
+		Reported:        d.Reported,
+		Desired:         d.Desired,
+		DesiredRevision: d.DesiredRevision,
+		Version:         d.Version,
+		Seq:             d.Seq,
+		ClientID:        d.ClientID,
+	}
+	for _, r := range d.Revisions {
+		backup.Revisions = append(backup.Revisions, &biz.ConfigRevision{
+			Version:  r.Version,
+			Time:     r.Time,
+			Source:   r.Source,
+			ClientID: r.ClientID,
+			Config:   r.Config,
+			Detail:   r.Detail,
+		})
+	}
+	return backup
+}
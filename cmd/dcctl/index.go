@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gitee.com/moyusir/data-collection/internal/biz"
+)
+
+// reindexConfigs 为用户全部设备类别(deviceClassID不为-1时为指定设备类别)下的设备配置重建二级索引
+func reindexConfigs(ctx context.Context, uc *biz.ConfigUsecase, deviceClassID int) error {
+	classes, err := selectDeviceClasses(deviceClassID)
+	if err != nil {
+		return err
+	}
+	return rebuildConfigIndexes(ctx, uc, classes)
+}
+
+func rebuildConfigIndexes(ctx context.Context, uc *biz.ConfigUsecase, classes []int) error {
+	var total int
+	for _, classID := range classes {
+		count, err := uc.RebuildConfigIndexes(ctx, classID, deviceClasses[classID])
+		if err != nil {
+			return fmt.Errorf("重建设备类别 %d 的配置索引时发生了错误:%v", classID, err)
+		}
+		total += count
+	}
+	fmt.Fprintf(os.Stderr, "重建了%d个设备的配置索引\n", total)
+	return nil
+}
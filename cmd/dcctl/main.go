@@ -0,0 +1,110 @@
+// dcctl 数据收集服务的运维命令行工具，目前提供设备配置的备份与恢复以及配置索引的重建:
+//
+//	dcctl -conf ../../configs config export [-o backup.jsonl] [-class 0]
+//	dcctl -conf ../../configs config import [-i backup.jsonl] [-class 0] [-republish]
+//	dcctl -conf ../../configs config reindex [-class 0]
+//
+// 备份文件的第一行为记录了格式版本以及所属用户的文件头，其余每行为一个设备的配置备份，
+// 包括设备上报的配置、desired配置、配置修订、配置更新序号以及与设备相关联的clientID，恢复后会为恢复的设备配置重建索引。
+// 启用了设备配置加密时，dcctl以服务的配置中的密钥解密以及加密设备配置，因此备份文件中的设备配置为明文，需要妥善保管；
+// 恢复时以当前的密钥重新加密，也可以借此将设备配置轮换到新的密钥。
+// 与服务本身相同，所操作的用户由USERNAME环境变量指定
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// flagconf is the config flag.
+var flagconf string
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.Usage = usage
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: dcctl [-conf path] config <command> [flags]
+
+commands:
+  export   导出用户的设备配置、配置修订以及设备路由
+  import   从备份文件恢复设备配置，可选地重新下发给设备
+  reindex  为引入配置索引之前保存的设备配置建立索引
+
+`)
+	flag.PrintDefaults()
+}
+
+// newRepo dcctl只操作redis中保存的设备配置，不连接influxdb，也不输出设备状态以及配置变更事件。
+// 与服务使用相同的密钥解密以及加密设备配置，因此导出的备份为明文，恢复时以当前的密钥重新加密
+func newRepo(redisData *data.RedisData, configCipher *data.ConfigCipher, logger log.Logger) biz.UnionRepo {
+	return data.NewRepo(redisData, nil, nil, nil, configCipher, logger)
+}
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 2 || args[0] != "config" {
+		usage()
+		os.Exit(2)
+	}
+
+	var (
+		cmd           = flag.NewFlagSet("config "+args[1], flag.ExitOnError)
+		path          string
+		deviceClassID int
+		republish     bool
+	)
+	cmd.IntVar(&deviceClassID, "class", -1, "只操作指定设备类别的设备配置，-1表示全部设备类别")
+	switch args[1] {
+	case "export":
+		cmd.StringVar(&path, "o", "-", "备份文件的路径，-表示标准输出")
+	case "import":
+		cmd.StringVar(&path, "i", "-", "备份文件的路径，-表示标准输入")
+		cmd.BoolVar(&republish, "republish", false, "恢复后将设备的desired配置重新下发给设备")
+	case "reindex":
+	default:
+		usage()
+		os.Exit(2)
+	}
+	cmd.Parse(args[2:])
+
+	// 命令行工具的日志输出到标准错误，只保留警告以上级别的日志
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	bc, err := conf.LoadConfig(flagconf, logger)
+	if err != nil {
+		fatalf("导入配置时发生了错误:%v", err)
+	}
+	uc, cleanup, err := initConfigUsecase(bc.Data, logger)
+	if err != nil {
+		fatalf("初始化时发生了错误:%v", err)
+	}
+	defer cleanup()
+
+	ctx := biz.WithAuditActor(context.Background(), &biz.AuditActor{ID: "dcctl"})
+	switch args[1] {
+	case "export":
+		err = exportConfigs(ctx, uc, path, deviceClassID)
+	case "import":
+		err = importConfigs(ctx, uc, path, deviceClassID, republish)
+	case "reindex":
+		err = reindexConfigs(ctx, uc, deviceClassID)
+	}
+	if err != nil {
+		cleanup()
+		fatalf("%v", err)
+	}
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "dcctl: "+format+"\n", args...)
+	os.Exit(1)
+}
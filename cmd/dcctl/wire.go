@@ -0,0 +1,19 @@
+//go:build wireinject
+// +build wireinject
+
+// The build tag makes sure the stub is not built in the final build.
+
+package main
+
+import (
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+// initConfigUsecase init the config usecase used by dcctl.
+func initConfigUsecase(*conf.Data, log.Logger) (*biz.ConfigUsecase, func(), error) {
+	panic(wire.Build(data.NewRedisData, data.NewConfigCipher, newRepo, biz.NewDeviceConfigUpdater, biz.NewConfigUsecase))
+}
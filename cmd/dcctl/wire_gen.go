@@ -0,0 +1,36 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Injectors from wire.go:
+
+// initConfigUsecase init the config usecase used by dcctl.
+func initConfigUsecase(confData *conf.Data, logger log.Logger) (*biz.ConfigUsecase, func(), error) {
+	redisData, cleanup, err := data.NewRedisData(confData)
+	if err != nil {
+		return nil, nil, err
+	}
+	configCipher, err := data.NewConfigCipher(confData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	unionRepo := newRepo(redisData, configCipher, logger)
+	deviceConfigUpdater := biz.NewDeviceConfigUpdater(confData, unionRepo, logger)
+	configUsecase, cleanup2 := biz.NewConfigUsecase(confData, unionRepo, deviceConfigUpdater, logger)
+	return configUsecase, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
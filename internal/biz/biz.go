@@ -1,15 +1,17 @@
 package biz
 
 import (
+	"context"
 	"fmt"
 	"gitee.com/moyusir/data-collection/internal/conf"
 	"github.com/google/wire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
 )
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewConfigUsecase, NewWarningDetectUsecase, NewDeviceConfigUpdater)
+var ProviderSet = wire.NewSet(NewConfigUsecase, NewWarningDetectUsecase, NewDeviceConfigUpdater, NewDeviceReadingCache, NewHealthUsecase, NewRolloutUsecase)
 
 // DeviceGeneralInfo 设备基本信息
 type DeviceGeneralInfo struct {
@@ -17,11 +19,30 @@ type DeviceGeneralInfo struct {
 	DeviceID      string
 }
 
-// UnionRepo 为了方便wire注入使用，这里合并两个repo接口
+// UnionRepo 为了方便wire注入使用，这里合并各个repo接口
 type UnionRepo interface {
 	ConfigRepo
 	WarningDetectRepo
 	PubSubClient
+	HealthRepo
+	ConfigUpdateStatusRepo
+	ConfigUpdateSeqRepo
+	RolloutRepo
+}
+
+// ConfigCodec 将设备配置的protobuf二进制信息编码为在redis中保存以及经由发布订阅传递的字符串，
+// 启用设备配置的静态加密时编码得到的是密文，并以key以及field作为附加认证数据，解码时需要提供相同的key以及field
+type ConfigCodec interface {
+	EncodeConfig(config []byte, key, field string) (string, error)
+	DecodeConfig(v, key, field string) ([]byte, error)
+}
+
+// LockRepo 基于redis实现的分布式锁
+type LockRepo interface {
+	// AcquireLock 在锁不存在或者已经由owner持有时获得锁并刷新锁的过期时间，返回owner是否持有锁
+	AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLock 释放owner持有的锁
+	ReleaseLock(ctx context.Context, key, owner string) error
 }
 
 // StateProtoMessage 用于规范设备状态信息定义的接口
@@ -36,6 +57,146 @@ func GetDeviceConfigKey(info *DeviceGeneralInfo) string {
 	return fmt.Sprintf("%s:device_config:%d:hash", conf.Username, info.DeviceClassID)
 }
 
+// GetDeviceDesiredConfigKey 以<用户id>:device_config_desired:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中保存设备影子中desired配置的protobuf二进制信息
+func GetDeviceDesiredConfigKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_desired:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetDeviceDesiredRevisionKey 以<用户id>:device_config_desired_version:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中保存设备影子中desired配置相应的修订版本号
+func GetDeviceDesiredRevisionKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_desired_version:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetDeviceConfigLockKey 以<用户id>:device_config_lock:<device_class_id>:<设备id>为键，
+// 保存正在以expected_revision为前提条件更新设备配置的请求的标识
+func GetDeviceConfigLockKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_lock:%d:%s", conf.Username, info.DeviceClassID, info.DeviceID)
+}
+
+// GetDeviceConfigSeqKey 以<用户id>:device_config_seq:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中保存设备最新的配置更新序号
+func GetDeviceConfigSeqKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_seq:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetDeviceConfigVersionKey 以<用户id>:device_config_version:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中保存设备配置最新的修订版本号
+func GetDeviceConfigVersionKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_version:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetDeviceConfigRevisionKey 以<用户id>:device_config_revision:<device_class_id>:<设备id>为键，
+// 在zset中保存以修订版本号为score，以修订记录为value的键值对
+func GetDeviceConfigRevisionKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_config_revision:%d:%s", conf.Username, info.DeviceClassID, info.DeviceID)
+}
+
+// GetPendingUpdatesKey 以<用户id>:config_update_pending:<clientID>为键，
+// 在redis stream中保存发往clientID且尚未被确认的配置更新消息
+func GetPendingUpdatesKey(clientID string) string {
+	return fmt.Sprintf("%s:config_update_pending:%s", conf.Username, clientID)
+}
+
+// GetClientDevicesKey 以<用户id>:client_devices:<device_class_id>:<clientID>为键，
+// 在redis set中保存与clientID相关联的设备id
+func GetClientDevicesKey(deviceClassID int, clientID string) string {
+	return fmt.Sprintf("%s:client_devices:%d:%s", conf.Username, deviceClassID, clientID)
+}
+
+// GetConfigUpdateStatusKey 以<用户id>:config_update_status:<配置更新id>为键，在redis hash中保存配置更新的下发状态
+func GetConfigUpdateStatusKey(updateID string) string {
+	return fmt.Sprintf("%s:config_update_status:%s", conf.Username, updateID)
+}
+
+// GetConfigUpdateResultChannel 配置更新结束时，以<用户id>:config_update_result:<配置更新id>为频道发布配置更新的状态
+func GetConfigUpdateResultChannel(updateID string) string {
+	return fmt.Sprintf("%s:config_update_result:%s", conf.Username, updateID)
+}
+
+// GetConfigUpdateWarningsKey 以<用户id>:config_update_warning为键，在redis stream中保存设备的警告信息，
+// 包括预警字段的读数超出合法范围以及配置更新最终失败时产生的警告信息
+func GetConfigUpdateWarningsKey() string {
+	return fmt.Sprintf("%s:config_update_warning", conf.Username)
+}
+
+// GetConfigTemplateKey 以<用户id>:device_config_template:hash为键
+// ,以设备类别号为field,在redis hash中保存设备类别配置模板的protobuf二进制信息
+func GetConfigTemplateKey() string {
+	return fmt.Sprintf("%s:device_config_template:hash", conf.Username)
+}
+
+// GetSeenDevicesKey 以<用户id>:device_seen:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中记录已经检查过是否需要下发配置模板的设备
+func GetSeenDevicesKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_seen:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetConfigAuditKey 以<用户id>:config_audit:<device_class_id>为键，在redis stream中保存设备类别下配置变更的审计记录
+func GetConfigAuditKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:config_audit:%d", conf.Username, info.DeviceClassID)
+}
+
+// GetConfigChangeStreamKey 以<用户id>:config_change为键，在redis stream中保存设备上报配置的变更事件，供其余服务消费
+func GetConfigChangeStreamKey() string {
+	return fmt.Sprintf("%s:config_change", conf.Username)
+}
+
+// GetDeviceConfigIndexKey 以{<用户id>:device_config_index:<device_class_id>}:<字段名>:<字段值>为键，
+// 在redis set中保存上报配置的该字段为该值的设备id。同一设备类别的索引以hash tag放置在同一slot中，以便求交集
+func GetDeviceConfigIndexKey(info *DeviceGeneralInfo, field, value string) string {
+	return fmt.Sprintf("{%s:device_config_index:%d}:%s:%s", conf.Username, info.DeviceClassID, field, value)
+}
+
+// GetDeviceConfigIndexRepairKey 以{<用户id>:device_config_index:<device_class_id>}:repair为键，
+// 在redis hash中以设备id为field保存维护索引失败、等待修复的设备及其失败时间。该键与设备类别的索引位于同一slot中
+func GetDeviceConfigIndexRepairKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("{%s:device_config_index:%d}:repair", conf.Username, info.DeviceClassID)
+}
+
+// GetDeviceLabelKey 以<用户id>:device_label:<device_class_id>:hash为键
+// ,以设备id为field,在redis hash中保存设备标签的json
+func GetDeviceLabelKey(info *DeviceGeneralInfo) string {
+	return fmt.Sprintf("%s:device_label:%d:hash", conf.Username, info.DeviceClassID)
+}
+
+// GetBulkUpdateJobKey 以<用户id>:config_bulk_job:<任务id>为键，在redis hash中保存批量更新任务的进度
+func GetBulkUpdateJobKey(jobID string) string {
+	return fmt.Sprintf("%s:config_bulk_job:%s", conf.Username, jobID)
+}
+
+// GetBulkUpdateJobUpdatesKey 以<用户id>:config_bulk_job_update:<任务id>为键，
+// 在redis hash中保存批量更新任务中以设备id为field，以配置更新id为value的键值对
+func GetBulkUpdateJobUpdatesKey(jobID string) string {
+	return fmt.Sprintf("%s:config_bulk_job_update:%s", conf.Username, jobID)
+}
+
+// GetActiveBulkUpdateJobsKey 以<用户id>:config_bulk_job_active为键，在redis set中保存尚未结束的批量更新任务的id
+func GetActiveBulkUpdateJobsKey() string {
+	return fmt.Sprintf("%s:config_bulk_job_active", conf.Username)
+}
+
+// GetBulkUpdateJobLockKey 以<用户id>:config_bulk_job_lock:<任务id>为键保存执行批量更新任务的实例的标识
+func GetBulkUpdateJobLockKey(jobID string) string {
+	return fmt.Sprintf("%s:config_bulk_job_lock:%s", conf.Username, jobID)
+}
+
+// GetConfigRolloutKey 以<用户id>:config_rollout:<灰度发布id>为键，在redis hash中保存灰度发布的进度以及控制命令
+func GetConfigRolloutKey(rolloutID string) string {
+	return fmt.Sprintf("%s:config_rollout:%s", conf.Username, rolloutID)
+}
+
+// GetActiveConfigRolloutsKey 以<用户id>:config_rollout_active为键，在redis set中保存尚未结束的灰度发布的id
+func GetActiveConfigRolloutsKey() string {
+	return fmt.Sprintf("%s:config_rollout_active", conf.Username)
+}
+
+// GetConfigRolloutLockKey 以<用户id>:config_rollout_lock为键保存推进灰度发布的实例的标识，保证同一时间只有一个实例推进灰度发布
+func GetConfigRolloutLockKey() string {
+	return fmt.Sprintf("%s:config_rollout_lock", conf.Username)
+}
+
 // GetDeviceStateKey 以<用户id>:device_state:<设备类别号>为键，在zset中保存
 // 以timestamp为score，以设备状态二进制protobuf信息为value的键值对
 func GetDeviceStateKey(info *DeviceGeneralInfo) string {
@@ -0,0 +1,501 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 批量更新的缺省下发速率以及任务进度的缺省保留时间
+const (
+	defaultBulkUpdateRate   = 100
+	defaultBulkUpdateJobTTL = 24 * time.Hour
+	// bulkUpdateFlushSize 批量更新时每下发若干个配置更新记录一次进度
+	bulkUpdateFlushSize = 100
+	// deviceConfigIDField 设备配置中保存设备id的字段
+	deviceConfigIDField = "id"
+	// bulkUpdateLockTTL 批量更新任务锁的有效期，执行任务的实例在下发过程中定期刷新任务锁，
+	// 实例异常退出时任务锁过期，其余实例据此将任务标记为结束
+	bulkUpdateLockTTL = time.Minute
+	// bulkUpdateStopTimeout 实例关闭时记录被中断的批量更新任务的最长时间
+	bulkUpdateStopTimeout = 5 * time.Second
+)
+
+// 批量更新任务在redis hash中的各个field
+const (
+	bulkJobFieldClassID       = "device_class_id"
+	bulkJobFieldTotal         = "total"
+	bulkJobFieldPublished     = "published"
+	bulkJobFieldPublishFailed = "publish_failed"
+	bulkJobFieldDone          = "done"
+	bulkJobFieldCreatedAt     = "created_at"
+)
+
+// DeviceSelector 批量更新时选择设备的条件，各个条件之间为与的关系
+type DeviceSelector struct {
+	// 选择设备类别下的全部设备，Labels以及IDPrefix均为空时必须为true
+	All bool `json:"all,omitempty"`
+	// 选择标签包含Labels中全部键值对的设备
+	Labels map[string]string `json:"labels,omitempty"`
+	// 选择id以IDPrefix为前缀的设备
+	IDPrefix string `json:"idPrefix,omitempty"`
+}
+
+// BulkUpdateJob 批量更新任务的进度
+type BulkUpdateJob struct {
+	JobID         string
+	DeviceClassID int
+	// 被选中的设备数量
+	Total int64
+	// 已经下发配置更新的设备数量
+	Published int64
+	// 配置更新已经发送给客户端的设备数量，包括已经确认的设备
+	Delivered int64
+	// 确认接收配置更新的设备数量
+	Acked int64
+	// 配置更新下发失败、被拒绝、过期或者被取代的设备数量
+	Failed int64
+	// 是否已经向全部被选中的设备下发了配置更新
+	Done      bool
+	CreatedAt time.Time
+}
+
+type BulkUpdateRepo interface {
+	// SaveBulkUpdateJob 将fields保存到key相应的hash中，并刷新hash的过期时间
+	SaveBulkUpdateJob(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error
+	// GetBulkUpdateJob 查询key相应hash中的全部field，hash不存在时返回404错误
+	GetBulkUpdateJob(ctx context.Context, key string) (map[string]string, error)
+	// SaveDeviceLabels 以设备id为field在key相应的hash中保存设备标签的json，标签不属于设备配置，因此不经过ConfigCodec编码
+	SaveDeviceLabels(ctx context.Context, key, field, labels string) error
+	// BatchGetDeviceLabels 批量查询key相应hash中设备标签的json，未设置标签的设备以空字符串表示
+	BatchGetDeviceLabels(ctx context.Context, key string, fields ...string) ([]string, error)
+}
+
+// SetDeviceLabels 设置设备的标签，覆盖设备原有的标签
+func (u *ConfigUsecase) SetDeviceLabels(
+	ctx context.Context, info *DeviceGeneralInfo, labels map[string]string) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SetDeviceLabels",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	value, err := json.Marshal(labels)
+	if err != nil {
+		return errors.Newf(500, "Biz_Config_Error", "序列化设备标签时发生了错误:%v", err)
+	}
+	return u.repo.SaveDeviceLabels(ctx, GetDeviceLabelKey(info), info.DeviceID, string(value))
+}
+
+// GetDeviceLabels 查询设备的标签，设备未设置标签时返回空的map
+func (u *ConfigUsecase) GetDeviceLabels(
+	ctx context.Context, info *DeviceGeneralInfo) (labels map[string]string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.GetDeviceLabels",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	values, err := u.repo.BatchGetDeviceLabels(ctx, GetDeviceLabelKey(info), info.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDeviceLabels(values[0])
+}
+
+// BulkUpdateDeviceConfig 将config下发给设备类别下被selector选中的全部设备，config中的设备id会被替换为各个设备的id。
+// 配置更新在后台以限定的速率依次下发，返回的批量更新任务可以用于查询下发进度
+func (u *ConfigUsecase) BulkUpdateDeviceConfig(ctx context.Context, deviceClassID int,
+	selector *DeviceSelector, config proto.Message) (job *BulkUpdateJob, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.BulkUpdateDeviceConfig",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if err := validateDeviceSelector(selector); err != nil {
+		return nil, err
+	}
+	if err := u.updater.validateConfigTemplate(deviceClassID, config); err != nil {
+		return nil, err
+	}
+	ids, err := u.selectDevices(ctx, deviceClassID, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, errors.New(404, "Biz_Config_NotFound", "没有满足选择条件的设备")
+	}
+	return u.startBulkUpdate(ctx, newBulkUpdateJobID(), deviceClassID, ids, config)
+}
+
+// newBulkUpdateJobID 产生全局唯一的批量更新任务id
+func newBulkUpdateJobID() string {
+	return uuid.NewString()
+}
+
+// startBulkUpdate 以jobID创建向ids中的设备下发config的批量更新任务，并在后台开始下发
+func (u *ConfigUsecase) startBulkUpdate(ctx context.Context,
+	jobID string, deviceClassID int, ids []string, config proto.Message) (*BulkUpdateJob, error) {
+	job := &BulkUpdateJob{
+		JobID:         jobID,
+		DeviceClassID: deviceClassID,
+		Total:         int64(len(ids)),
+		CreatedAt:     time.Now(),
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("config.bulk_job_id", job.JobID),
+		attribute.Int64("device.count", job.Total),
+	)
+	err := u.repo.SaveBulkUpdateJob(ctx, GetBulkUpdateJobKey(job.JobID), map[string]string{
+		bulkJobFieldClassID:       strconv.Itoa(deviceClassID),
+		bulkJobFieldTotal:         strconv.FormatInt(job.Total, 10),
+		bulkJobFieldPublished:     "0",
+		bulkJobFieldPublishFailed: "0",
+		bulkJobFieldDone:          strconv.FormatBool(false),
+		bulkJobFieldCreatedAt:     strconv.FormatInt(job.CreatedAt.UnixNano(), 10),
+	}, u.bulkUpdateJobTTL)
+	if err != nil {
+		return nil, err
+	}
+	// 任务结束之前由本实例持有任务锁，并将任务记录为尚未结束，使实例异常退出后任务能够被其余实例标记为结束
+	if _, err := u.repo.AcquireLock(ctx, GetBulkUpdateJobLockKey(job.JobID), u.owner, bulkUpdateLockTTL); err != nil {
+		return nil, err
+	}
+	if err := u.updater.pubSubClient.AddSetMember(ctx, GetActiveBulkUpdateJobsKey(), job.JobID); err != nil {
+		return nil, err
+	}
+
+	// 后台下发的链路不随请求结束，以link关联发起批量更新的请求，下发随实例的关闭而停止
+	runCtx, runSpan := monitor.StartSpan(u.ctx, "ConfigUsecase.runBulkUpdate",
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(attribute.String("config.bulk_job_id", job.JobID)))
+	// 各个配置更新沿用发起批量更新的请求指定的截止时间
+	if expiresAt := UpdateExpiryFromContext(ctx); !expiresAt.IsZero() {
+		runCtx = WithUpdateExpiry(runCtx, expiresAt)
+	}
+	// 各个配置变更的审计记录以发起批量更新的调用者作为调用者
+	if actor, ok := AuditActorFromContext(ctx); ok {
+		runCtx = WithAuditActor(runCtx, actor)
+	}
+	u.running.Store(job.JobID, true)
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		defer u.running.Delete(job.JobID)
+		u.runBulkUpdate(runCtx, runSpan, job, ids, proto.Clone(config))
+	}()
+	return job, nil
+}
+
+// GetBulkUpdateJob 查询批量更新任务的进度，其中的送达、确认以及失败数由各个配置更新的状态汇总得到
+func (u *ConfigUsecase) GetBulkUpdateJob(ctx context.Context, jobID string) (job *BulkUpdateJob, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.GetBulkUpdateJob",
+		trace.WithAttributes(attribute.String("config.bulk_job_id", jobID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if jobID == "" {
+		return nil, errors.New(400, "Biz_Config_Error", "批量更新任务的id不能为空")
+	}
+	fields, err := u.repo.GetBulkUpdateJob(ctx, GetBulkUpdateJobKey(jobID))
+	if err != nil {
+		return nil, err
+	}
+	classID, _ := strconv.Atoi(fields[bulkJobFieldClassID])
+	job = &BulkUpdateJob{
+		JobID:         jobID,
+		DeviceClassID: classID,
+		CreatedAt:     parseUnixNano(fields[bulkJobFieldCreatedAt]),
+	}
+	job.Total, _ = strconv.ParseInt(fields[bulkJobFieldTotal], 10, 64)
+	job.Published, _ = strconv.ParseInt(fields[bulkJobFieldPublished], 10, 64)
+	job.Failed, _ = strconv.ParseInt(fields[bulkJobFieldPublishFailed], 10, 64)
+	job.Done, _ = strconv.ParseBool(fields[bulkJobFieldDone])
+
+	// 尚未下发任何配置更新时，记录配置更新id的hash不存在
+	updates, err := u.repo.GetBulkUpdateJob(ctx, GetBulkUpdateJobUpdatesKey(jobID))
+	if err != nil && errors.Code(err) != 404 {
+		return nil, err
+	}
+	for _, updateID := range updates {
+		status, err := u.updater.GetConfigUpdateStatus(ctx, updateID)
+		if err != nil {
+			if errors.Code(err) != 404 {
+				return nil, err
+			}
+			// 状态已经过期的配置更新视为失败
+			job.Failed++
+			continue
+		}
+		switch status.Status {
+		case UpdateStatusSent, UpdateStatusRetrying:
+			job.Delivered++
+		case UpdateStatusAcked, UpdateStatusApplied:
+			job.Delivered++
+			job.Acked++
+		case UpdateStatusFailed, UpdateStatusExpired, UpdateStatusSuperseded:
+			job.Failed++
+		}
+	}
+	return job, nil
+}
+
+// selectDevices 遍历设备类别下已经上报配置的设备id，返回满足选择条件的设备id，遍历时只读取设备id而不读取配置本身
+func (u *ConfigUsecase) selectDevices(ctx context.Context, deviceClassID int, selector *DeviceSelector) ([]string, error) {
+	info := &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+	var (
+		ids    []string
+		seen   = make(map[string]bool)
+		cursor uint64
+	)
+	for {
+		page, next, err := u.repo.ScanDeviceIDs(ctx, GetDeviceConfigKey(info), cursor, maxConfigPageSize)
+		if err != nil {
+			return nil, err
+		}
+		// hscan可能返回重复的field
+		for _, id := range page {
+			if !seen[id] && strings.HasPrefix(id, selector.IDPrefix) {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	if len(selector.Labels) == 0 {
+		return ids, nil
+	}
+
+	matched := make([]string, 0, len(ids))
+	for start := 0; start < len(ids); start += maxConfigPageSize {
+		end := start + maxConfigPageSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		values, err := u.repo.BatchGetDeviceLabels(ctx, GetDeviceLabelKey(info), ids[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			labels, err := decodeDeviceLabels(v)
+			if err != nil {
+				return nil, err
+			}
+			if matchLabels(labels, selector.Labels) {
+				matched = append(matched, ids[start+i])
+			}
+		}
+	}
+	return matched, nil
+}
+
+// runBulkUpdate 以限定的速率依次向各个设备下发配置更新，并定期记录下发的进度。
+// ctx被取消时停止下发，尚未下发的设备计为下发失败
+func (u *ConfigUsecase) runBulkUpdate(
+	ctx context.Context, span trace.Span, job *BulkUpdateJob, ids []string, config proto.Message) {
+	var published, failed int64
+	defer func() {
+		span.SetAttributes(
+			attribute.Int64("config.bulk_published", published),
+			attribute.Int64("config.bulk_failed", failed),
+		)
+		monitor.EndSpan(span, nil)
+	}()
+
+	var tick <-chan time.Time
+	if interval := time.Second / time.Duration(u.bulkUpdateRate); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var (
+		updates     = make(map[string]string, bulkUpdateFlushSize)
+		lockKey     = GetBulkUpdateJobLockKey(job.JobID)
+		refreshedAt = time.Now()
+	)
+	for i, id := range ids {
+		if i > 0 && tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			failed += int64(len(ids) - i)
+			u.logger.Warnf("实例关闭，批量更新任务 %s 停止下发，尚未下发的%d个设备计为下发失败", job.JobID, len(ids)-i)
+			// ctx已经被取消，以新的ctx记录任务的结束
+			stopCtx, cancel := context.WithTimeout(
+				trace.ContextWithSpan(context.Background(), span), bulkUpdateStopTimeout)
+			defer cancel()
+			u.saveBulkUpdateProgress(stopCtx, job.JobID, updates, published, failed, true)
+			u.releaseBulkUpdate(stopCtx, job.JobID)
+			return
+		}
+		if time.Since(refreshedAt) > bulkUpdateLockTTL/3 {
+			ok, err := u.repo.AcquireLock(ctx, lockKey, u.owner, bulkUpdateLockTTL)
+			if err != nil {
+				u.logger.Errorf("刷新批量更新任务 %s 的任务锁时发生了错误:%v", job.JobID, err)
+			} else if !ok {
+				// 任务锁已经过期并被其余实例获得，任务已经被其余实例标记为结束
+				u.logger.Errorf("批量更新任务 %s 的任务锁已经被其余实例获得，停止下发", job.JobID)
+				return
+			} else {
+				refreshedAt = time.Now()
+			}
+		}
+
+		c := proto.Clone(config)
+		setDeviceConfigID(c, id)
+		updateID, err := u.UpdateDeviceConfig(ctx, &DeviceGeneralInfo{DeviceClassID: job.DeviceClassID, DeviceID: id}, c)
+		if err != nil {
+			failed++
+			u.logger.Errorf("批量更新任务 %s 向设备 %s 下发配置更新时发生了错误:%v", job.JobID, id, err)
+		} else {
+			published++
+			updates[id] = updateID
+		}
+
+		if last := i == len(ids)-1; last || (i+1)%bulkUpdateFlushSize == 0 {
+			u.saveBulkUpdateProgress(ctx, job.JobID, updates, published, failed, last)
+			updates = make(map[string]string, bulkUpdateFlushSize)
+		}
+	}
+	u.releaseBulkUpdate(ctx, job.JobID)
+}
+
+// releaseBulkUpdate 将已经结束的批量更新任务从尚未结束的任务中删除，并释放任务锁
+func (u *ConfigUsecase) releaseBulkUpdate(ctx context.Context, jobID string) {
+	if err := u.updater.pubSubClient.RemoveSetMember(ctx, GetActiveBulkUpdateJobsKey(), jobID); err != nil {
+		u.logger.Errorf("删除已经结束的批量更新任务 %s 时发生了错误:%v", jobID, err)
+	}
+	if err := u.repo.ReleaseLock(ctx, GetBulkUpdateJobLockKey(jobID), u.owner); err != nil {
+		u.logger.Errorf("释放批量更新任务 %s 的任务锁时发生了错误:%v", jobID, err)
+	}
+}
+
+// watchBulkUpdates 在启动时以及每隔bulkUpdateLockTTL检查一次尚未结束的批量更新任务，直到ctx被取消
+func (u *ConfigUsecase) watchBulkUpdates(ctx context.Context) {
+	ticker := time.NewTicker(bulkUpdateLockTTL)
+	defer ticker.Stop()
+	for {
+		u.recoverBulkUpdates(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverBulkUpdates 将执行实例已经退出的批量更新任务标记为结束，尚未下发的设备计为下发失败。
+// 执行中的任务的任务锁由执行实例持有，因此只处理本实例未在执行且能够获得任务锁的任务
+func (u *ConfigUsecase) recoverBulkUpdates(ctx context.Context) {
+	jobIDs, err := u.updater.pubSubClient.GetSetMembers(ctx, GetActiveBulkUpdateJobsKey())
+	if err != nil {
+		u.logger.Errorf("查询尚未结束的批量更新任务时发生了错误:%v", err)
+		return
+	}
+	for _, jobID := range jobIDs {
+		if _, ok := u.running.Load(jobID); ok {
+			continue
+		}
+		ok, err := u.repo.AcquireLock(ctx, GetBulkUpdateJobLockKey(jobID), u.owner, bulkUpdateLockTTL)
+		if err != nil {
+			u.logger.Errorf("获取批量更新任务 %s 的任务锁时发生了错误:%v", jobID, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		// 任务进度已经过期时只需删除任务
+		key := GetBulkUpdateJobKey(jobID)
+		fields, err := u.repo.GetBulkUpdateJob(ctx, key)
+		if err != nil && errors.Code(err) != 404 {
+			u.logger.Errorf("查询批量更新任务 %s 的进度时发生了错误:%v", jobID, err)
+			continue
+		}
+		if done, _ := strconv.ParseBool(fields[bulkJobFieldDone]); err == nil && !done {
+			total, _ := strconv.ParseInt(fields[bulkJobFieldTotal], 10, 64)
+			published, _ := strconv.ParseInt(fields[bulkJobFieldPublished], 10, 64)
+			err := u.repo.SaveBulkUpdateJob(ctx, key, map[string]string{
+				bulkJobFieldPublishFailed: strconv.FormatInt(total-published, 10),
+				bulkJobFieldDone:          strconv.FormatBool(true),
+			}, u.bulkUpdateJobTTL)
+			if err != nil {
+				u.logger.Errorf("将批量更新任务 %s 标记为结束时发生了错误:%v", jobID, err)
+				continue
+			}
+			u.logger.Warnf("批量更新任务 %s 的执行实例已经退出，尚未下发的%d个设备计为下发失败",
+				jobID, total-published)
+		}
+		u.releaseBulkUpdate(ctx, jobID)
+	}
+}
+
+// saveBulkUpdateProgress 记录批量更新任务新下发的配置更新id以及下发的进度，进度只用于查询，因此记录失败时只打印日志
+func (u *ConfigUsecase) saveBulkUpdateProgress(ctx context.Context, jobID string,
+	updates map[string]string, published, failed int64, done bool) {
+	if len(updates) != 0 {
+		err := u.repo.SaveBulkUpdateJob(ctx, GetBulkUpdateJobUpdatesKey(jobID), updates, u.bulkUpdateJobTTL)
+		if err != nil {
+			u.logger.Errorf("记录批量更新任务 %s 下发的配置更新时发生了错误:%v", jobID, err)
+		}
+	}
+	err := u.repo.SaveBulkUpdateJob(ctx, GetBulkUpdateJobKey(jobID), map[string]string{
+		bulkJobFieldPublished:     strconv.FormatInt(published, 10),
+		bulkJobFieldPublishFailed: strconv.FormatInt(failed, 10),
+		bulkJobFieldDone:          strconv.FormatBool(done),
+	}, u.bulkUpdateJobTTL)
+	if err != nil {
+		u.logger.Errorf("记录批量更新任务 %s 的进度时发生了错误:%v", jobID, err)
+	}
+}
+
+// validateDeviceSelector 检查选择设备的条件是否为空
+func validateDeviceSelector(selector *DeviceSelector) error {
+	if selector == nil || (!selector.All && len(selector.Labels) == 0 && selector.IDPrefix == "") {
+		return errors.New(400, "Biz_Config_Error",
+			"选择设备的条件不能为空，若需要更新设备类别下的全部设备，请将all设置为true")
+	}
+	return nil
+}
+
+// decodeDeviceLabels 反序列化设备标签，value为空时返回空的map
+func decodeDeviceLabels(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if value == "" {
+		return labels, nil
+	}
+	if err := json.Unmarshal([]byte(value), &labels); err != nil {
+		return nil, errors.Newf(500, "Biz_Config_Error", "反序列化设备标签时发生了错误:%v", err)
+	}
+	return labels, nil
+}
+
+// matchLabels 判断labels是否包含selector中的全部键值对
+func matchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// setDeviceConfigID 利用反射设置设备配置中的设备id
+func setDeviceConfigID(config proto.Message, id string) {
+	m := config.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(deviceConfigIDField)
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfString(id))
+}
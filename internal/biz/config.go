@@ -1,40 +1,333 @@
 package biz
 
 import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	protoV1 "github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
+	"strconv"
+	"sync"
+	"time"
 )
 
 type ConfigUsecase struct {
-	repo   ConfigRepo
-	logger *log.Helper
+	repo    ConfigRepo
+	updater *DeviceConfigUpdater
+	// 每个设备保留的配置修订数量
+	maxRevisions int64
+	// 批量更新时每秒下发的最大配置更新数以及批量更新任务进度的保留时间
+	bulkUpdateRate   int64
+	bulkUpdateJobTTL time.Duration
+	// 每个设备类别保留的配置审计记录数量
+	maxAuditRecords int64
+	// 配置变更事件stream的最大长度以及有效期
+	changeStreamMaxLen int64
+	changeStreamTTL    time.Duration
+	// 各设备类别的初始配置冲突处理策略
+	initialConfigPolicies map[int]string
+	// 实例的标识，作为批量更新任务锁的持有者
+	owner string
+	// 后台执行批量更新任务的goroutine随ctx的取消而结束，running记录本实例正在执行的批量更新任务的id
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running sync.Map
+	logger  *log.Helper
 }
 type ConfigRepo interface {
 	// SaveDeviceConfig 保存设备配置信息
-	SaveDeviceConfig(key, field string, value []byte) error
+	SaveDeviceConfig(ctx context.Context, key, field string, value []byte) error
+	// GetDeviceConfig 查询设备配置信息
+	GetDeviceConfig(ctx context.Context, key, field string) ([]byte, error)
+	// ListDeviceConfigs 从cursor处开始分页遍历设备配置信息，返回本页的设备配置以及下一页的游标，游标为0时表示遍历完毕
+	ListDeviceConfigs(ctx context.Context, key string, cursor uint64, count int64) (configs [][]byte, next uint64, err error)
+	// BatchGetDeviceConfigs 批量查询设备配置信息，不存在的设备配置以nil表示
+	BatchGetDeviceConfigs(ctx context.Context, key string, fields ...string) ([][]byte, error)
+	// DeleteDeviceConfig 删除设备配置信息
+	DeleteDeviceConfig(ctx context.Context, key, field string) error
+	ConfigRevisionRepo
+	ConfigAuditRepo
+	ConfigChangeRepo
+	ConfigBackupRepo
+	ConfigTemplateRepo
+	ConfigIndexRepo
+	ConfigUpdateSeqRepo
+	BulkUpdateRepo
+	LockRepo
 }
 
-func NewConfigUsecase(repo UnionRepo, logger log.Logger) *ConfigUsecase {
-	return &ConfigUsecase{
-		repo:   repo,
-		logger: log.NewHelper(logger),
+// 分页查询设备配置时单页数量的缺省值以及上限
+const (
+	defaultConfigPageSize = 50
+	maxConfigPageSize     = 1000
+)
+
+func NewConfigUsecase(c *conf.Data, repo UnionRepo, updater *DeviceConfigUpdater, logger log.Logger) (*ConfigUsecase, func()) {
+	maxRevisions := c.ConfigRevision.GetMaxRevisions()
+	if maxRevisions <= 0 {
+		maxRevisions = defaultMaxConfigRevisions
+	}
+	bulkUpdateRate := c.BulkUpdate.GetRate()
+	if bulkUpdateRate <= 0 {
+		bulkUpdateRate = defaultBulkUpdateRate
+	}
+	bulkUpdateJobTTL := c.BulkUpdate.GetJobTtl().AsDuration()
+	if bulkUpdateJobTTL <= 0 {
+		bulkUpdateJobTTL = defaultBulkUpdateJobTTL
+	}
+	maxAuditRecords := c.ConfigAudit.GetMaxLen()
+	if maxAuditRecords <= 0 {
+		maxAuditRecords = defaultMaxAuditRecords
+	}
+	changeStreamMaxLen := c.ConfigChange.GetStreamMaxLen()
+	if changeStreamMaxLen <= 0 {
+		changeStreamMaxLen = defaultConfigChangeStreamMaxLen
+	}
+	changeStreamTTL := c.ConfigChange.GetStreamTtl().AsDuration()
+	if changeStreamTTL <= 0 {
+		changeStreamTTL = defaultConfigChangeStreamTTL
+	}
+	uc := &ConfigUsecase{
+		repo:                  repo,
+		updater:               updater,
+		maxRevisions:          maxRevisions,
+		bulkUpdateRate:        bulkUpdateRate,
+		bulkUpdateJobTTL:      bulkUpdateJobTTL,
+		maxAuditRecords:       maxAuditRecords,
+		changeStreamMaxLen:    changeStreamMaxLen,
+		changeStreamTTL:       changeStreamTTL,
+		initialConfigPolicies: make(map[int]string),
+		owner:                 uuid.NewString(),
+		logger:                log.NewHelper(logger),
+	}
+	uc.ctx, uc.cancel = context.WithCancel(context.Background())
+	for classID, class := range c.DeviceClasses {
+		policy := class.GetInitialConfigPolicy()
+		if policy == "" {
+			continue
+		}
+		if !validInitialConfigPolicy(policy) {
+			uc.logger.Errorf("设备类别 %d 的初始配置冲突处理策略 %s 不合法，使用缺省的%s策略",
+				classID, policy, InitialConfigDeviceWins)
+			continue
+		}
+		uc.initialConfigPolicies[int(classID)] = policy
+	}
+
+	// 定期将执行实例已经退出的批量更新任务标记为结束
+	uc.wg.Add(1)
+	go func() {
+		defer uc.wg.Done()
+		uc.watchBulkUpdates(uc.ctx)
+	}()
+
+	return uc, func() {
+		// 取消正在执行的批量更新任务，并等待各个任务记录尚未下发的设备
+		uc.cancel()
+		uc.wg.Wait()
 	}
 }
 
-// SaveDeviceConfig 保存指定用户名以及设备类别号下的设备信息
-func (u *ConfigUsecase) SaveDeviceConfig(info *DeviceGeneralInfo, config proto.Message) error {
+// SaveDeviceConfig 保存指定用户名以及设备类别号下设备上报的配置，即设备影子的reported配置，
+// 并以source以及clientID记录一次配置修订。配置发生变化时发布配置变更事件
+func (u *ConfigUsecase) SaveDeviceConfig(
+	ctx context.Context, info *DeviceGeneralInfo, config proto.Message, source, clientID string) error {
+	_, err := u.saveDeviceConfig(ctx, info, config, source, clientID)
+	return err
+}
+
+// saveDeviceConfig 保存设备上报的配置，返回保存之前的配置的protobuf二进制信息，此前没有保存过配置时返回nil
+func (u *ConfigUsecase) saveDeviceConfig(ctx context.Context,
+	info *DeviceGeneralInfo, config proto.Message, source, clientID string) (oldConfig []byte, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SaveDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
 	// 以<用户id>:device_config:<device_class_id>:hash为键
 	// ,以设备id为field,在redis hash中保存设备配置的protobuf二进制信息
 	key := GetDeviceConfigKey(info)
 	marshal, err := proto.Marshal(config)
 	if err != nil {
-		return errors.Newf(
+		return nil, errors.Newf(
 			500, "Biz_Config_Error", "序列化设备配置信息时发生了错误:%v", err)
 	}
-	err = u.repo.SaveDeviceConfig(key, info.DeviceID, marshal)
+	oldConfig = u.getStoredConfig(ctx, key, info)
+	err = u.repo.SaveDeviceConfig(ctx, key, info.DeviceID, marshal)
+	if err != nil {
+		return nil, err
+	}
+	// 配置已经保存，因此即使索引维护失败也记录修订并发布配置变更事件，之后再返回错误
+	indexErr := u.updateConfigIndexes(ctx, info, oldConfig, config)
+	revision := u.recordRevision(ctx, info, marshal, source, clientID)
+	u.publishConfigChange(ctx, info, oldConfig, config, source, clientID, revision)
+	return oldConfig, indexErr
+}
+
+// UpdateDeviceConfig 将配置保存为设备影子的desired配置并下发给设备，并记录一次配置修订，
+// 返回的配置更新id可以用于查询配置更新的下发状态
+func (u *ConfigUsecase) UpdateDeviceConfig(
+	ctx context.Context, info *DeviceGeneralInfo, config proto.Message) (updateID string, err error) {
+	updateID, _, err = u.publishDesiredConfig(ctx, info, config, RevisionSourceUpdate, 0)
+	return updateID, err
+}
+
+// publishDesiredConfig 将配置保存为设备影子的desired配置并下发给设备，并以source记录一次配置修订，
+// 返回配置更新id以及desired配置的修订版本号，修订记录失败时版本号为0，保存desired配置的修订版本号失败时返回错误。
+// expectedRevision大于0时，desired配置的修订版本号在保存时已经不等于expectedRevision则返回409错误。
+// 配置在下发之前校验，并且只有下发成功后才保存为desired配置，因此不满足校验规则或者因设备尚未与clientID关联等原因
+// 下发失败的配置不会成为desired配置。无论下发成功与否，均以source作为操作记录一条审计记录。
+// 配置中值为掩码的敏感字段保留其当前值
+func (u *ConfigUsecase) publishDesiredConfig(ctx context.Context, info *DeviceGeneralInfo,
+	config proto.Message, source string, expectedRevision int64) (updateID string, revision int64, err error) {
+	oldConfig := u.getStoredConfig(ctx, GetDeviceDesiredConfigKey(info), info)
+	if hasMaskedFields(config.ProtoReflect()) {
+		// 值为掩码的敏感字段保留desired配置中的值，尚未下发过配置更新时保留设备上报的配置中的值
+		stored := oldConfig
+		if stored == nil {
+			stored = u.getStoredConfig(ctx, GetDeviceConfigKey(info), info)
+		}
+		if config, err = restoreMaskedFields(config, stored); err != nil {
+			return "", 0, err
+		}
+	}
+	marshal, err := proto.Marshal(config)
+	if err != nil {
+		return "", 0, errors.Newf(
+			500, "Biz_Config_Error", "序列化设备配置信息时发生了错误:%v", err)
+	}
+	defer func() { u.recordAudit(ctx, info, source, oldConfig, marshal, updateID, err) }()
+
+	// 配置由updater在下发之前校验，不满足校验规则的配置不会下发，也就不会保存为desired配置
+	clientID, updateID, err := u.updater.UpdateDeviceConfig(ctx, info, protoV1.MessageV1(config))
+	if err != nil {
+		return "", 0, err
+	}
+	if err = u.saveDesiredConfig(ctx, info, marshal); err != nil {
+		return "", 0, err
+	}
+	revision = u.recordRevision(ctx, info, marshal, source, clientID)
+	// 版本号与desired配置不一致时，以expected_revision为前提条件的更新可能覆盖其他请求的更新，因此保存失败时返回错误。
+	// 此时配置更新已经下发，仍然返回配置更新id以便记录审计记录以及查询下发状态
+	if err = u.saveDesiredRevision(ctx, info, expectedRevision, revision); err != nil {
+		return updateID, 0, err
+	}
+	return updateID, revision, nil
+}
+
+// GetDeviceConfig 查询设备配置信息，并反序列化到config中
+func (u *ConfigUsecase) GetDeviceConfig(ctx context.Context, info *DeviceGeneralInfo, config proto.Message) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.GetDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	marshal, err := u.repo.GetDeviceConfig(ctx, GetDeviceConfigKey(info), info.DeviceID)
 	if err != nil {
 		return err
 	}
+	if err = proto.Unmarshal(marshal, config); err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "反序列化设备配置信息时发生了错误:%v", err)
+	}
 	return nil
 }
+
+// ListDeviceConfigs 分页查询设备类别下的设备配置信息，以protoTemplate的副本返回各个设备配置，
+// pageToken为空时从第一页开始查询，返回的nextPageToken为空时表示查询完毕
+func (u *ConfigUsecase) ListDeviceConfigs(
+	ctx context.Context,
+	deviceClassID int,
+	pageSize int64,
+	pageToken string,
+	protoTemplate proto.Message) (configs []proto.Message, nextPageToken string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ListDeviceConfigs",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if pageSize <= 0 {
+		pageSize = defaultConfigPageSize
+	} else if pageSize > maxConfigPageSize {
+		pageSize = maxConfigPageSize
+	}
+	// page token即为hscan的游标
+	var cursor uint64
+	if pageToken != "" {
+		cursor, err = strconv.ParseUint(pageToken, 10, 64)
+		if err != nil || cursor == 0 {
+			return nil, "", errors.Newf(400, "Biz_Config_Error", "非法的page token:%s", pageToken)
+		}
+	}
+
+	info := &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+	values, next, err := u.repo.ListDeviceConfigs(ctx, GetDeviceConfigKey(info), cursor, pageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	configs, err = unmarshalDeviceConfigs(values, protoTemplate)
+	if err != nil {
+		return nil, "", err
+	}
+	if next != 0 {
+		nextPageToken = strconv.FormatUint(next, 10)
+	}
+	return configs, nextPageToken, nil
+}
+
+// BatchGetDeviceConfigs 批量查询设备配置信息，以protoTemplate的副本返回已保存的设备配置，并返回未保存配置的设备id
+func (u *ConfigUsecase) BatchGetDeviceConfigs(
+	ctx context.Context,
+	deviceClassID int,
+	ids []string,
+	protoTemplate proto.Message) (configs []proto.Message, notFound []string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.BatchGetDeviceConfigs",
+		trace.WithAttributes(
+			attribute.Int("device.class_id", deviceClassID),
+			attribute.Int("device.count", len(ids)),
+		))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if len(ids) > maxConfigPageSize {
+		return nil, nil, errors.Newf(
+			400, "Biz_Config_Error", "单次最多查询%d个设备的配置", maxConfigPageSize)
+	}
+
+	info := &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, GetDeviceConfigKey(info), ids...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make([][]byte, 0, len(values))
+	for i, v := range values {
+		if v == nil {
+			notFound = append(notFound, ids[i])
+		} else {
+			found = append(found, v)
+		}
+	}
+	configs, err = unmarshalDeviceConfigs(found, protoTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return configs, notFound, nil
+}
+
+// unmarshalDeviceConfigs 将设备配置的二进制信息反序列化为protoTemplate的副本
+func unmarshalDeviceConfigs(values [][]byte, protoTemplate proto.Message) ([]proto.Message, error) {
+	configs := make([]proto.Message, 0, len(values))
+	for _, v := range values {
+		config := proto.Clone(protoTemplate)
+		proto.Reset(config)
+		if err := proto.Unmarshal(v, config); err != nil {
+			return nil, errors.Newf(
+				500, "Biz_Config_Error", "反序列化设备配置信息时发生了错误:%v", err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
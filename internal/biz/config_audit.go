@@ -0,0 +1,229 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 每个设备类别保留的配置审计记录数量的缺省值
+const defaultMaxAuditRecords = 1000000
+
+// 单次查询审计记录时最多遍历的记录数，按照设备id过滤时本页可能因此不满pageSize条
+const maxAuditScanRecords = 10 * maxConfigPageSize
+
+// 配置变更的结果
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailed  = "failed"
+)
+
+const (
+	// SystemActor 不是由请求发起的配置变更，例如灰度发布各个阶段下发的配置更新，以system作为调用者
+	SystemActor = "system"
+	// AnonymousActor 未携带调用者身份的请求发起的配置变更以anonymous作为调用者
+	AnonymousActor = "anonymous"
+)
+
+// AuditActor 发起配置变更的调用者
+type AuditActor struct {
+	// 调用者身份，设备经由配置更新流以及初始配置流产生的配置变更以clientID作为调用者身份
+	ID string
+	// 调用者的来源ip，请求经由受信任的代理转发时取自x-forwarded-for请求头
+	SourceIP string
+	// 与服务直接建立连接的对端ip
+	PeerIP string
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor 返回携带了调用者的ctx，经由该ctx产生的配置变更以其作为审计记录中的调用者
+func WithAuditActor(ctx context.Context, actor *AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext 返回ctx携带的调用者
+func AuditActorFromContext(ctx context.Context) (*AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(*AuditActor)
+	return actor, ok
+}
+
+// ConfigAuditRecord 设备配置的一次变更的审计记录
+type ConfigAuditRecord struct {
+	// 审计记录在redis stream中的消息id，按照记录的先后顺序递增
+	ID       string
+	Time     time.Time
+	Actor    string
+	SourceIP string
+	PeerIP   string
+	// 变更的操作，以配置修订的来源表示，包括update、rollback、template、conflict以及ack
+	Operation string
+	DeviceID  string
+	// 变更相关的配置更新id
+	UpdateID string
+	// 变更前后设备配置的protobuf二进制信息，变更前不存在配置时为空
+	OldConfig, NewConfig []byte
+	Result               string
+	Error                string
+}
+
+// UnmarshalConfigs 将审计记录中变更前后的设备配置反序列化到oldConfig以及newConfig中，返回变更前后是否存在配置
+func (r *ConfigAuditRecord) UnmarshalConfigs(oldConfig, newConfig proto.Message) (hasOld, hasNew bool, err error) {
+	for _, c := range []struct {
+		value  []byte
+		config proto.Message
+	}{{r.OldConfig, oldConfig}, {r.NewConfig, newConfig}} {
+		if c.value == nil {
+			continue
+		}
+		if err := proto.Unmarshal(c.value, c.config); err != nil {
+			return false, false, errors.Newf(
+				500, "Biz_Config_Error", "反序列化审计记录%s中的设备配置时发生了错误:%v", r.ID, err)
+		}
+	}
+	return r.OldConfig != nil, r.NewConfig != nil, nil
+}
+
+// ConfigAuditFilter 查询审计记录的条件，各个条件为零值时不限
+type ConfigAuditFilter struct {
+	DeviceID   string
+	Start, End time.Time
+}
+
+type ConfigAuditRepo interface {
+	// AddConfigAuditRecord 向key相应的stream追加审计记录，stream长度超出maxLen时删除最旧的记录
+	AddConfigAuditRecord(ctx context.Context, key string, record *ConfigAuditRecord, maxLen int64) error
+	// RangeConfigAuditRecords 按照从旧到新的顺序查询key相应的stream中消息id介于start与end之间(包含两端)的至多count条审计记录，
+	// start与end为-以及+时分别表示不限
+	RangeConfigAuditRecords(ctx context.Context, key, start, end string, count int64) ([]*ConfigAuditRecord, error)
+}
+
+// recordAudit 追加一条配置变更的审计记录，调用者取自ctx，err为变更失败的原因。
+// 审计记录写入失败时只打印日志，不影响配置本身的保存与下发
+func (u *ConfigUsecase) recordAudit(ctx context.Context, info *DeviceGeneralInfo,
+	operation string, oldConfig, newConfig []byte, updateID string, err error) {
+	record := &ConfigAuditRecord{
+		Time:      time.Now(),
+		Actor:     SystemActor,
+		Operation: operation,
+		DeviceID:  info.DeviceID,
+		UpdateID:  updateID,
+		OldConfig: oldConfig,
+		NewConfig: newConfig,
+		Result:    AuditResultSuccess,
+	}
+	if actor, ok := AuditActorFromContext(ctx); ok {
+		record.Actor, record.SourceIP, record.PeerIP = actor.ID, actor.SourceIP, actor.PeerIP
+		if record.Actor == "" {
+			record.Actor = AnonymousActor
+		}
+	}
+	if err != nil {
+		record.Result, record.Error = AuditResultFailed, err.Error()
+	}
+
+	if err := u.repo.AddConfigAuditRecord(ctx, GetConfigAuditKey(info), record, u.maxAuditRecords); err != nil {
+		u.logger.Errorf("记录设备 %s 的配置审计记录时发生了错误:%v", info.DeviceID, err)
+	}
+}
+
+// getStoredConfig 查询key中保存的设备配置用于审计记录以及配置变更事件，查询失败时只打印日志并返回nil
+func (u *ConfigUsecase) getStoredConfig(ctx context.Context, key string, info *DeviceGeneralInfo) []byte {
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, key, info.DeviceID)
+	if err != nil {
+		u.logger.Errorf("查询设备 %s 变更前的配置时发生了错误:%v", info.DeviceID, err)
+		return nil
+	}
+	return values[0]
+}
+
+// SaveAckedDeviceConfig 将客户端确认接收的配置更新保存为设备上报的配置，并记录配置修订以及审计记录
+func (u *ConfigUsecase) SaveAckedDeviceConfig(ctx context.Context,
+	info *DeviceGeneralInfo, config proto.Message, clientID, updateID string) error {
+	oldConfig, err := u.saveDeviceConfig(ctx, info, config, RevisionSourceAck, clientID)
+	newConfig, _ := proto.Marshal(config)
+	u.recordAudit(ctx, info, RevisionSourceAck, oldConfig, newConfig, updateID, err)
+	return err
+}
+
+// ListConfigAuditRecords 按照从旧到新的顺序分页查询设备类别下满足filter的审计记录，
+// pageToken为空时从最早的记录开始查询，返回的nextPageToken为空时表示查询完毕
+func (u *ConfigUsecase) ListConfigAuditRecords(
+	ctx context.Context,
+	deviceClassID int,
+	filter *ConfigAuditFilter,
+	pageSize int64,
+	pageToken string) (records []*ConfigAuditRecord, nextPageToken string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ListConfigAuditRecords",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if pageSize <= 0 {
+		pageSize = defaultConfigPageSize
+	} else if pageSize > maxConfigPageSize {
+		pageSize = maxConfigPageSize
+	}
+	// stream的消息id以毫秒时间戳开头，因此时间范围可以直接转换为消息id的范围
+	start, end := "-", "+"
+	if !filter.Start.IsZero() {
+		start = strconv.FormatInt(filter.Start.UnixMilli(), 10)
+	}
+	if !filter.End.IsZero() {
+		end = strconv.FormatInt(filter.End.UnixMilli(), 10)
+	}
+	// page token即为上一页最后一条审计记录的消息id
+	if pageToken != "" {
+		if start, err = nextStreamID(pageToken); err != nil {
+			return nil, "", err
+		}
+	}
+
+	key := GetConfigAuditKey(&DeviceGeneralInfo{DeviceClassID: deviceClassID})
+	for scanned := int64(0); scanned < maxAuditScanRecords; {
+		batch, err := u.repo.RangeConfigAuditRecords(ctx, key, start, end, pageSize)
+		if err != nil {
+			return nil, "", err
+		}
+		scanned += int64(len(batch))
+		for i, r := range batch {
+			if filter.DeviceID != "" && r.DeviceID != filter.DeviceID {
+				continue
+			}
+			records = append(records, r)
+			if int64(len(records)) == pageSize {
+				if i == len(batch)-1 && int64(len(batch)) < pageSize {
+					return records, "", nil
+				}
+				return records, r.ID, nil
+			}
+		}
+		if int64(len(batch)) < pageSize {
+			return records, "", nil
+		}
+		nextPageToken = batch[len(batch)-1].ID
+		if start, err = nextStreamID(nextPageToken); err != nil {
+			return nil, "", err
+		}
+	}
+	// 达到遍历上限时以最后遍历的记录作为下一页的起点
+	return records, nextPageToken, nil
+}
+
+// nextStreamID 返回stream中紧随id之后的消息id，用于以id之后的消息作为下一页的起点
+func nextStreamID(id string) (string, error) {
+	i := strings.IndexByte(id, '-')
+	if i > 0 {
+		ms, err1 := strconv.ParseUint(id[:i], 10, 64)
+		seq, err2 := strconv.ParseUint(id[i+1:], 10, 64)
+		if err1 == nil && err2 == nil {
+			return strconv.FormatUint(ms, 10) + "-" + strconv.FormatUint(seq+1, 10), nil
+		}
+	}
+	return "", errors.Newf(400, "Biz_Config_Error", "非法的page token:%s", id)
+}
@@ -0,0 +1,205 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// 导出设备配置时单次遍历的设备数
+const backupScanCount = 500
+
+// DeviceConfigBackup 单个设备的配置备份，用于灾难恢复以及在集群之间迁移用户
+type DeviceConfigBackup struct {
+	DeviceClassID int
+	DeviceID      string
+	// 设备上报的配置以及desired配置的protobuf二进制信息，不存在时为nil
+	Reported, Desired []byte
+	// desired配置相应的修订版本号
+	DesiredRevision int64
+	// 设备配置最新的修订版本号，以及按照版本号从旧到新排列的配置修订
+	Version   int64
+	Revisions []*ConfigRevision
+	// 设备最新的配置更新序号，恢复后下发的配置更新在其基础上递增，避免被设备视为过期的配置更新而拒绝
+	Seq int64
+	// 与设备相关联的clientID，即设备配置更新的路由，设备尚未连接时为空
+	ClientID string
+}
+
+type ConfigBackupRepo interface {
+	// ScanDeviceIDs 从cursor处开始分页遍历key相应hash中的设备id，返回本页的设备id以及下一页的游标，
+	// 游标为0时表示遍历完毕。与hscan相同，同一设备id可能出现多次
+	ScanDeviceIDs(ctx context.Context, key string, cursor uint64, count int64) (ids []string, next uint64, err error)
+	// GetConfigVersion 查询versionKey相应hash的field上最新的修订版本号，没有修订时返回0
+	GetConfigVersion(ctx context.Context, versionKey, field string) (int64, error)
+	// RestoreConfigRevisions 以revisions替换revisionKey相应zset中的全部修订，
+	// 并将versionKey相应hash的field上的修订版本号设置为version
+	RestoreConfigRevisions(ctx context.Context, versionKey, field, revisionKey string,
+		version int64, revisions []*ConfigRevision) error
+}
+
+// ExportDeviceConfigs 遍历设备类别下保存了上报配置或desired配置的设备，依次以设备的配置备份调用f，
+// f返回错误时停止遍历并返回该错误
+func (u *ConfigUsecase) ExportDeviceConfigs(
+	ctx context.Context, deviceClassID int, f func(*DeviceConfigBackup) error) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ExportDeviceConfigs",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	var (
+		class    = &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+		exported = make(map[string]struct{})
+	)
+	for _, key := range []string{GetDeviceConfigKey(class), GetDeviceDesiredConfigKey(class)} {
+		var cursor uint64
+		for {
+			ids, next, err := u.repo.ScanDeviceIDs(ctx, key, cursor, backupScanCount)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if _, ok := exported[id]; ok {
+					continue
+				}
+				exported[id] = struct{}{}
+
+				backup, err := u.backupDeviceConfig(ctx, &DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: id})
+				if err != nil {
+					return err
+				}
+				if err := f(backup); err != nil {
+					return err
+				}
+			}
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+	}
+	span.SetAttributes(attribute.Int("backup.devices", len(exported)))
+	return nil
+}
+
+// backupDeviceConfig 查询单个设备的配置备份
+func (u *ConfigUsecase) backupDeviceConfig(ctx context.Context, info *DeviceGeneralInfo) (*DeviceConfigBackup, error) {
+	backup := &DeviceConfigBackup{DeviceClassID: info.DeviceClassID, DeviceID: info.DeviceID}
+	for _, c := range []struct {
+		key   string
+		value *[]byte
+	}{{GetDeviceConfigKey(info), &backup.Reported}, {GetDeviceDesiredConfigKey(info), &backup.Desired}} {
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, c.key, info.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		*c.value = values[0]
+	}
+
+	var err error
+	if backup.DesiredRevision, err = u.getDesiredRevision(ctx, info); err != nil {
+		return nil, err
+	}
+	if backup.Version, err = u.repo.GetConfigVersion(ctx, GetDeviceConfigVersionKey(info), info.DeviceID); err != nil {
+		return nil, err
+	}
+	if backup.Seq, err = u.getDeviceConfigSeq(ctx, info); err != nil {
+		return nil, err
+	}
+	if backup.ClientID, err = u.updater.GetDeviceClientID(ctx, info); err != nil {
+		return nil, err
+	}
+
+	// 修订按照版本号从新到旧查询，备份中按照从旧到新的顺序保存
+	var before int64
+	for {
+		revisions, err := u.repo.ListConfigRevisions(ctx, GetDeviceConfigRevisionKey(info), before, maxConfigPageSize)
+		if err != nil {
+			return nil, err
+		}
+		backup.Revisions = append(backup.Revisions, revisions...)
+		if int64(len(revisions)) < maxConfigPageSize {
+			break
+		}
+		before = revisions[len(revisions)-1].Version
+	}
+	for i, j := 0, len(backup.Revisions)-1; i < j; i, j = i+1, j-1 {
+		backup.Revisions[i], backup.Revisions[j] = backup.Revisions[j], backup.Revisions[i]
+	}
+	return backup, nil
+}
+
+// ImportDeviceConfig 以配置备份覆盖设备的上报配置、desired配置、配置修订以及与设备相关联的clientID。
+// 配置更新序号只在备份中的序号更大时覆盖，避免恢复后下发的配置更新被设备视为过期的配置更新
+func (u *ConfigUsecase) ImportDeviceConfig(ctx context.Context, backup *DeviceConfigBackup) (err error) {
+	info := &DeviceGeneralInfo{DeviceClassID: backup.DeviceClassID, DeviceID: backup.DeviceID}
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ImportDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if info.DeviceID == "" {
+		return errors.New(400, "Biz_Config_Error", "配置备份中的设备id不能为空")
+	}
+	if backup.Reported != nil {
+		if err := u.repo.SaveDeviceConfig(ctx, GetDeviceConfigKey(info), info.DeviceID, backup.Reported); err != nil {
+			return err
+		}
+	}
+	if backup.Desired != nil {
+		if err := u.saveDesiredConfig(ctx, info, backup.Desired); err != nil {
+			return err
+		}
+	}
+	if backup.DesiredRevision > 0 {
+		err := u.repo.SetDeviceConfigSeq(ctx, GetDeviceDesiredRevisionKey(info), info.DeviceID, backup.DesiredRevision)
+		if err != nil {
+			return err
+		}
+	}
+	if backup.Version > 0 || len(backup.Revisions) > 0 {
+		err := u.repo.RestoreConfigRevisions(ctx, GetDeviceConfigVersionKey(info), info.DeviceID,
+			GetDeviceConfigRevisionKey(info), backup.Version, backup.Revisions)
+		if err != nil {
+			return err
+		}
+	}
+	if backup.Seq > 0 {
+		seq, err := u.getDeviceConfigSeq(ctx, info)
+		if err != nil {
+			return err
+		}
+		if backup.Seq > seq {
+			if err := u.repo.SetDeviceConfigSeq(ctx, GetDeviceConfigSeqKey(info), info.DeviceID, backup.Seq); err != nil {
+				return err
+			}
+		}
+	}
+	if backup.ClientID != "" {
+		if err := u.updater.ConnectDeviceAndClientID(ctx, backup.ClientID, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RepublishDeviceConfig 将设备的desired配置重新下发给设备，并以restore作为来源记录配置修订，
+// 用于恢复配置备份后使设备与恢复的配置保持一致。设备没有desired配置时不下发，返回的配置更新id为空
+func (u *ConfigUsecase) RepublishDeviceConfig(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (updateID string, err error) {
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, GetDeviceDesiredConfigKey(info), info.DeviceID)
+	if err != nil {
+		return "", err
+	}
+	if values[0] == nil {
+		return "", nil
+	}
+	config := proto.Clone(protoTemplate)
+	if err := proto.Unmarshal(values[0], config); err != nil {
+		return "", errors.Newf(
+			500, "Biz_Config_Error", "反序列化设备 %s 的desired配置时发生了错误:%v", info.DeviceID, err)
+	}
+	updateID, _, err = u.publishDesiredConfig(ctx, info, config, RevisionSourceRestore, 0)
+	return updateID, err
+}
@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"context"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// 配置变更事件stream各项配置的缺省值
+const (
+	defaultConfigChangeStreamMaxLen = 100000
+	defaultConfigChangeStreamTTL    = 7 * 24 * time.Hour
+)
+
+// ConfigChangeEvent 设备上报配置的一次变更事件，追加到redis stream中并发送给webhook，供其余服务消费
+type ConfigChangeEvent struct {
+	// 事件在redis stream中的消息id，追加到stream之后填入
+	ID            string
+	Time          time.Time
+	DeviceClassID int
+	DeviceID      string
+	// 变更的来源，与配置修订的来源相同
+	Source   string
+	ClientID string
+	// 变更相应的配置修订的版本号，修订记录失败时为0
+	Revision int64
+	// 变更前设备没有保存过配置
+	Created bool
+	// 变更后设备配置的json，敏感字段以掩码替换
+	Config []byte
+	// 变更的字段，设备此前没有保存过配置时与空配置进行比较
+	Diff []*ConfigFieldDiff
+}
+
+type ConfigChangeRepo interface {
+	// PublishConfigChange 向stream追加配置变更事件，stream长度超出maxLen时删除最旧的事件，
+	// 并刷新stream的有效期，同时将事件异步地发送给订阅了该设备类别的webhook
+	PublishConfigChange(ctx context.Context, stream string, event *ConfigChangeEvent, maxLen int64, ttl time.Duration) error
+}
+
+// publishConfigChange 比较设备变更前后的配置，配置发生变化时发布配置变更事件。
+// 事件发布失败时只打印日志，不影响配置本身的保存
+func (u *ConfigUsecase) publishConfigChange(ctx context.Context, info *DeviceGeneralInfo,
+	oldConfig []byte, config proto.Message, source, clientID string, revision int64) {
+	old := config.ProtoReflect().New().Interface()
+	if oldConfig != nil {
+		if err := proto.Unmarshal(oldConfig, old); err != nil {
+			u.logger.Errorf("反序列化设备 %s 变更前的配置时发生了错误:%v", info.DeviceID, err)
+			return
+		}
+	}
+	diff := diffConfigs(old, config)
+	if oldConfig != nil && len(diff) == 0 {
+		return
+	}
+	// 事件由其余服务消费，敏感字段以掩码替换
+	value, err := protojson.Marshal(maskedClone(config))
+	if err != nil {
+		u.logger.Errorf("序列化设备 %s 的配置变更事件时发生了错误:%v", info.DeviceID, err)
+		return
+	}
+
+	event := &ConfigChangeEvent{
+		Time:          time.Now(),
+		DeviceClassID: info.DeviceClassID,
+		DeviceID:      info.DeviceID,
+		Source:        source,
+		ClientID:      clientID,
+		Revision:      revision,
+		Created:       oldConfig == nil,
+		Config:        value,
+		Diff:          diff,
+	}
+	err = u.repo.PublishConfigChange(ctx, GetConfigChangeStreamKey(), event, u.changeStreamMaxLen, u.changeStreamTTL)
+	if err != nil {
+		u.logger.Errorf("发布设备 %s 的配置变更事件时发生了错误:%v", info.DeviceID, err)
+	}
+}
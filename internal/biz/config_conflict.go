@@ -0,0 +1,195 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 设备上传的初始配置与desired配置冲突时的处理策略
+const (
+	// InitialConfigDeviceWins 以设备上传的配置为准，desired配置被替换为设备上传的配置
+	InitialConfigDeviceWins = "device-wins"
+	// InitialConfigServerWins 以desired配置为准，并通过配置更新流将desired配置重新下发给设备
+	InitialConfigServerWins = "server-wins"
+	// InitialConfigNewestRevisionWins 设备上传的配置已经应用了最近一次下发的配置更新时以设备为准，否则以desired配置为准
+	InitialConfigNewestRevisionWins = "newest-revision-wins"
+)
+
+// 冲突处理中生效的一方，同时作为监控指标的winner标签值
+const (
+	conflictWinnerDevice = "device"
+	conflictWinnerServer = "server"
+)
+
+// validInitialConfigPolicy 返回policy是否为支持的冲突处理策略
+func validInitialConfigPolicy(policy string) bool {
+	return policy == InitialConfigDeviceWins || policy == InitialConfigServerWins ||
+		policy == InitialConfigNewestRevisionWins
+}
+
+// SaveInitialDeviceConfig 保存设备通过初始配置流上传的配置，并在其与desired配置不一致时按照设备类别的冲突处理策略处理：
+// device-wins时以上传的配置替换desired配置，server-wins时将desired配置重新下发给设备，
+// newest-revision-wins时比较上传配置的version与设备最近一次配置更新的序号决定生效的一方。
+// 冲突及其处理结果以conflict来源的修订记录在配置历史中，protoTemplate用于反序列化desired配置以及配置模板。
+// 首次出现且没有任何已保存配置的设备不存在冲突，设备类别存在配置模板时以模板作为其desired配置并下发
+func (u *ConfigUsecase) SaveInitialDeviceConfig(ctx context.Context,
+	info *DeviceGeneralInfo, config proto.Message, clientID string, protoTemplate proto.Message) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SaveInitialDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	// 是否为首次出现的设备需要在保存上传的配置之前检查，未携带clientID的设备无法接收配置更新，因此不下发配置模板
+	var first bool
+	if clientID != "" {
+		if first, err = u.firstSeen(ctx, info); err != nil {
+			return err
+		}
+	}
+
+	// 无论哪一方生效，reported配置始终为设备实际使用的配置
+	if err = u.SaveDeviceConfig(ctx, info, config, RevisionSourceInitial, clientID); err != nil {
+		return err
+	}
+	if first {
+		// 首次出现的设备没有desired配置，因此不存在冲突。与上报配置的保存不同，配置模板下发失败时只打印日志
+		if _, err := u.applyConfigTemplate(ctx, info, protoTemplate); err != nil {
+			u.logger.Errorf("向设备 %v 下发配置模板时发生了错误:%v", info.DeviceID, err)
+		}
+		return nil
+	}
+
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, GetDeviceDesiredConfigKey(info), info.DeviceID)
+	if err != nil {
+		return err
+	}
+	desired, err := unmarshalShadowConfig(values[0], protoTemplate)
+	if err != nil || desired == nil {
+		return err
+	}
+	diffs := diffConfigs(config, desired)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	policy := u.initialConfigPolicy(info.DeviceClassID)
+	winner, err := u.resolveInitialConfigConflict(ctx, info, policy, config)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("config.conflict_policy", policy),
+		attribute.String("config.conflict_winner", winner),
+	)
+	monitor.InitialConfigConflicts.WithLabelValues(strconv.Itoa(info.DeviceClassID), winner).Inc()
+
+	fields := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		fields = append(fields, d.Field)
+	}
+	detail := fmt.Sprintf("初始配置与desired配置冲突，按照%s策略以%s的配置为准，存在差异的字段:%s",
+		policy, winner, strings.Join(fields, ","))
+
+	if winner == conflictWinnerDevice {
+		// 以设备上传的配置替换desired配置，并以冲突修订作为desired配置的修订版本号
+		marshal, err := proto.Marshal(config)
+		if err != nil {
+			return errors.Newf(
+				500, "Biz_Config_Error", "序列化设备配置信息时发生了错误:%v", err)
+		}
+		oldConfig, _ := proto.Marshal(desired)
+		err = u.saveDesiredConfig(ctx, info, marshal)
+		u.recordAudit(ctx, info, RevisionSourceConflict, oldConfig, marshal, "", err)
+		if err != nil {
+			return err
+		}
+		revision := u.recordConflict(ctx, info, marshal, clientID, detail)
+		if err := u.saveDesiredRevision(ctx, info, 0, revision); err != nil {
+			return err
+		}
+		u.logger.Infof("设备 %s 的初始配置与desired配置冲突，以设备上传的配置为准", info.DeviceID)
+		return nil
+	}
+
+	// desired配置的内容不变，因此不更新desired配置的修订版本号，避免以expected_revision更新配置的请求失败
+	marshal, err := proto.Marshal(desired)
+	if err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "序列化设备配置信息时发生了错误:%v", err)
+	}
+	u.recordConflict(ctx, info, marshal, clientID, detail)
+	u.logger.Infof("设备 %s 的初始配置与desired配置冲突，将desired配置重新下发给设备", info.DeviceID)
+	// 未携带clientID的设备无法接收配置更新，desired配置在设备下次携带clientID连接时下发
+	if clientID == "" {
+		return nil
+	}
+	// 与上报配置的保存不同，下发失败时只打印日志，desired配置在设备下次连接时再次下发
+	if err := u.SyncDeviceShadow(ctx, info, protoTemplate); err != nil {
+		u.logger.Errorf("向设备 %v 重新下发desired配置时发生了错误:%v", info.DeviceID, err)
+	}
+	return nil
+}
+
+// resolveInitialConfigConflict 按照冲突处理策略返回生效的一方
+func (u *ConfigUsecase) resolveInitialConfigConflict(
+	ctx context.Context, info *DeviceGeneralInfo, policy string, config proto.Message) (string, error) {
+	switch policy {
+	case InitialConfigDeviceWins:
+		return conflictWinnerDevice, nil
+	case InitialConfigNewestRevisionWins:
+		// 上传配置的version不小于设备最近一次配置更新的序号时，说明设备在应用最近一次配置更新之后修改了配置
+		seq, err := u.getDeviceConfigSeq(ctx, info)
+		if err != nil {
+			return "", err
+		}
+		if getDeviceConfigVersion(config) >= seq {
+			return conflictWinnerDevice, nil
+		}
+		return conflictWinnerServer, nil
+	default:
+		return conflictWinnerServer, nil
+	}
+}
+
+// recordConflict 以conflict来源记录冲突处理中生效的配置，detail为冲突的处理结果
+func (u *ConfigUsecase) recordConflict(
+	ctx context.Context, info *DeviceGeneralInfo, config []byte, clientID, detail string) int64 {
+	return u.saveRevision(ctx, info, &ConfigRevision{
+		Time:     time.Now(),
+		Source:   RevisionSourceConflict,
+		ClientID: clientID,
+		Config:   config,
+		Detail:   detail,
+	})
+}
+
+// getDeviceConfigSeq 查询设备最近一次配置更新的序号，尚未下发过配置更新时返回0
+func (u *ConfigUsecase) getDeviceConfigSeq(ctx context.Context, info *DeviceGeneralInfo) (int64, error) {
+	return u.repo.GetDeviceConfigSeq(ctx, GetDeviceConfigSeqKey(info), info.DeviceID)
+}
+
+// initialConfigPolicy 返回设备类别的冲突处理策略，未配置时为device-wins
+func (u *ConfigUsecase) initialConfigPolicy(deviceClassID int) string {
+	if policy, ok := u.initialConfigPolicies[deviceClassID]; ok {
+		return policy
+	}
+	return InitialConfigDeviceWins
+}
+
+// getDeviceConfigVersion 利用反射读取设备配置的version字段，设备配置没有该字段时返回0
+func getDeviceConfigVersion(config proto.Message) int64 {
+	m := config.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(deviceConfigVersionField)
+	if fd == nil || fd.Kind() != protoreflect.Int64Kind {
+		return 0
+	}
+	return m.Get(fd).Int()
+}
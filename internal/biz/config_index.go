@@ -0,0 +1,368 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigFieldPredicate 设备配置字段的等值条件
+type ConfigFieldPredicate struct {
+	Field string
+	Value string
+}
+
+type ConfigIndexRepo interface {
+	// UpdateConfigIndexes 将设备id从removeKeys相应的set中移除，并添加到addKeys相应的set中
+	UpdateConfigIndexes(ctx context.Context, deviceID string, removeKeys, addKeys []string) error
+	// IntersectConfigIndexes 返回keys相应的set的交集
+	IntersectConfigIndexes(ctx context.Context, keys ...string) ([]string, error)
+	// MarkConfigIndexRepair 记录维护索引失败的设备，value为失败时间
+	MarkConfigIndexRepair(ctx context.Context, key, deviceID, value string) error
+	// GetConfigIndexRepairs 返回全部等待修复索引的设备id到失败时间的映射
+	GetConfigIndexRepairs(ctx context.Context, key string) (map[string]string, error)
+	// UnmarkConfigIndexRepair 在设备的失败时间仍为value时删除设备的修复记录
+	UnmarkConfigIndexRepair(ctx context.Context, key, deviceID, value string) error
+}
+
+// ParseConfigFieldPredicate 解析<字段名>==<字段值>格式的等值条件，字段名与字段值两侧的空白被忽略
+func ParseConfigFieldPredicate(s string) (*ConfigFieldPredicate, error) {
+	parts := strings.SplitN(s, "==", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return nil, errors.Newf(400, "Biz_Config_Error", "非法的查询条件:%s，查询条件的格式为<字段名>==<字段值>", s)
+	}
+	return &ConfigFieldPredicate{Field: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1])}, nil
+}
+
+// indexedFields 返回设备配置中建立了二级索引的字段，即除配置更新序号以及敏感字段以外的全部顶层标量字段。
+// 字段值是索引键的一部分，因此敏感字段不建立索引
+func indexedFields(config proto.Message) []protoreflect.FieldDescriptor {
+	var (
+		fds    []protoreflect.FieldDescriptor
+		fields = config.ProtoReflect().Descriptor().Fields()
+	)
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); indexable(fd) {
+			fds = append(fds, fd)
+		}
+	}
+	return fds
+}
+
+func indexable(fd protoreflect.FieldDescriptor) bool {
+	return fd.Name() != deviceConfigVersionField && !fd.IsList() && !fd.IsMap() &&
+		!isMessageField(fd) && !isSensitive(fd)
+}
+
+// configIndexKeys 返回设备配置所属的索引，config为nil或字段未设置时不属于该字段的索引
+func configIndexKeys(info *DeviceGeneralInfo, config proto.Message) map[string]struct{} {
+	keys := make(map[string]struct{})
+	if config == nil {
+		return keys
+	}
+	m := config.ProtoReflect()
+	for _, fd := range indexedFields(config) {
+		if fd.HasPresence() && !m.Has(fd) {
+			continue
+		}
+		keys[GetDeviceConfigIndexKey(info, string(fd.Name()), formatField(m, fd))] = struct{}{}
+	}
+	return keys
+}
+
+// updateConfigIndexes 依据设备变更前后的配置维护设备所属的索引，oldConfig为nil时表示设备此前没有保存过配置。
+// 维护失败的设备被记录为等待修复，查询时总是作为候选设备并以配置本身过滤，直到重建索引时被修复，
+// 只有记录等待修复的设备也失败时才返回错误
+func (u *ConfigUsecase) updateConfigIndexes(
+	ctx context.Context, info *DeviceGeneralInfo, oldConfig []byte, config proto.Message) error {
+	var old proto.Message
+	if oldConfig != nil {
+		old = config.ProtoReflect().New().Interface()
+		if err := proto.Unmarshal(oldConfig, old); err != nil {
+			// 无法得知设备此前所属的索引时，只将设备添加到新的索引中。设备残留在旧索引中不影响查询结果
+			u.logger.Errorf("反序列化设备 %s 变更前的配置时发生了错误:%v", info.DeviceID, err)
+			old = nil
+		}
+	}
+
+	var (
+		oldKeys, newKeys = configIndexKeys(info, old), configIndexKeys(info, config)
+		removeKeys       []string
+		addKeys          []string
+	)
+	for k := range oldKeys {
+		if _, ok := newKeys[k]; !ok {
+			removeKeys = append(removeKeys, k)
+		}
+	}
+	for k := range newKeys {
+		if _, ok := oldKeys[k]; !ok {
+			addKeys = append(addKeys, k)
+		}
+	}
+	if len(removeKeys) == 0 && len(addKeys) == 0 {
+		return nil
+	}
+	err := u.repo.UpdateConfigIndexes(ctx, info.DeviceID, removeKeys, addKeys)
+	if err == nil {
+		return nil
+	}
+
+	u.logger.Errorf("维护设备 %s 的配置索引时发生了错误，设备被记录为等待修复:%v", info.DeviceID, err)
+	key := GetDeviceConfigIndexRepairKey(info)
+	if err := u.repo.MarkConfigIndexRepair(ctx, key, info.DeviceID, time.Now().Format(time.RFC3339Nano)); err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "维护设备 %s 的配置索引失败，并且无法记录等待修复的设备:%v", info.DeviceID, err)
+	}
+	return nil
+}
+
+// RebuildConfigIndexes 将设备类别下全部设备添加到其上报配置所属的索引中，
+// 用于为引入索引之前保存的设备配置建立索引，返回处理的设备数
+func (u *ConfigUsecase) RebuildConfigIndexes(
+	ctx context.Context, deviceClassID int, protoTemplate proto.Message) (count int, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.RebuildConfigIndexes",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	// 重建开始之前等待修复的设备在重建之后即被修复，重建过程中新增的修复记录则保留到下次重建
+	var (
+		class     = &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+		key       = GetDeviceConfigKey(class)
+		repairKey = GetDeviceConfigIndexRepairKey(class)
+		cursor    uint64
+	)
+	repairs, err := u.repo.GetConfigIndexRepairs(ctx, repairKey)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		ids, next, err := u.repo.ScanDeviceIDs(ctx, key, cursor, backupScanCount)
+		if err != nil {
+			return count, err
+		}
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, key, ids...)
+		if err != nil {
+			return count, err
+		}
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			info := &DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: ids[i]}
+			config := proto.Clone(protoTemplate)
+			if err := proto.Unmarshal(value, config); err != nil {
+				return count, errors.Newf(
+					500, "Biz_Config_Error", "反序列化设备 %s 的配置时发生了错误:%v", info.DeviceID, err)
+			}
+			var addKeys []string
+			for k := range configIndexKeys(info, config) {
+				addKeys = append(addKeys, k)
+			}
+			if err := u.repo.UpdateConfigIndexes(ctx, info.DeviceID, nil, addKeys); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	for id, failedAt := range repairs {
+		if err := u.repo.UnmarkConfigIndexRepair(ctx, repairKey, id, failedAt); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// SearchDeviceConfigs 按照设备id的顺序分页查询设备类别下上报配置满足全部等值条件的设备配置，
+// 以protoTemplate的副本返回。pageToken为空时从第一页开始查询，返回的nextPageToken为空时表示查询完毕
+func (u *ConfigUsecase) SearchDeviceConfigs(
+	ctx context.Context,
+	deviceClassID int,
+	predicates []*ConfigFieldPredicate,
+	pageSize int64,
+	pageToken string,
+	protoTemplate proto.Message) (configs []proto.Message, nextPageToken string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SearchDeviceConfigs",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if len(predicates) == 0 {
+		return nil, "", errors.New(400, "Biz_Config_Error", "至少需要指定一个查询条件")
+	}
+	if pageSize <= 0 {
+		pageSize = defaultConfigPageSize
+	} else if pageSize > maxConfigPageSize {
+		pageSize = maxConfigPageSize
+	}
+
+	// 将条件中的字段值转换为与索引相同的格式
+	var (
+		class  = &DeviceGeneralInfo{DeviceClassID: deviceClassID}
+		fields = make([]protoreflect.FieldDescriptor, len(predicates))
+		wants  = make([]string, len(predicates))
+		keys   = make([]string, len(predicates))
+	)
+	for i, p := range predicates {
+		fields[i], wants[i], err = resolvePredicate(protoTemplate, p)
+		if err != nil {
+			return nil, "", err
+		}
+		keys[i] = GetDeviceConfigIndexKey(class, string(fields[i].Name()), wants[i])
+	}
+
+	ids, err := u.repo.IntersectConfigIndexes(ctx, keys...)
+	if err != nil {
+		return nil, "", err
+	}
+	// 等待修复索引的设备可能不在其应属的索引中，因此总是作为候选设备
+	repairs, err := u.repo.GetConfigIndexRepairs(ctx, GetDeviceConfigIndexRepairKey(class))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(repairs) > 0 {
+		candidates := make(map[string]struct{}, len(ids)+len(repairs))
+		for _, id := range ids {
+			candidates[id] = struct{}{}
+		}
+		for id := range repairs {
+			if _, ok := candidates[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	// page token即为上一页最后一个设备的id
+	if pageToken != "" {
+		ids = ids[sort.SearchStrings(ids, pageToken):]
+		if len(ids) > 0 && ids[0] == pageToken {
+			ids = ids[1:]
+		}
+	}
+	span.SetAttributes(attribute.Int("search.candidates", len(ids)))
+
+	key := GetDeviceConfigKey(class)
+	for len(ids) > 0 && int64(len(configs)) < pageSize {
+		batch := ids
+		if n := pageSize - int64(len(configs)); int64(len(batch)) > n {
+			batch = batch[:n]
+		}
+		ids = ids[len(batch):]
+
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, key, batch...)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			config := proto.Clone(protoTemplate)
+			if err := proto.Unmarshal(value, config); err != nil {
+				return nil, "", errors.Newf(
+					500, "Biz_Config_Error", "反序列化设备 %s 的配置时发生了错误:%v", batch[i], err)
+			}
+			// 索引可能因维护失败而滞后于配置本身，因此以配置本身再次过滤
+			if matchPredicates(config, fields, wants) {
+				configs = append(configs, config)
+			}
+		}
+		nextPageToken = batch[len(batch)-1]
+	}
+	if len(ids) == 0 {
+		nextPageToken = ""
+	}
+	return configs, nextPageToken, nil
+}
+
+// resolvePredicate 返回条件相应的字段，以及以与索引相同的格式表示的字段值
+func resolvePredicate(protoTemplate proto.Message, p *ConfigFieldPredicate) (protoreflect.FieldDescriptor, string, error) {
+	fields := protoTemplate.ProtoReflect().Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(p.Field))
+	if fd == nil {
+		fd = fields.ByJSONName(p.Field)
+	}
+	if fd == nil || !indexable(fd) {
+		return nil, "", errors.Newf(400, "Biz_Config_Error", "字段 %s 不存在或不支持查询", p.Field)
+	}
+
+	var (
+		v   protoreflect.Value
+		err error
+	)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(p.Value)
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(p.Value)); ev != nil {
+			v = protoreflect.ValueOfEnum(ev.Number())
+		} else {
+			var n int64
+			n, err = strconv.ParseInt(p.Value, 10, 32)
+			v = protoreflect.ValueOfEnum(protoreflect.EnumNumber(n))
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int64
+		n, err = strconv.ParseInt(p.Value, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var n int64
+		n, err = strconv.ParseInt(p.Value, 10, 64)
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var n uint64
+		n, err = strconv.ParseUint(p.Value, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var n uint64
+		n, err = strconv.ParseUint(p.Value, 10, 64)
+		v = protoreflect.ValueOfUint64(n)
+	case protoreflect.FloatKind:
+		var f float64
+		f, err = strconv.ParseFloat(p.Value, 32)
+		v = protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		var f float64
+		f, err = strconv.ParseFloat(p.Value, 64)
+		v = protoreflect.ValueOfFloat64(f)
+	case protoreflect.BytesKind:
+		var b []byte
+		b, err = hex.DecodeString(p.Value)
+		v = protoreflect.ValueOfBytes(b)
+	default:
+		v = protoreflect.ValueOfString(p.Value)
+	}
+	if err != nil {
+		return nil, "", errors.Newf(400, "Biz_Config_Error", "字段 %s 的值 %s 不合法:%v", p.Field, p.Value, err)
+	}
+	return fd, formatSingular(fd, v), nil
+}
+
+// matchPredicates 判断设备配置是否满足全部条件
+func matchPredicates(config proto.Message, fields []protoreflect.FieldDescriptor, wants []string) bool {
+	m := config.ProtoReflect()
+	for i, fd := range fields {
+		if fd.HasPresence() && !m.Has(fd) {
+			return false
+		}
+		if formatField(m, fd) != wants[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 部分更新设备配置时，设备配置锁的过期时间
+const configLockTTL = 10 * time.Second
+
+// PatchDeviceConfig 以paths指定的字段部分更新设备配置，paths为空时以patch替换整个配置。
+// 部分更新以设备影子的desired配置为基础，尚未下发过配置更新时以设备上报的配置为基础。
+// expectedRevision大于0时，只有desired配置当前的修订版本号与之相等时才进行更新，否则返回409错误。
+// 更新期间持有设备配置锁，其他请求正在部分更新同一设备的配置时返回409错误。
+// 返回配置更新id以及更新后desired配置的修订版本号
+func (u *ConfigUsecase) PatchDeviceConfig(
+	ctx context.Context,
+	info *DeviceGeneralInfo,
+	patch proto.Message,
+	paths []string,
+	expectedRevision int64,
+	protoTemplate proto.Message) (updateID string, revision int64, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.PatchDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...),
+		trace.WithAttributes(
+			attribute.StringSlice("config.update_mask", paths),
+			attribute.Int64("config.expected_revision", expectedRevision),
+		))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if _, err := fieldmaskpb.New(protoTemplate, paths...); err != nil {
+		return "", 0, errors.Newf(400, "Biz_Config_Error", "非法的update_mask:%v", err)
+	}
+	if expectedRevision < 0 {
+		return "", 0, errors.New(400, "Biz_Config_Error", "expected_revision不能为负数")
+	}
+
+	// 加锁保证读取基础配置、检查版本号与保存配置之间不会有其他部分更新，避免并发的部分更新相互覆盖
+	key, owner := GetDeviceConfigLockKey(info), uuid.NewString()
+	locked, err := u.repo.AcquireLock(ctx, key, owner, configLockTTL)
+	if err != nil {
+		return "", 0, err
+	}
+	if !locked {
+		return "", 0, errors.Newf(409, "Biz_Config_Conflict", "设备 %s 的配置正在被其他请求更新", info.DeviceID)
+	}
+	defer func() {
+		if err := u.repo.ReleaseLock(ctx, key, owner); err != nil {
+			u.logger.Errorf("释放设备 %s 的配置锁时发生了错误:%v", info.DeviceID, err)
+		}
+	}()
+
+	if expectedRevision > 0 {
+		current, err := u.getDesiredRevision(ctx, info)
+		if err != nil {
+			return "", 0, err
+		}
+		if current != expectedRevision {
+			return "", 0, errors.Newf(409, "Biz_Config_Conflict",
+				"设备 %s 的配置已经被修改，当前的修订版本号为%d", info.DeviceID, current).
+				WithMetadata(map[string]string{"current_revision": strconv.FormatInt(current, 10)})
+		}
+	}
+
+	config := patch
+	if len(paths) != 0 {
+		config, err = u.getPatchBase(ctx, info, protoTemplate)
+		if err != nil {
+			return "", 0, err
+		}
+		applyFieldMask(config, patch, paths)
+	}
+	setDeviceConfigID(config, info.DeviceID)
+
+	// 保存修订版本号时再次比较expectedRevision，配置锁过期等情况下其他请求在检查之后修改了配置时同样返回409错误
+	updateID, revision, err = u.publishDesiredConfig(ctx, info, config, RevisionSourceUpdate, expectedRevision)
+	if err != nil {
+		return "", 0, err
+	}
+	span.SetAttributes(attribute.Int64("config.revision", revision))
+	return updateID, revision, nil
+}
+
+// getPatchBase 查询部分更新的基础配置，即desired配置，尚未下发过配置更新时为设备上报的配置
+func (u *ConfigUsecase) getPatchBase(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (proto.Message, error) {
+	for _, key := range []string{GetDeviceDesiredConfigKey(info), GetDeviceConfigKey(info)} {
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, key, info.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if values[0] != nil {
+			configs, err := unmarshalDeviceConfigs(values, protoTemplate)
+			if err != nil {
+				return nil, err
+			}
+			return configs[0], nil
+		}
+	}
+	return nil, errors.Newf(404, "Biz_Config_NotFound", "设备 %s 的配置不存在，无法进行部分更新", info.DeviceID)
+}
+
+// applyFieldMask 将src中paths指定的字段复制到dst中，src中未设置的字段在dst中被清除。
+// paths需要事先以fieldmaskpb.New检查，嵌套字段以.分隔
+func applyFieldMask(dst, src proto.Message, paths []string) {
+	for _, path := range paths {
+		copyField(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+	}
+}
+
+// copyField 将src中names指定的字段复制到dst中
+func copyField(dst, src protoreflect.Message, names []string) {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	if len(names) > 1 {
+		copyField(dst.Mutable(fd).Message(), src.Get(fd).Message(), names[1:])
+		return
+	}
+	if src.Has(fd) {
+		dst.Set(fd, src.Get(fd))
+	} else {
+		dst.Clear(fd)
+	}
+}
@@ -0,0 +1,278 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 每个设备保留的配置修订数量的缺省值
+const defaultMaxConfigRevisions = 100
+
+// 配置修订的来源
+const (
+	// RevisionSourceInitial 设备通过初始配置流上传的配置
+	RevisionSourceInitial = "initial"
+	// RevisionSourceAck 客户端确认接收的配置更新
+	RevisionSourceAck = "ack"
+	// RevisionSourceUpdate 通过UpdateDeviceConfig请求下发的配置更新
+	RevisionSourceUpdate = "update"
+	// RevisionSourceRollback 通过回滚重新下发的历史配置
+	RevisionSourceRollback = "rollback"
+	// RevisionSourceConflict 设备上传的初始配置与desired配置冲突时，按照冲突处理策略生效的配置
+	RevisionSourceConflict = "conflict"
+	// RevisionSourceTemplate 设备首次出现时下发的设备类别配置模板
+	RevisionSourceTemplate = "template"
+	// RevisionSourceRestore 恢复配置备份后重新下发的desired配置
+	RevisionSourceRestore = "restore"
+)
+
+// ConfigRevision 设备配置的一次修订
+type ConfigRevision struct {
+	// 修订的版本号，同一设备的版本号单调递增
+	Version  int64
+	Time     time.Time
+	Source   string
+	ClientID string
+	// 设备配置的protobuf二进制信息
+	Config []byte
+	// 修订的补充说明，例如初始配置冲突的处理结果
+	Detail string
+}
+
+// UnmarshalConfig 将修订中的设备配置反序列化到config中
+func (r *ConfigRevision) UnmarshalConfig(config proto.Message) error {
+	if err := proto.Unmarshal(r.Config, config); err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "反序列化版本%d的设备配置时发生了错误:%v", r.Version, err)
+	}
+	return nil
+}
+
+// ConfigFieldDiff 两个修订之间存在差异的字段
+type ConfigFieldDiff struct {
+	Field string
+	// 字段在两个修订中的值，字段未设置时为空
+	From, To string
+}
+
+type ConfigRevisionRepo interface {
+	// SaveConfigRevision 在versionKey相应hash的field上自增得到新的版本号，并以该版本号将修订保存到
+	// revisionKey相应的zset中，只保留最新的maxRevisions个修订
+	SaveConfigRevision(ctx context.Context, versionKey, field, revisionKey string,
+		revision *ConfigRevision, maxRevisions int64) (version int64, err error)
+	// ListConfigRevisions 按照版本号从新到旧查询版本号小于before的至多count个修订，before为0时从最新的修订开始查询
+	ListConfigRevisions(ctx context.Context, revisionKey string, before, count int64) ([]*ConfigRevision, error)
+	// GetConfigRevision 查询指定版本号的修订
+	GetConfigRevision(ctx context.Context, revisionKey string, version int64) (*ConfigRevision, error)
+}
+
+// recordRevision 记录设备配置的一次修订并返回修订的版本号，修订记录只用于追溯、回滚以及并发更新的检查，
+// 因此记录失败时只打印日志并返回0，不影响配置本身的保存与下发
+func (u *ConfigUsecase) recordRevision(
+	ctx context.Context, info *DeviceGeneralInfo, config []byte, source, clientID string) int64 {
+	return u.saveRevision(ctx, info, &ConfigRevision{
+		Time:     time.Now(),
+		Source:   source,
+		ClientID: clientID,
+		Config:   config,
+	})
+}
+
+// saveRevision 保存设备配置的一次修订并返回修订的版本号，保存失败时只打印日志并返回0
+func (u *ConfigUsecase) saveRevision(ctx context.Context, info *DeviceGeneralInfo, revision *ConfigRevision) int64 {
+	version, err := u.repo.SaveConfigRevision(
+		ctx,
+		GetDeviceConfigVersionKey(info),
+		info.DeviceID,
+		GetDeviceConfigRevisionKey(info),
+		revision,
+		u.maxRevisions,
+	)
+	if err != nil {
+		u.logger.Errorf("记录设备 %s 的配置修订时发生了错误:%v", info.DeviceID, err)
+		return 0
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("config.version", version))
+	return version
+}
+
+// ListConfigRevisions 按照版本号从新到旧分页查询设备配置的修订，
+// pageToken为空时从最新的修订开始查询，返回的nextPageToken为空时表示查询完毕
+func (u *ConfigUsecase) ListConfigRevisions(
+	ctx context.Context,
+	info *DeviceGeneralInfo,
+	pageSize int64,
+	pageToken string) (revisions []*ConfigRevision, nextPageToken string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ListConfigRevisions",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if pageSize <= 0 {
+		pageSize = defaultConfigPageSize
+	} else if pageSize > maxConfigPageSize {
+		pageSize = maxConfigPageSize
+	}
+	// page token即为上一页最后一个修订的版本号
+	var before int64
+	if pageToken != "" {
+		before, err = strconv.ParseInt(pageToken, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, "", errors.Newf(400, "Biz_Config_Error", "非法的page token:%s", pageToken)
+		}
+	}
+
+	// 多查询一个修订，用于判断是否还有下一页
+	revisions, err = u.repo.ListConfigRevisions(ctx, GetDeviceConfigRevisionKey(info), before, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(revisions)) > pageSize {
+		revisions = revisions[:pageSize]
+		nextPageToken = strconv.FormatInt(revisions[pageSize-1].Version, 10)
+	}
+	return revisions, nextPageToken, nil
+}
+
+// GetConfigRevision 查询设备配置指定版本号的修订
+func (u *ConfigUsecase) GetConfigRevision(
+	ctx context.Context, info *DeviceGeneralInfo, version int64) (*ConfigRevision, error) {
+	return u.repo.GetConfigRevision(ctx, GetDeviceConfigRevisionKey(info), version)
+}
+
+// DiffConfigRevisions 比较设备配置两个修订之间存在差异的字段，protoTemplate用于反序列化两个修订中的设备配置
+func (u *ConfigUsecase) DiffConfigRevisions(
+	ctx context.Context,
+	info *DeviceGeneralInfo,
+	fromVersion, toVersion int64,
+	protoTemplate proto.Message) (diffs []*ConfigFieldDiff, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.DiffConfigRevisions",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	configs := make([]proto.Message, 2)
+	for i, version := range []int64{fromVersion, toVersion} {
+		revision, err := u.GetConfigRevision(ctx, info, version)
+		if err != nil {
+			return nil, err
+		}
+		configs[i] = proto.Clone(protoTemplate)
+		proto.Reset(configs[i])
+		if err := revision.UnmarshalConfig(configs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return diffConfigs(configs[0], configs[1]), nil
+}
+
+// RollbackDeviceConfig 将设备配置回滚到指定版本号的修订，即将该修订中的配置保存为desired配置并重新下发给设备，
+// protoTemplate用于反序列化修订中的设备配置，返回重新下发配置的配置更新id
+func (u *ConfigUsecase) RollbackDeviceConfig(
+	ctx context.Context, info *DeviceGeneralInfo, version int64, protoTemplate proto.Message) (updateID string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.RollbackDeviceConfig",
+		trace.WithAttributes(deviceAttributes(info)...),
+		trace.WithAttributes(attribute.Int64("config.rollback_version", version)),
+	)
+	defer func() { monitor.EndSpan(span, err) }()
+
+	revision, err := u.GetConfigRevision(ctx, info, version)
+	if err != nil {
+		return "", err
+	}
+	config := proto.Clone(protoTemplate)
+	proto.Reset(config)
+	if err = revision.UnmarshalConfig(config); err != nil {
+		return "", err
+	}
+
+	updateID, _, err = u.publishDesiredConfig(ctx, info, config, RevisionSourceRollback, 0)
+	return updateID, err
+}
+
+// diffConfigs 逐个字段比较两个同类型的设备配置，配置更新的序号不属于配置本身，不参与比较。
+// 敏感字段以实际的值进行比较，差异中则以掩码表示
+func diffConfigs(from, to proto.Message) []*ConfigFieldDiff {
+	var (
+		diffs                []*ConfigFieldDiff
+		fields               = from.ProtoReflect().Descriptor().Fields()
+		maskedFrom, maskedTo protoreflect.Message
+	)
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Name() == deviceConfigVersionField {
+			continue
+		}
+		if formatField(from.ProtoReflect(), fd) == formatField(to.ProtoReflect(), fd) {
+			continue
+		}
+		if maskedFrom == nil {
+			maskedFrom, maskedTo = maskedClone(from).ProtoReflect(), maskedClone(to).ProtoReflect()
+		}
+		diffs = append(diffs, &ConfigFieldDiff{
+			Field: string(fd.Name()),
+			From:  formatField(maskedFrom, fd),
+			To:    formatField(maskedTo, fd),
+		})
+	}
+	return diffs
+}
+
+// formatField 将字段的值格式化为字符串，具有presence的字段未设置时返回空字符串
+func formatField(m protoreflect.Message, fd protoreflect.FieldDescriptor) string {
+	if fd.HasPresence() && !m.Has(fd) {
+		return ""
+	}
+	v := m.Get(fd)
+
+	switch {
+	case fd.IsList():
+		list := v.List()
+		elements := make([]string, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			elements = append(elements, formatSingular(fd, list.Get(i)))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case fd.IsMap():
+		var entries []string
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries = append(entries, fmt.Sprintf("%v: %s", k.Interface(), formatSingular(fd.MapValue(), v)))
+			return true
+		})
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return formatSingular(fd, v)
+	}
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		b, err := protojson.Marshal(v.Message().Interface())
+		if err != nil {
+			return fmt.Sprint(v.Message().Interface())
+		}
+		return string(b)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
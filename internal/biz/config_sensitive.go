@@ -0,0 +1,176 @@
+package biz
+
+import (
+	"bytes"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sync"
+)
+
+// SensitiveFieldOption 标记设备配置中敏感字段(例如设备凭证)的字段选项的全名
+const SensitiveFieldOption protoreflect.FullName = "api.dataCollection.v1.sensitive"
+
+// MaskedValue 敏感字段在api响应、日志、配置差异以及配置变更事件中的掩码
+const MaskedValue = "******"
+
+// sensitiveFields 缓存字段是否被标记为敏感字段
+var sensitiveFields sync.Map
+
+// isSensitive 判断字段是否以SensitiveFieldOption标记为敏感字段
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	if v, ok := sensitiveFields.Load(fd); ok {
+		return v.(bool)
+	}
+
+	var sensitive bool
+	if opts := fd.Options(); opts != nil {
+		if m := opts.ProtoReflect(); m.IsValid() {
+			m.Range(func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+				if xd.IsExtension() && xd.FullName() == SensitiveFieldOption && xd.Kind() == protoreflect.BoolKind {
+					sensitive = v.Bool()
+					return false
+				}
+				return true
+			})
+		}
+	}
+	sensitiveFields.Store(fd, sensitive)
+	return sensitive
+}
+
+// MaskSensitiveFields 将消息以及其中嵌套的消息中已设置的敏感字段替换为掩码，string以及bytes字段替换为MaskedValue，
+// 其余类型的字段被清除，用于在api响应以及日志中隐藏设备凭证等信息
+func MaskSensitiveFields(m proto.Message) {
+	maskMessage(m.ProtoReflect())
+}
+
+func maskMessage(m protoreflect.Message) {
+	// 遍历的同时修改消息的行为未定义，因此先收集需要处理的字段
+	var fds []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+
+	for _, fd := range fds {
+		v := m.Get(fd)
+		switch {
+		case isSensitive(fd):
+			if fd.IsList() || fd.IsMap() {
+				m.Clear(fd)
+				continue
+			}
+			switch fd.Kind() {
+			case protoreflect.StringKind:
+				m.Set(fd, protoreflect.ValueOfString(MaskedValue))
+			case protoreflect.BytesKind:
+				m.Set(fd, protoreflect.ValueOfBytes([]byte(MaskedValue)))
+			default:
+				m.Clear(fd)
+			}
+		case fd.IsList():
+			if isMessageField(fd) {
+				for list, i := v.List(), 0; i < list.Len(); i++ {
+					maskMessage(list.Get(i).Message())
+				}
+			}
+		case fd.IsMap():
+			if isMessageField(fd.MapValue()) {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					maskMessage(v.Message())
+					return true
+				})
+			}
+		case isMessageField(fd):
+			maskMessage(v.Message())
+		}
+	}
+}
+
+// maskedClone 返回敏感字段被替换为掩码的消息副本
+func maskedClone(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	MaskSensitiveFields(m)
+	return m
+}
+
+// restoreMaskedFields 客户端通常将查询得到的配置修改后再提交，其中的敏感字段已被MaskSensitiveFields隐藏，
+// 这里将config中值为掩码的string以及bytes敏感字段还原为stored(保存的配置的protobuf二进制信息，可以为nil)中的值。
+// 其余类型的敏感字段被隐藏时已被清除，无法与有意的清除区分，因此按照提交的值保存，需要保留其当前值时应当以update_mask修改配置。
+// 返回还原后的config副本，不修改config本身，调用前应当以hasMaskedFields检查是否存在需要还原的字段
+func restoreMaskedFields(config proto.Message, stored []byte) (proto.Message, error) {
+	base := config.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(stored, base); err != nil {
+		return nil, errors.Newf(
+			500, "Biz_Config_Error", "反序列化保存的配置以还原敏感字段时发生了错误:%v", err)
+	}
+	config = proto.Clone(config)
+	restoreMessage(config.ProtoReflect(), base.ProtoReflect())
+	return config, nil
+}
+
+// restoreMessage 将m中被隐藏的敏感字段还原为stored中的值，stored中未设置该字段时清除该字段
+func restoreMessage(m, stored protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case isSensitive(fd):
+			if !isMasked(m, fd) {
+				continue
+			}
+			if stored.Has(fd) {
+				m.Set(fd, stored.Get(fd))
+			} else {
+				m.Clear(fd)
+			}
+		case fd.IsList() || fd.IsMap() || !m.Has(fd):
+		case isMessageField(fd):
+			restoreMessage(m.Mutable(fd).Message(), stored.Get(fd).Message())
+		}
+	}
+}
+
+// hasMaskedFields 判断消息中是否存在被隐藏的敏感字段
+func hasMaskedFields(m protoreflect.Message) bool {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case isSensitive(fd):
+			if isMasked(m, fd) {
+				return true
+			}
+		case fd.IsList() || fd.IsMap() || !m.Has(fd):
+		case isMessageField(fd):
+			if hasMaskedFields(m.Get(fd).Message()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMasked 判断敏感字段的值是否为MaskSensitiveFields写入的掩码，只有string以及bytes字段以掩码替换
+func isMasked(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if fd.IsList() || fd.IsMap() || !m.Has(fd) {
+		return false
+	}
+	return isMaskedValue(fd, m.Get(fd))
+}
+
+func isMaskedValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return v.String() == MaskedValue
+	case protoreflect.BytesKind:
+		return bytes.Equal(v.Bytes(), []byte(MaskedValue))
+	default:
+		return false
+	}
+}
+
+func isMessageField(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+}
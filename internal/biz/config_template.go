@@ -0,0 +1,147 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+)
+
+type ConfigTemplateRepo interface {
+	// MarkDeviceSeen 在key相应的hash中记录field，field此前不存在即设备首次出现时返回true
+	MarkDeviceSeen(ctx context.Context, key, field string) (bool, error)
+	// UnmarkDeviceSeen 删除key相应hash中的field
+	UnmarkDeviceSeen(ctx context.Context, key, field string) error
+}
+
+// SetConfigTemplate 校验并保存设备类别的配置模板，模板中的设备id在下发时被替换为各个设备的id，因此不校验设备id。
+// 模板中值为掩码的敏感字段保留当前模板中的值
+func (u *ConfigUsecase) SetConfigTemplate(ctx context.Context, deviceClassID int, config proto.Message) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SetConfigTemplate",
+		trace.WithAttributes(attribute.Int("device.class_id", deviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if err = u.updater.validateConfigTemplate(deviceClassID, config); err != nil {
+		return err
+	}
+	key, field := GetConfigTemplateKey(), strconv.Itoa(deviceClassID)
+	if hasMaskedFields(config.ProtoReflect()) {
+		// 值为掩码的敏感字段保留当前模板中的值
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, key, field)
+		if err != nil {
+			return err
+		}
+		if config, err = restoreMaskedFields(config, values[0]); err != nil {
+			return err
+		}
+	}
+	marshal, err := proto.Marshal(config)
+	if err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "序列化配置模板时发生了错误:%v", err)
+	}
+	return u.repo.SaveDeviceConfig(ctx, key, field, marshal)
+}
+
+// GetConfigTemplate 查询设备类别的配置模板，并反序列化到config中，模板不存在时返回404错误
+func (u *ConfigUsecase) GetConfigTemplate(ctx context.Context, deviceClassID int, config proto.Message) error {
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, GetConfigTemplateKey(), strconv.Itoa(deviceClassID))
+	if err != nil {
+		return err
+	}
+	if values[0] == nil {
+		return errors.Newf(404, "Biz_Config_NotFound", "设备类别 %d 的配置模板不存在", deviceClassID)
+	}
+	if err = proto.Unmarshal(values[0], config); err != nil {
+		return errors.Newf(
+			500, "Biz_Config_Error", "反序列化配置模板时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// DeleteConfigTemplate 删除设备类别的配置模板，此后首次出现的设备不再下发配置模板
+func (u *ConfigUsecase) DeleteConfigTemplate(ctx context.Context, deviceClassID int) error {
+	return u.repo.DeleteDeviceConfig(ctx, GetConfigTemplateKey(), strconv.Itoa(deviceClassID))
+}
+
+// ApplyConfigTemplate 设备首次出现且没有任何已保存的配置时，以设备类别的配置模板作为其desired配置并下发给设备，
+// 返回是否下发了配置模板。各个实例共享设备是否出现过的记录，每个设备只检查一次，protoTemplate用于反序列化配置模板
+func (u *ConfigUsecase) ApplyConfigTemplate(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (applied bool, err error) {
+	first, err := u.firstSeen(ctx, info)
+	if err != nil || !first {
+		return false, err
+	}
+
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.ApplyConfigTemplate",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	applied, err = u.applyConfigTemplate(ctx, info, protoTemplate)
+	if err != nil {
+		// 下发失败的设备在下次出现时重新检查
+		u.unmarkDeviceSeen(ctx, info)
+		return false, err
+	}
+	return applied, nil
+}
+
+// firstSeen 返回设备是否为首次出现，即设备此前没有被任何实例检查过且设备没有任何已保存的配置
+func (u *ConfigUsecase) firstSeen(ctx context.Context, info *DeviceGeneralInfo) (bool, error) {
+	first, err := u.repo.MarkDeviceSeen(ctx, GetSeenDevicesKey(info), info.DeviceID)
+	if err != nil || !first {
+		return false, err
+	}
+	stored, err := u.hasStoredConfig(ctx, info)
+	if err != nil {
+		u.unmarkDeviceSeen(ctx, info)
+		return false, err
+	}
+	return !stored, nil
+}
+
+// unmarkDeviceSeen 删除设备出现过的记录，删除失败时只打印日志，此时设备不会再被检查
+func (u *ConfigUsecase) unmarkDeviceSeen(ctx context.Context, info *DeviceGeneralInfo) {
+	if err := u.repo.UnmarkDeviceSeen(ctx, GetSeenDevicesKey(info), info.DeviceID); err != nil {
+		u.logger.Errorf("删除设备 %s 出现过的记录时发生了错误:%v", info.DeviceID, err)
+	}
+}
+
+// hasStoredConfig 返回设备是否保存了reported配置或者desired配置
+func (u *ConfigUsecase) hasStoredConfig(ctx context.Context, info *DeviceGeneralInfo) (bool, error) {
+	for _, key := range []string{GetDeviceConfigKey(info), GetDeviceDesiredConfigKey(info)} {
+		values, err := u.repo.BatchGetDeviceConfigs(ctx, key, info.DeviceID)
+		if err != nil {
+			return false, err
+		}
+		if values[0] != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyConfigTemplate 以设备类别的配置模板作为设备的desired配置并下发给设备，设备类别没有配置模板时返回false
+func (u *ConfigUsecase) applyConfigTemplate(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (bool, error) {
+	config := proto.Clone(protoTemplate)
+	proto.Reset(config)
+	err := u.GetConfigTemplate(ctx, info.DeviceClassID, config)
+	if errors.Code(err) == 404 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	setDeviceConfigID(config, info.DeviceID)
+	if _, _, err = u.publishDesiredConfig(ctx, info, config, RevisionSourceTemplate, 0); err != nil {
+		return false, err
+	}
+	monitor.ConfigTemplatesApplied.WithLabelValues(strconv.Itoa(info.DeviceClassID)).Inc()
+	u.logger.Infof("设备 %s 首次出现，向其下发了设备类别 %d 的配置模板", info.DeviceID, info.DeviceClassID)
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"strconv"
+	"time"
+)
+
+// updateExpiryKey ctx中保存配置更新截止时间的key
+type updateExpiryKey struct{}
+
+// WithUpdateExpiry 返回携带了配置更新截止时间的ctx，以该ctx发起的配置更新在截止时间之前
+// 未下发给设备时不再下发，而是标记为expired
+func WithUpdateExpiry(ctx context.Context, expiresAt time.Time) context.Context {
+	return context.WithValue(ctx, updateExpiryKey{}, expiresAt)
+}
+
+// UpdateExpiryFromContext 返回ctx中配置更新的截止时间，没有截止时间时返回零值
+func UpdateExpiryFromContext(ctx context.Context) time.Time {
+	expiresAt, _ := ctx.Value(updateExpiryKey{}).(time.Time)
+	return expiresAt
+}
+
+// expiredAt 返回配置更新在t时是否已经超过截止时间
+func (u *DeviceConfigUpdate) expiredAt(t time.Time) bool {
+	return !u.expiresAt.IsZero() && !t.Before(u.expiresAt)
+}
+
+// expireDeviceConfigUpdate 将超过截止时间仍未被客户端确认的配置更新标记为expired，
+// 并从待确认队列中删除，客户端重新连接后不再重发
+func (updater *DeviceConfigUpdater) expireDeviceConfigUpdate(
+	ctx context.Context, clientID string, update *DeviceConfigUpdate) {
+	updater.SetUpdateStatus(ctx, update, UpdateStatusExpired, errors.Newf(410, "Biz_Config_Expired",
+		"配置更新在截止时间%s之前未下发给设备", update.expiresAt.Format(time.RFC3339)))
+	if err := updater.AckDeviceConfigUpdate(ctx, clientID, update); err != nil {
+		updater.logger.Errorf("删除 %v 的过期配置更新时发生了错误:%v", clientID, err)
+	}
+	monitor.ConfigUpdatesExpired.WithLabelValues(strconv.Itoa(update.deviceClassID)).Inc()
+	updater.logger.Infof("设备 %s 的配置更新 %s 超过了截止时间，不再下发", update.deviceID, update.updateID)
+}
+
+// expire 删除队列中已经超过截止时间的配置更新
+func (q *updateQueue) expire(ctx context.Context, t time.Time) {
+	updates := q.updates[:0]
+	for _, u := range q.updates {
+		if u.expiredAt(t) {
+			q.updater.expireDeviceConfigUpdate(ctx, q.clientID, u)
+		} else {
+			updates = append(updates, u)
+		}
+	}
+	for i := len(updates); i < len(q.updates); i++ {
+		q.updates[i] = nil
+	}
+	q.updates = updates
+}
+
+// nextExpiry 返回队列中最早的截止时间，队列中的配置更新都没有截止时间时返回零值
+func (q *updateQueue) nextExpiry() (expiresAt time.Time) {
+	for _, u := range q.updates {
+		if !u.expiresAt.IsZero() && (expiresAt.IsZero() || u.expiresAt.Before(expiresAt)) {
+			expiresAt = u.expiresAt
+		}
+	}
+	return expiresAt
+}
@@ -0,0 +1,106 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strconv"
+)
+
+// deviceConfigVersionField 设备配置中保存配置更新序号的字段，设备只应用序号大于已应用序号的配置更新
+const deviceConfigVersionField = "version"
+
+type ConfigUpdateSeqRepo interface {
+	// IncrDeviceConfigSeq 在key相应hash的field上自增并返回自增后的序号
+	IncrDeviceConfigSeq(ctx context.Context, key, field string) (int64, error)
+	// GetDeviceConfigSeq 查询key相应hash的field上的序号，field不存在时返回0
+	GetDeviceConfigSeq(ctx context.Context, key, field string) (int64, error)
+	// SetDeviceConfigSeq 将key相应hash的field上的序号设置为seq
+	SetDeviceConfigSeq(ctx context.Context, key, field string, seq int64) error
+	// CompareAndSetDeviceConfigSeq 仅在key相应hash的field上的序号等于old时将其设置为seq，field不存在时视为0，返回是否设置成功
+	CompareAndSetDeviceConfigSeq(ctx context.Context, key, field string, old, seq int64) (bool, error)
+}
+
+// setDeviceConfigVersion 利用反射将配置更新序号写入设备配置的version字段，设备配置没有该字段时不做处理
+func setDeviceConfigVersion(config proto.Message, seq int64) {
+	m := proto.MessageReflect(config)
+	fd := m.Descriptor().Fields().ByName(deviceConfigVersionField)
+	if fd == nil || fd.Kind() != protoreflect.Int64Kind {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfInt64(seq))
+}
+
+// updateQueue 在配置更新交给grpc流发送之前暂存配置更新，保证同一设备的配置更新按照序号递增的顺序下发。
+// 序号不大于同一设备已经入队的最大序号的配置更新视为过期，设备类别开启了合并时，
+// 同一设备尚未发送的配置更新被更新的配置更新取代
+type updateQueue struct {
+	updater  *DeviceConfigUpdater
+	clientID string
+	updates  []*DeviceConfigUpdate
+	// 各个设备已经入队的最大序号
+	lastSeq map[string]int64
+}
+
+func newUpdateQueue(updater *DeviceConfigUpdater, clientID string) *updateQueue {
+	return &updateQueue{
+		updater:  updater,
+		clientID: clientID,
+		lastSeq:  make(map[string]int64),
+	}
+}
+
+// push 将配置更新加入队列，旧版本实例发布的配置更新不包含序号，直接入队
+func (q *updateQueue) push(ctx context.Context, update *DeviceConfigUpdate) {
+	if update.seq == 0 {
+		q.updates = append(q.updates, update)
+		return
+	}
+
+	device := GetDeviceKey(&DeviceGeneralInfo{DeviceClassID: update.deviceClassID, DeviceID: update.deviceID})
+	if last := q.lastSeq[device]; update.seq <= last {
+		q.supersede(ctx, update, errors.Newf(409, "Biz_Config_Conflict",
+			"配置更新的序号%d不大于已经下发的序号%d", update.seq, last))
+		return
+	}
+	q.lastSeq[device] = update.seq
+
+	if q.updater.coalesceUpdates[update.deviceClassID] {
+		for i, u := range q.updates {
+			if u.seq != 0 && u.deviceClassID == update.deviceClassID && u.deviceID == update.deviceID {
+				q.supersede(ctx, u, errors.Newf(409, "Biz_Config_Conflict",
+					"配置更新被序号为%d的配置更新取代", update.seq))
+				q.updates = append(q.updates[:i], q.updates[i+1:]...)
+				break
+			}
+		}
+	}
+	q.updates = append(q.updates, update)
+}
+
+// peek 返回队首的配置更新，队列为空时返回nil
+func (q *updateQueue) peek() *DeviceConfigUpdate {
+	if len(q.updates) == 0 {
+		return nil
+	}
+	return q.updates[0]
+}
+
+// pop 删除队首的配置更新
+func (q *updateQueue) pop() {
+	q.updates[0] = nil
+	q.updates = q.updates[1:]
+}
+
+// supersede 将不再下发的配置更新标记为superseded，并从待确认队列中删除
+func (q *updateQueue) supersede(ctx context.Context, update *DeviceConfigUpdate, reason error) {
+	q.updater.SetUpdateStatus(ctx, update, UpdateStatusSuperseded, reason)
+	if err := q.updater.AckDeviceConfigUpdate(ctx, q.clientID, update); err != nil {
+		q.updater.logger.Errorf("删除 %v 的被取代的配置更新时发生了错误:%v", q.clientID, err)
+	}
+	monitor.ConfigUpdatesSuperseded.WithLabelValues(strconv.Itoa(update.deviceClassID)).Inc()
+	q.updater.logger.Infof("设备 %s 的配置更新 %s 不再下发:%v",
+		update.deviceID, update.updateID, errors.FromError(reason).Message)
+}
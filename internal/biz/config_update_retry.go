@@ -0,0 +1,102 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	utilApi "gitee.com/moyusir/util/api/util/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"time"
+)
+
+const (
+	// configUpdateWarningFieldName 配置更新失败的警告信息中使用的设备字段名
+	configUpdateWarningFieldName = "config"
+	// maxConfigUpdateWarnings 警告信息stream的最大长度
+	maxConfigUpdateWarnings = 1000
+)
+
+// RetryDeviceConfigUpdate 在客户端答复接收配置更新失败后调用，reason为失败的原因，返回重发配置更新前
+// 需要等待的退避时间。配置更新的发送次数达到上限时，将其标记为failed、从待确认队列中删除并上报警告信息，此时返回false。
+// 配置更新在退避结束前就会超过截止时间时，将其标记为expired并从待确认队列中删除，同样返回false
+func (updater *DeviceConfigUpdater) RetryDeviceConfigUpdate(ctx context.Context, clientID string,
+	info *DeviceGeneralInfo, update *DeviceConfigUpdate, reason error) (backoff time.Duration, retry bool) {
+	// 客户端重新连接后重发的配置更新以及转移到新clientID的配置更新不包含此前被拒绝的次数，
+	// 因此以状态中记录的次数为准，使发送次数的上限在重新连接之间同样有效
+	if attempts := updater.getUpdateAttempts(ctx, update); attempts > update.attempts {
+		update.attempts = attempts
+	}
+	update.attempts++
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("config.update_attempts", update.attempts))
+	if update.attempts >= updater.maxUpdateAttempts {
+		updater.failDeviceConfigUpdate(ctx, clientID, info, update, reason)
+		return 0, false
+	}
+	updater.SetUpdateStatus(ctx, update, UpdateStatusRetrying, reason)
+
+	// 退避时间从minRetryBackoff开始，每次重发翻倍，不超过maxRetryBackoff
+	backoff = updater.minRetryBackoff
+	for i := int64(1); i < update.attempts && backoff < updater.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > updater.maxRetryBackoff {
+		backoff = updater.maxRetryBackoff
+	}
+	if update.expiredAt(time.Now().Add(backoff)) {
+		updater.expireDeviceConfigUpdate(ctx, clientID, update)
+		return 0, false
+	}
+	return backoff, true
+}
+
+// failDeviceConfigUpdate 将多次被客户端拒绝的配置更新标记为failed，并以警告信息的形式上报。
+// 配置更新同时从待确认队列中删除，客户端重新连接后不再重发
+func (updater *DeviceConfigUpdater) failDeviceConfigUpdate(ctx context.Context, clientID string,
+	info *DeviceGeneralInfo, update *DeviceConfigUpdate, reason error) {
+	reason = errors.Newf(400, "Biz_Config_Error",
+		"配置更新发送%d次后仍被客户端拒绝:%s", update.attempts, errors.FromError(reason).Message)
+	updater.SetUpdateStatus(ctx, update, UpdateStatusFailed, reason)
+	monitor.ConfigUpdateFailures.WithLabelValues(strconv.Itoa(info.DeviceClassID)).Inc()
+
+	if err := updater.AckDeviceConfigUpdate(ctx, clientID, update); err != nil {
+		updater.logger.Errorf("删除 %v 的失败配置更新时发生了错误:%v", clientID, err)
+	}
+	if err := updater.reportUpdateFailure(ctx, info, update, reason); err != nil {
+		updater.logger.Errorf("上报设备 %s 的配置更新失败的警告信息时发生了错误:%v", info.DeviceID, err)
+	}
+	updater.logger.Warnf("设备 %s 的配置更新 %s 失败:%v", info.DeviceID, update.updateID, reason)
+}
+
+// reportUpdateFailure 将配置更新失败的警告信息追加到警告信息stream中，警告的时间范围为配置更新创建至失败的时间
+func (updater *DeviceConfigUpdater) reportUpdateFailure(
+	ctx context.Context, info *DeviceGeneralInfo, update *DeviceConfigUpdate, reason error) error {
+	end := time.Now()
+	start := end
+	if update.updateID != "" {
+		if status, err := updater.GetConfigUpdateStatus(ctx, update.updateID); err == nil && !status.CreatedAt.IsZero() {
+			start = status.CreatedAt
+		}
+	}
+
+	warning := &utilApi.Warning{
+		DeviceClassId:   int32(info.DeviceClassID),
+		DeviceId:        info.DeviceID,
+		DeviceFieldName: configUpdateWarningFieldName,
+		WarningMessage:  fmt.Sprintf("配置更新 %s 失败:%v", update.updateID, errors.FromError(reason).Message),
+		Start:           timestamppb.New(start),
+		End:             timestamppb.New(end),
+	}
+	msg, err := protojson.Marshal(warning)
+	if err != nil {
+		return errors.Newf(500, "Biz_Config_Error", "序列化配置更新失败的警告信息时发生了错误:%v", err)
+	}
+	_, _, err = updater.pubSubClient.AddStreamMsg(
+		ctx, GetConfigUpdateWarningsKey(), string(msg), maxConfigUpdateWarnings, updater.updateStatusTTL())
+	return err
+}
@@ -0,0 +1,245 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"time"
+)
+
+// 配置更新的状态
+const (
+	// UpdateStatusQueued 配置更新已经加入clientID的待确认队列
+	UpdateStatusQueued = "queued"
+	// UpdateStatusSent 配置更新已经通过grpc流发送给客户端
+	UpdateStatusSent = "sent"
+	// UpdateStatusRetrying 客户端答复接收配置更新失败，配置更新等待重发
+	UpdateStatusRetrying = "retrying"
+	// UpdateStatusAcked 客户端答复接收配置更新成功
+	UpdateStatusAcked = "acked"
+	// UpdateStatusApplied 配置更新已经作为设备上报的配置保存
+	UpdateStatusApplied = "applied"
+	// UpdateStatusFailed 客户端答复接收配置更新失败，或者服务端处理答复时发生了错误
+	UpdateStatusFailed = "failed"
+	// UpdateStatusExpired 配置更新超过有效期仍未被客户端确认，或者超过截止时间仍未下发给设备
+	UpdateStatusExpired = "expired"
+	// UpdateStatusSuperseded 配置更新在下发前被同一设备序号更大的配置更新取代
+	UpdateStatusSuperseded = "superseded"
+)
+
+// 配置更新状态在redis hash中的各个field
+const (
+	updateStatusFieldClassID   = "device_class_id"
+	updateStatusFieldDeviceID  = "device_id"
+	updateStatusFieldClientID  = "client_id"
+	updateStatusFieldStatus    = "status"
+	updateStatusFieldError     = "error"
+	updateStatusFieldCreatedAt = "created_at"
+	updateStatusFieldUpdatedAt = "updated_at"
+	updateStatusFieldAttempts  = "attempts"
+	updateStatusFieldSeq       = "seq"
+	updateStatusFieldExpiresAt = "expires_at"
+)
+
+// ConfigUpdateStatus 配置更新的下发状态
+type ConfigUpdateStatus struct {
+	UpdateID      string
+	DeviceClassID int
+	DeviceID      string
+	ClientID      string
+	Status        string
+	// 配置更新失败时的原因
+	Error string
+	// 配置更新被客户端拒绝的次数
+	Attempts int64
+	// 配置更新在设备上的序号
+	Seq int64
+	// 配置更新的截止时间，零值表示没有截止时间
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Done 返回配置更新是否已经结束，即已经被设备应用、失败、过期或者被取代
+func (s *ConfigUpdateStatus) Done() bool {
+	return s.Status == UpdateStatusApplied || s.Status == UpdateStatusFailed ||
+		s.Status == UpdateStatusExpired || s.Status == UpdateStatusSuperseded
+}
+
+type ConfigUpdateStatusRepo interface {
+	// SaveConfigUpdateStatus 将fields保存到key相应的hash中，并刷新hash的过期时间
+	SaveConfigUpdateStatus(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error
+	// GetConfigUpdateStatus 查询key相应hash中的全部field，hash不存在时返回404错误
+	GetConfigUpdateStatus(ctx context.Context, key string) (map[string]string, error)
+}
+
+// newConfigUpdateID 产生全局唯一的配置更新id
+func newConfigUpdateID() string {
+	return uuid.NewString()
+}
+
+// createUpdateStatus 以queued状态记录新的配置更新，expiresAt为零值时不记录截止时间
+func (updater *DeviceConfigUpdater) createUpdateStatus(ctx context.Context,
+	updateID, clientID string, info *DeviceGeneralInfo, seq int64, expiresAt time.Time) error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	fields := map[string]string{
+		updateStatusFieldClassID:   strconv.Itoa(info.DeviceClassID),
+		updateStatusFieldDeviceID:  info.DeviceID,
+		updateStatusFieldClientID:  clientID,
+		updateStatusFieldStatus:    UpdateStatusQueued,
+		updateStatusFieldSeq:       strconv.FormatInt(seq, 10),
+		updateStatusFieldCreatedAt: now,
+		updateStatusFieldUpdatedAt: now,
+	}
+	if !expiresAt.IsZero() {
+		fields[updateStatusFieldExpiresAt] = strconv.FormatInt(expiresAt.UnixNano(), 10)
+	}
+	return updater.pubSubClient.SaveConfigUpdateStatus(
+		ctx, GetConfigUpdateStatusKey(updateID), fields, updater.updateStatusTTL())
+}
+
+// SetUpdateStatus 更新配置更新的状态，reason为配置更新失败的原因。状态记录只用于查询，
+// 因此记录失败时只打印日志，不影响配置更新的下发
+func (updater *DeviceConfigUpdater) SetUpdateStatus(
+	ctx context.Context, update *DeviceConfigUpdate, status string, reason error) {
+	if update.updateID == "" {
+		return
+	}
+	updater.setUpdateStatus(ctx, update.updateID, status, reason, update.attempts)
+}
+
+// setUpdateStatus attempts为0时不记录配置更新被拒绝的次数
+func (updater *DeviceConfigUpdater) setUpdateStatus(
+	ctx context.Context, updateID, status string, reason error, attempts int64) {
+	fields := map[string]string{
+		updateStatusFieldStatus:    status,
+		updateStatusFieldError:     "",
+		updateStatusFieldUpdatedAt: strconv.FormatInt(time.Now().UnixNano(), 10),
+	}
+	if reason != nil {
+		fields[updateStatusFieldError] = reason.Error()
+	}
+	if attempts > 0 {
+		fields[updateStatusFieldAttempts] = strconv.FormatInt(attempts, 10)
+	}
+
+	err := updater.pubSubClient.SaveConfigUpdateStatus(
+		ctx, GetConfigUpdateStatusKey(updateID), fields, updater.updateStatusTTL())
+	if err != nil {
+		updater.logger.Errorf("记录配置更新 %s 的状态 %s 时发生了错误:%v", updateID, status, err)
+	}
+	// 配置更新结束时，通知等待设备确认的实例，这些实例与持有客户端grpc流的实例可能不同
+	if (&ConfigUpdateStatus{Status: status}).Done() {
+		err = updater.pubSubClient.PublishMsg(ctx, GetConfigUpdateResultChannel(updateID), status)
+		if err != nil {
+			updater.logger.Errorf("通知配置更新 %s 的结果时发生了错误:%v", updateID, err)
+		}
+	}
+	trace.SpanFromContext(ctx).AddEvent("config_update."+status,
+		trace.WithAttributes(attribute.String("config.update_id", updateID)))
+}
+
+// getUpdateAttempts 查询状态中记录的配置更新被客户端拒绝的次数，查询失败时只打印日志并返回0
+func (updater *DeviceConfigUpdater) getUpdateAttempts(ctx context.Context, update *DeviceConfigUpdate) int64 {
+	if update.updateID == "" {
+		return 0
+	}
+	fields, err := updater.pubSubClient.GetConfigUpdateStatus(ctx, GetConfigUpdateStatusKey(update.updateID))
+	if err != nil {
+		updater.logger.Errorf("查询配置更新 %s 的发送次数时发生了错误:%v", update.updateID, err)
+		return 0
+	}
+	attempts, _ := strconv.ParseInt(fields[updateStatusFieldAttempts], 10, 64)
+	return attempts
+}
+
+// GetConfigUpdateStatus 查询配置更新的下发状态，超过有效期仍未被确认的配置更新视为过期
+func (updater *DeviceConfigUpdater) GetConfigUpdateStatus(
+	ctx context.Context, updateID string) (status *ConfigUpdateStatus, err error) {
+	ctx, span := monitor.StartSpan(ctx, "DeviceConfigUpdater.GetConfigUpdateStatus",
+		trace.WithAttributes(attribute.String("config.update_id", updateID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if updateID == "" {
+		return nil, errors.New(400, "Biz_Config_Error", "配置更新的id不能为空")
+	}
+	fields, err := updater.pubSubClient.GetConfigUpdateStatus(ctx, GetConfigUpdateStatusKey(updateID))
+	if err != nil {
+		return nil, err
+	}
+
+	classID, _ := strconv.Atoi(fields[updateStatusFieldClassID])
+	attempts, _ := strconv.ParseInt(fields[updateStatusFieldAttempts], 10, 64)
+	seq, _ := strconv.ParseInt(fields[updateStatusFieldSeq], 10, 64)
+	status = &ConfigUpdateStatus{
+		UpdateID:      updateID,
+		DeviceClassID: classID,
+		DeviceID:      fields[updateStatusFieldDeviceID],
+		ClientID:      fields[updateStatusFieldClientID],
+		Status:        fields[updateStatusFieldStatus],
+		Error:         fields[updateStatusFieldError],
+		Attempts:      attempts,
+		Seq:           seq,
+		ExpiresAt:     parseUnixNano(fields[updateStatusFieldExpiresAt]),
+		CreatedAt:     parseUnixNano(fields[updateStatusFieldCreatedAt]),
+		UpdatedAt:     parseUnixNano(fields[updateStatusFieldUpdatedAt]),
+	}
+	// 客户端长时间未重新连接时，过期的配置更新不会被重发，也就不会被标记为expired
+	if (status.Status == UpdateStatusQueued || status.Status == UpdateStatusSent ||
+		status.Status == UpdateStatusRetrying) &&
+		(time.Since(status.CreatedAt) > updater.pendingUpdateTTL ||
+			!status.ExpiresAt.IsZero() && !time.Now().Before(status.ExpiresAt)) {
+		status.Status = UpdateStatusExpired
+	}
+	return status, nil
+}
+
+// WaitForUpdateResult 等待配置更新结束或者超时，返回等待结束时配置更新的状态
+func (updater *DeviceConfigUpdater) WaitForUpdateResult(
+	ctx context.Context, updateID string, timeout time.Duration) (status *ConfigUpdateStatus, err error) {
+	ctx, span := monitor.StartSpan(ctx, "DeviceConfigUpdater.WaitForUpdateResult",
+		trace.WithAttributes(
+			attribute.String("config.update_id", updateID),
+			attribute.String("config.wait_timeout", timeout.String()),
+		))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	results, err := updater.pubSubClient.GetMsgChannel(waitCtx, GetConfigUpdateResultChannel(updateID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 订阅生效前配置更新可能已经结束，因此订阅后先查询一次状态
+	status, err = updater.GetConfigUpdateStatus(ctx, updateID)
+	if err != nil || status.Done() {
+		return status, err
+	}
+	select {
+	case <-results:
+	case <-waitCtx.Done():
+	}
+	status, err = updater.GetConfigUpdateStatus(ctx, updateID)
+	if err == nil {
+		span.SetAttributes(attribute.String("config.update_status", status.Status))
+	}
+	return status, err
+}
+
+// updateStatusTTL 配置更新状态的保留时间，保证配置更新过期后仍然可以查询其状态
+func (updater *DeviceConfigUpdater) updateStatusTTL() time.Duration {
+	return 2 * updater.pendingUpdateTTL
+}
+
+func parseUnixNano(v string) time.Time {
+	nano, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nano)
+}
@@ -2,11 +2,33 @@ package biz
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 待确认配置更新队列的缺省最大长度以及缺省有效期
+const (
+	defaultMaxPendingUpdates = 100
+	defaultPendingUpdateTTL  = 24 * time.Hour
+)
+
+// 客户端拒绝配置更新时，缺省的最大发送次数以及重发的退避时间
+const (
+	defaultMaxUpdateAttempts = 3
+	defaultMinRetryBackoff   = time.Second
+	defaultMaxRetryBackoff   = 30 * time.Second
 )
 
 // DeviceConfigUpdater 负责接收和发送配置更新的消息
@@ -14,7 +36,18 @@ type DeviceConfigUpdater struct {
 	pubSubClient UnionRepo
 	// DeviceUpdateChannelsKey 保存设备和其更新channel对应关系的hash的key
 	deviceUpdateChannelsKey string
-	logger                  *log.Helper
+	// 每个clientID的待确认配置更新队列的最大长度以及配置更新的有效期
+	maxPendingUpdates int64
+	pendingUpdateTTL  time.Duration
+	// 配置更新的最大发送次数以及重发的退避时间
+	maxUpdateAttempts int64
+	minRetryBackoff   time.Duration
+	maxRetryBackoff   time.Duration
+	// 开启了配置更新合并的设备类别
+	coalesceUpdates map[int]bool
+	// 各设备类别注册的设备配置校验器
+	validators map[int][]ConfigValidator
+	logger     *log.Helper
 }
 
 // PubSubClient 发布订阅的客户端
@@ -22,85 +55,294 @@ type PubSubClient interface {
 	// GetMsgChannel 返回订阅频道
 	GetMsgChannel(ctx context.Context, name string) (msgChan <-chan string, err error)
 	// PublishMsg 向指定的channel发送消息
-	PublishMsg(channel string, message ...string) error
-	AddFieldValuePair(key, field, value string) error
-	GetValueOfField(key, field string) (value string, err error)
+	PublishMsg(ctx context.Context, channel string, message ...string) error
+	AddFieldValuePair(ctx context.Context, key, field, value string) error
+	GetValueOfField(ctx context.Context, key, field string) (value string, err error)
+	// LookupValueOfField 与GetValueOfField相同，但field不存在时不视为错误，而是返回false
+	LookupValueOfField(ctx context.Context, key, field string) (value string, ok bool, err error)
+	// AddSetMember 向指定的set添加成员
+	AddSetMember(ctx context.Context, key, member string) error
+	// RemoveSetMember 删除指定set中的成员
+	RemoveSetMember(ctx context.Context, key, member string) error
+	// GetSetMembers 查询指定set中的全部成员
+	GetSetMembers(ctx context.Context, key string) ([]string, error)
 	// CreateClientID 产生一个分布式全局唯一的clientID
-	CreateClientID() (string, error)
+	CreateClientID(ctx context.Context) (string, error)
+	// AddStreamMsg 向指定的stream追加消息并返回消息id，stream长度超出maxLen时删除并返回最旧的消息，
+	// 并在ttl内没有追加新消息时删除整个stream
+	AddStreamMsg(ctx context.Context,
+		stream, msg string, maxLen int64, ttl time.Duration) (id string, trimmed []*StreamMsg, err error)
+	// GetStreamMsgs 按照从旧到新的顺序返回stream中的全部消息
+	GetStreamMsgs(ctx context.Context, stream string) ([]*StreamMsg, error)
+	// DeleteStreamMsgs 删除stream中指定id的消息
+	DeleteStreamMsgs(ctx context.Context, stream string, ids ...string) error
+	ConfigCodec
+}
+
+// StreamMsg stream中的消息，id的格式为<毫秒时间戳>-<序号>
+type StreamMsg struct {
+	ID    string
+	Value string
 }
 
-func NewDeviceConfigUpdater(repo UnionRepo, logger log.Logger) *DeviceConfigUpdater {
-	return &DeviceConfigUpdater{
+// ConfigUpdateMessage 通过发布订阅传递的配置更新消息
+type ConfigUpdateMessage struct {
+	// 以ConfigCodec编码的设备配置protobuf二进制信息，启用设备配置的静态加密时为密文
+	Config string `json:"config"`
+	// 发布消息时的追踪上下文，使订阅方所在的实例能够延续发起配置更新的链路
+	Carrier propagation.MapCarrier `json:"carrier,omitempty"`
+	// 配置更新在待确认队列中的消息id，用于确认配置更新以及去除重复的配置更新
+	ID string `json:"id,omitempty"`
+	// 配置更新的id，用于记录配置更新的下发状态
+	UpdateID string `json:"updateID,omitempty"`
+	// 接收配置更新的设备以及配置更新在该设备上的序号
+	DeviceClassID int    `json:"deviceClassID"`
+	DeviceID      string `json:"deviceID,omitempty"`
+	Seq           int64  `json:"seq,omitempty"`
+	// 配置更新的截止时间，unix纳秒时间戳，为0时没有截止时间
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+}
+
+// DeviceConfigUpdate 订阅方接收到的配置更新
+type DeviceConfigUpdate struct {
+	Config  proto.Message
+	carrier propagation.MapCarrier
+	// 待确认队列中的消息id以及配置更新的id，旧版本实例发布的配置更新不包含这两个id
+	id       string
+	updateID string
+	// 接收配置更新的设备以及配置更新在该设备上的序号，旧版本实例发布的配置更新不包含序号
+	deviceClassID int
+	deviceID      string
+	seq           int64
+	// 配置更新的截止时间，零值表示没有截止时间
+	expiresAt time.Time
+	// 配置更新是否来自待确认队列的重发
+	replayed bool
+	// 配置更新被客户端拒绝的次数
+	attempts int64
+}
+
+// UpdateID 返回配置更新的id，旧版本实例发布的配置更新返回空字符串
+func (u *DeviceConfigUpdate) UpdateID() string {
+	return u.updateID
+}
+
+// Context 返回延续了发布方链路的ctx
+func (u *DeviceConfigUpdate) Context(ctx context.Context) context.Context {
+	if len(u.carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, u.carrier)
+}
+
+func NewDeviceConfigUpdater(c *conf.Data, repo UnionRepo, logger log.Logger) *DeviceConfigUpdater {
+	updater := &DeviceConfigUpdater{
 		pubSubClient:            repo,
 		deviceUpdateChannelsKey: fmt.Sprintf("%s:device:update:channel", conf.Username),
+		maxPendingUpdates:       c.PendingUpdates.GetMaxLen(),
+		pendingUpdateTTL:        c.PendingUpdates.GetTtl().AsDuration(),
+		maxUpdateAttempts:       c.UpdateRetry.GetMaxAttempts(),
+		minRetryBackoff:         c.UpdateRetry.GetMinBackoff().AsDuration(),
+		maxRetryBackoff:         c.UpdateRetry.GetMaxBackoff().AsDuration(),
+		coalesceUpdates:         make(map[int]bool),
+		validators:              make(map[int][]ConfigValidator),
 		logger:                  log.NewHelper(logger),
 	}
+	for classID, class := range c.DeviceClasses {
+		updater.coalesceUpdates[int(classID)] = class.GetCoalesceUpdates()
+		if fields := class.GetRequiredFields(); len(fields) != 0 {
+			updater.RegisterConfigValidator(int(classID), requiredFieldsValidator(fields))
+		}
+	}
+	if updater.maxPendingUpdates <= 0 {
+		updater.maxPendingUpdates = defaultMaxPendingUpdates
+	}
+	if updater.pendingUpdateTTL <= 0 {
+		updater.pendingUpdateTTL = defaultPendingUpdateTTL
+	}
+	if updater.maxUpdateAttempts <= 0 {
+		updater.maxUpdateAttempts = defaultMaxUpdateAttempts
+	}
+	if updater.minRetryBackoff <= 0 {
+		updater.minRetryBackoff = defaultMinRetryBackoff
+	}
+	if updater.maxRetryBackoff < updater.minRetryBackoff {
+		updater.maxRetryBackoff = defaultMaxRetryBackoff
+	}
+	return updater
 }
 
-// UpdateDeviceConfig 更新设备的配置
-func (updater *DeviceConfigUpdater) UpdateDeviceConfig(info *DeviceGeneralInfo, config proto.Message) error {
+// UpdateDeviceConfig 更新设备的配置，返回接收配置更新消息的clientID以及配置更新的id，
+// 配置不满足设备类别的校验规则时返回400错误，不会下发给设备
+func (updater *DeviceConfigUpdater) UpdateDeviceConfig(
+	ctx context.Context, info *DeviceGeneralInfo, config proto.Message) (clientID, updateID string, err error) {
+	ctx, span := monitor.StartSpan(ctx, "DeviceConfigUpdater.UpdateDeviceConfig",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(deviceAttributes(info)...),
+	)
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if err := updater.ValidateDeviceConfig(info, proto.MessageV2(config)); err != nil {
+		return "", "", err
+	}
 	deviceKey := GetDeviceKey(info)
 	updateChanName, err := updater.pubSubClient.GetValueOfField(
-		updater.deviceUpdateChannelsKey, deviceKey)
+		ctx, updater.deviceUpdateChannelsKey, deviceKey)
 	if err != nil {
-		return err
+		return "", "", err
 	}
+	span.SetAttributes(attribute.String("messaging.destination", updateChanName))
 
-	// 将proto msg转换为十六进制字符串进行publish
+	// 为配置更新分配设备内递增的序号并写入配置的version字段，设备据此拒绝过期的配置更新
+	seq, err := updater.pubSubClient.IncrDeviceConfigSeq(ctx, GetDeviceConfigSeqKey(info), info.DeviceID)
+	if err != nil {
+		return "", "", err
+	}
+	span.SetAttributes(attribute.Int64("config.seq", seq))
+	config = proto.Clone(config)
+	setDeviceConfigVersion(config, seq)
+
+	// 将proto msg编码为字符串进行publish，启用加密时待确认队列以及频道中传递的均为密文
 	marshal, err := proto.Marshal(config)
 	if err != nil {
-		return errors.Newf(
+		return "", "", errors.Newf(
 			500, "Biz_Config_Error",
 			"对设备配置信息进行protobuf序列化时发生了错误:%v", err,
 		)
 	}
-	msg := fmt.Sprintf("%x", marshal)
+	// 配置更新消息会在clientID的待确认队列之间转移，因此以设备以及配置更新id而不是所在的redis键作为编码的上下文
+	updateID = newConfigUpdateID()
+	encoded, err := updater.pubSubClient.EncodeConfig(marshal, deviceKey, updateID)
+	if err != nil {
+		return "", "", err
+	}
+	// 将当前的追踪上下文随配置一同发布
+	carrier := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	message := &ConfigUpdateMessage{
+		Config:        encoded,
+		Carrier:       carrier,
+		UpdateID:      updateID,
+		DeviceClassID: info.DeviceClassID,
+		DeviceID:      info.DeviceID,
+		Seq:           seq,
+	}
+	expiresAt := UpdateExpiryFromContext(ctx)
+	if !expiresAt.IsZero() {
+		message.ExpiresAt = expiresAt.UnixNano()
+		span.SetAttributes(attribute.String("config.expires_at", expiresAt.Format(time.RFC3339)))
+	}
+	span.SetAttributes(attribute.String("config.update_id", message.UpdateID))
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return "", "", errors.Newf(
+			500, "Biz_Config_Error", "序列化配置更新消息时发生了错误:%v", err)
+	}
 
-	// 发布消息
-	err = updater.pubSubClient.PublishMsg(updateChanName, msg)
+	// 先将配置更新追加到clientID的待确认队列中，保证客户端未连接时配置更新不会丢失
+	var trimmed []*StreamMsg
+	message.ID, trimmed, err = updater.pubSubClient.AddStreamMsg(
+		ctx, GetPendingUpdatesKey(updateChanName), string(msg),
+		updater.maxPendingUpdates, updater.pendingUpdateTTL)
 	if err != nil {
-		return err
+		return "", "", err
+	}
+	span.SetAttributes(attribute.String("messaging.message_id", message.ID))
+	if err := updater.createUpdateStatus(ctx, message.UpdateID, updateChanName, info, seq, expiresAt); err != nil {
+		updater.logger.Errorf("记录配置更新 %s 的状态时发生了错误:%v", message.UpdateID, err)
+	}
+	updater.dropPendingUpdates(ctx, updateChanName, trimmed)
+	msg, err = json.Marshal(message)
+	if err != nil {
+		return "", "", errors.Newf(
+			500, "Biz_Config_Error", "序列化配置更新消息时发生了错误:%v", err)
 	}
 
-	return nil
+	// 再发布消息，通知已经连接的客户端
+	err = updater.pubSubClient.PublishMsg(ctx, updateChanName, string(msg))
+	if err != nil {
+		return "", "", err
+	}
+
+	return updateChanName, message.UpdateID, nil
 }
 
-// GetDeviceUpdateMsgChannel 获得推送clientID相关的配置更新消息的channel
-// 这里直接将clientID作为了channel的名称
+// GetDeviceUpdateMsgChannel 返回clientID相应的配置更新channel，首先按照从旧到新的顺序重发
+// 待确认队列中尚未过期的配置更新，之后转发通过频道实时发布的配置更新。
+// 配置更新在交给channel之前经过updateQueue，同一设备的配置更新按照序号递增的顺序下发，
+// 超过截止时间仍未交给channel的配置更新被丢弃
 func (updater *DeviceConfigUpdater) GetDeviceUpdateMsgChannel(
-	ctx context.Context, clientID string, protoTemplate proto.Message) (<-chan proto.Message, error) {
+	ctx context.Context, clientID string, protoTemplate proto.Message) (<-chan *DeviceConfigUpdate, error) {
+	// 先订阅频道再查询待确认队列，避免两者之间发布的配置更新丢失，重复的配置更新依据消息id去除
 	msgChannel, err := updater.pubSubClient.GetMsgChannel(ctx, clientID)
 	if err != nil {
 		return nil, err
 	}
+	pending, err := updater.getPendingUpdates(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
 
-	updateMsg := make(chan proto.Message)
+	updateMsg := make(chan *DeviceConfigUpdate)
 	go func() {
 		defer close(updateMsg)
+
+		queue := newUpdateQueue(updater, clientID)
+		// 最后一个重发的配置更新的消息id
+		var lastID string
+		for _, m := range pending {
+			update, err := updater.decodeUpdateMessage(m.Value, protoTemplate)
+			if err != nil {
+				continue
+			}
+			update.id, lastID = m.ID, m.ID
+			update.replayed = true
+			queue.push(ctx, update)
+		}
+
 		for {
+			queue.expire(ctx, time.Now())
+			// 队列为空时out为nil，select不会选择发送的分支
+			var (
+				out    chan<- *DeviceConfigUpdate
+				expiry <-chan time.Time
+				timer  *time.Timer
+			)
+			next := queue.peek()
+			if next != nil {
+				out = updateMsg
+			}
+			// 队列中最早的截止时间到达时重新检查队列
+			if expiresAt := queue.nextExpiry(); !expiresAt.IsZero() {
+				timer = time.NewTimer(time.Until(expiresAt))
+				expiry = timer.C
+			}
 			select {
 			case <-ctx.Done():
 				return
-			case msg := <-msgChannel:
-				// 将十六进制的字符串转换为二进制信息，并反序列化为proto message
-				// TODO 忽略反序列化失败的消息?
-				var b []byte
-				_, err := fmt.Sscanf(msg, "%x", &b)
+			case msg, ok := <-msgChannel:
+				// 订阅被关闭时结束channel，尚未下发的配置更新保留在待确认队列中，客户端重新连接后重发
+				if !ok {
+					updater.logger.Infof("%v 的配置更新频道的订阅已经关闭", clientID)
+					return
+				}
+				update, err := updater.decodeUpdateMessage(msg, protoTemplate)
 				if err != nil {
-					updater.logger.Errorf(
-						"反序列化接收到的配置更新十六进制字符串时发生了错误:%v", err,
-					)
 					continue
 				}
-
-				err = proto.Unmarshal(b, protoTemplate)
-				if err != nil {
-					updater.logger.Errorf(
-						"将设备配置的二进制信息反序列化为proto message时发生了错误:%v", err,
-					)
+				if update.id != "" && lastID != "" && compareStreamID(update.id, lastID) <= 0 {
 					continue
 				}
-				updateMsg <- proto.Clone(protoTemplate)
+				queue.push(ctx, update)
+			case out <- next:
+				queue.pop()
+				if next.replayed {
+					monitor.PendingUpdatesReplayed.Inc()
+				}
+			case <-expiry:
+			}
+			if timer != nil {
+				timer.Stop()
 			}
 		}
 	}()
@@ -108,26 +350,338 @@ func (updater *DeviceConfigUpdater) GetDeviceUpdateMsgChannel(
 	return updateMsg, nil
 }
 
+// AckDeviceConfigUpdate 在客户端确认接收配置更新后，将其从clientID的待确认队列中删除
+func (updater *DeviceConfigUpdater) AckDeviceConfigUpdate(
+	ctx context.Context, clientID string, update *DeviceConfigUpdate) error {
+	if update.id == "" {
+		return nil
+	}
+	return updater.pubSubClient.DeleteStreamMsgs(ctx, GetPendingUpdatesKey(clientID), update.id)
+}
+
+// getPendingUpdates 查询clientID的待确认队列中尚未过期的配置更新，并删除已经过期的配置更新
+func (updater *DeviceConfigUpdater) getPendingUpdates(ctx context.Context, clientID string) ([]*StreamMsg, error) {
+	key := GetPendingUpdatesKey(clientID)
+	msgs, err := updater.pubSubClient.GetStreamMsgs(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pending  = make([]*StreamMsg, 0, len(msgs))
+		expired  []string
+		deadline = time.Now().Add(-updater.pendingUpdateTTL)
+	)
+	for _, m := range msgs {
+		if ms, _, ok := parseStreamID(m.ID); ok && time.UnixMilli(int64(ms)).Before(deadline) {
+			expired = append(expired, m.ID)
+			if message, err := unmarshalUpdateMessage(m.Value); err == nil && message.UpdateID != "" {
+				updater.setUpdateStatus(ctx, message.UpdateID, UpdateStatusExpired, nil, 0)
+			}
+		} else {
+			pending = append(pending, m)
+		}
+	}
+	if len(expired) != 0 {
+		monitor.PendingUpdatesExpired.Add(float64(len(expired)))
+		if err := updater.pubSubClient.DeleteStreamMsgs(ctx, key, expired...); err != nil {
+			updater.logger.Errorf("删除 %v 的过期配置更新时发生了错误:%v", clientID, err)
+		}
+	}
+	return pending, nil
+}
+
+// hasPendingUpdate 返回clientID的待确认队列中设备最新的配置更新是否与config一致，比较时忽略配置的version字段。
+// 一致时设备重新连接后会收到重发的配置更新，无需再次下发相同的配置
+func (updater *DeviceConfigUpdater) hasPendingUpdate(
+	ctx context.Context, clientID string, info *DeviceGeneralInfo, config proto.Message) (bool, error) {
+	pending, err := updater.getPendingUpdates(ctx, clientID)
+	if err != nil {
+		return false, err
+	}
+
+	var latest *StreamMsg
+	for _, m := range pending {
+		message, err := unmarshalUpdateMessage(m.Value)
+		if err == nil && message.DeviceClassID == info.DeviceClassID && message.DeviceID == info.DeviceID {
+			latest = m
+		}
+	}
+	if latest == nil {
+		return false, nil
+	}
+	update, err := updater.decodeUpdateMessage(latest.Value, proto.Clone(config))
+	if err != nil {
+		return false, nil
+	}
+	config = proto.Clone(config)
+	setDeviceConfigVersion(config, 0)
+	setDeviceConfigVersion(update.Config, 0)
+	return proto.Equal(config, update.Config), nil
+}
+
+// dropPendingUpdates 将因待确认队列超出长度上限而被删除的配置更新标记为失败
+func (updater *DeviceConfigUpdater) dropPendingUpdates(ctx context.Context, clientID string, trimmed []*StreamMsg) {
+	if len(trimmed) == 0 {
+		return
+	}
+	monitor.PendingUpdatesDropped.Add(float64(len(trimmed)))
+	reason := errors.Newf(503, "Biz_Config_Dropped",
+		"客户端 %s 的待确认队列超过了%d条的长度上限，配置更新在确认之前被丢弃", clientID, updater.maxPendingUpdates)
+	for _, m := range trimmed {
+		if message, err := unmarshalUpdateMessage(m.Value); err == nil && message.UpdateID != "" {
+			updater.setUpdateStatus(ctx, message.UpdateID, UpdateStatusFailed, reason, 0)
+		}
+	}
+}
+
+// migratePendingUpdates 将设备在旧clientID的待确认队列中的配置更新转移到新clientID的待确认队列中，
+// 并通过新clientID的频道发布，使设备以新的clientID重新连接后仍能收到尚未确认的配置更新。
+// 旧版本实例发布的配置更新不包含设备信息，无法转移
+func (updater *DeviceConfigUpdater) migratePendingUpdates(
+	ctx context.Context, from, to string, info *DeviceGeneralInfo) error {
+	key := GetPendingUpdatesKey(from)
+	msgs, err := updater.pubSubClient.GetStreamMsgs(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var (
+		moved    []string
+		deadline = time.Now().Add(-updater.pendingUpdateTTL)
+	)
+	for _, m := range msgs {
+		message, err := unmarshalUpdateMessage(m.Value)
+		if err != nil || message.DeviceClassID != info.DeviceClassID || message.DeviceID != info.DeviceID {
+			continue
+		}
+		// 转移后消息id中的时间戳被刷新，因此已经过期的配置更新直接标记为过期
+		if ms, _, ok := parseStreamID(m.ID); ok && time.UnixMilli(int64(ms)).Before(deadline) {
+			moved = append(moved, m.ID)
+			monitor.PendingUpdatesExpired.Inc()
+			if message.UpdateID != "" {
+				updater.setUpdateStatus(ctx, message.UpdateID, UpdateStatusExpired, nil, 0)
+			}
+			continue
+		}
+
+		id, trimmed, err := updater.pubSubClient.AddStreamMsg(
+			ctx, GetPendingUpdatesKey(to), m.Value, updater.maxPendingUpdates, updater.pendingUpdateTTL)
+		if err != nil {
+			return err
+		}
+		moved = append(moved, m.ID)
+		updater.dropPendingUpdates(ctx, to, trimmed)
+		if message.UpdateID != "" {
+			err = updater.pubSubClient.SaveConfigUpdateStatus(ctx, GetConfigUpdateStatusKey(message.UpdateID),
+				map[string]string{updateStatusFieldClientID: to}, updater.updateStatusTTL())
+			if err != nil {
+				updater.logger.Errorf("记录配置更新 %s 的clientID时发生了错误:%v", message.UpdateID, err)
+			}
+		}
+
+		message.ID = id
+		msg, err := json.Marshal(message)
+		if err != nil {
+			return errors.Newf(
+				500, "Biz_Config_Error", "序列化配置更新消息时发生了错误:%v", err)
+		}
+		if err := updater.pubSubClient.PublishMsg(ctx, to, string(msg)); err != nil {
+			return err
+		}
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	return updater.pubSubClient.DeleteStreamMsgs(ctx, key, moved...)
+}
+
+// unmarshalUpdateMessage 反序列化配置更新消息，兼容旧版本实例直接发布的十六进制字符串
+func unmarshalUpdateMessage(msg string) (*ConfigUpdateMessage, error) {
+	message := &ConfigUpdateMessage{Config: msg}
+	if strings.HasPrefix(msg, "{") {
+		if err := json.Unmarshal([]byte(msg), message); err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
+// decodeUpdateMessage 将配置更新消息反序列化为配置更新，protoTemplate用于反序列化设备配置
+func (updater *DeviceConfigUpdater) decodeUpdateMessage(
+	msg string, protoTemplate proto.Message) (*DeviceConfigUpdate, error) {
+	message, err := unmarshalUpdateMessage(msg)
+	if err != nil {
+		updater.logger.Errorf("反序列化接收到的配置更新消息时发生了错误:%v", err)
+		return nil, err
+	}
+
+	// 将编码的字符串还原为二进制信息，并反序列化为proto message
+	// TODO 忽略反序列化失败的消息?
+	info := &DeviceGeneralInfo{DeviceClassID: message.DeviceClassID, DeviceID: message.DeviceID}
+	b, err := updater.pubSubClient.DecodeConfig(message.Config, GetDeviceKey(info), message.UpdateID)
+	if err != nil {
+		updater.logger.Errorf(
+			"解码接收到的配置更新时发生了错误:%v", err,
+		)
+		return nil, err
+	}
+
+	err = proto.Unmarshal(b, protoTemplate)
+	if err != nil {
+		updater.logger.Errorf(
+			"将设备配置的二进制信息反序列化为proto message时发生了错误:%v", err,
+		)
+		return nil, err
+	}
+	update := &DeviceConfigUpdate{
+		Config:        proto.Clone(protoTemplate),
+		carrier:       message.Carrier,
+		id:            message.ID,
+		updateID:      message.UpdateID,
+		deviceClassID: message.DeviceClassID,
+		deviceID:      message.DeviceID,
+		seq:           message.Seq,
+	}
+	if message.ExpiresAt != 0 {
+		update.expiresAt = time.Unix(0, message.ExpiresAt)
+	}
+	return update, nil
+}
+
 // ConnectDeviceAndClientID 建立clientID和设备的关联关系，
-// 可以利用与设备相关联的clientID的channel接收相应设备的配置更新消息
-func (updater *DeviceConfigUpdater) ConnectDeviceAndClientID(clientID string, info *DeviceGeneralInfo) error {
+// 可以利用与设备相关联的clientID的channel接收相应设备的配置更新消息。
+// 设备以新的clientID重新连接时，将其尚未确认的配置更新转移到新clientID的待确认队列中
+func (updater *DeviceConfigUpdater) ConnectDeviceAndClientID(
+	ctx context.Context, clientID string, info *DeviceGeneralInfo) error {
+	deviceKey := GetDeviceKey(info)
+	// 记录与clientID相关联的设备，clientID重新连接时据此同步设备影子。
+	// 关联关系不变时同样记录，使在此之前建立的关联关系也能被记录
+	err := updater.pubSubClient.AddSetMember(ctx, GetClientDevicesKey(info.DeviceClassID, clientID), info.DeviceID)
+	if err != nil {
+		return err
+	}
+	oldClientID, ok, err := updater.pubSubClient.LookupValueOfField(ctx, updater.deviceUpdateChannelsKey, deviceKey)
+	if err != nil {
+		return err
+	}
+	if ok && oldClientID == clientID {
+		return nil
+	}
+
+	// 先转移再切换关联关系，转移失败时关联关系保持不变，设备下次上报时重新转移
+	if ok {
+		if err := updater.migratePendingUpdates(ctx, oldClientID, clientID, info); err != nil {
+			return err
+		}
+	}
 	// 由于clientID即为clientID相应的channel的名称，因此直接
 	// 建立添加设备key和clientID的键值对即可
-	err := updater.pubSubClient.AddFieldValuePair(
-		updater.deviceUpdateChannelsKey, GetDeviceKey(info), clientID)
+	err = updater.pubSubClient.AddFieldValuePair(
+		ctx, updater.deviceUpdateChannelsKey, deviceKey, clientID)
 	if err != nil {
 		return err
 	}
+	// 转移与切换之间仍可能有配置更新追加到旧clientID的待确认队列中，切换后再转移一次
+	if ok {
+		if err := updater.migratePendingUpdates(ctx, oldClientID, clientID, info); err != nil {
+			updater.logger.Errorf("转移设备 %s 的待确认配置更新时发生了错误:%v", deviceKey, err)
+		}
+		// 删除失败时设备在旧clientID重新连接并查询关联的设备时被删除
+		err := updater.pubSubClient.RemoveSetMember(ctx, GetClientDevicesKey(info.DeviceClassID, oldClientID), info.DeviceID)
+		if err != nil {
+			updater.logger.Errorf("删除 %v 关联的设备 %s 时发生了错误:%v", oldClientID, deviceKey, err)
+		}
+	}
 
 	return nil
 }
 
+// GetDeviceClientID 查询与设备相关联的clientID，设备尚未与clientID关联时返回空字符串
+func (updater *DeviceConfigUpdater) GetDeviceClientID(ctx context.Context, info *DeviceGeneralInfo) (string, error) {
+	clientID, _, err := updater.pubSubClient.LookupValueOfField(
+		ctx, updater.deviceUpdateChannelsKey, GetDeviceKey(info))
+	return clientID, err
+}
+
+// GetClientDeviceIDs 查询与clientID相关联的设备类别下的设备id，已经改为与其他clientID关联的设备被一并删除
+func (updater *DeviceConfigUpdater) GetClientDeviceIDs(
+	ctx context.Context, clientID string, deviceClassID int) ([]string, error) {
+	key := GetClientDevicesKey(deviceClassID, clientID)
+	members, err := updater.pubSubClient.GetSetMembers(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, id := range members {
+		info := &DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: id}
+		current, err := updater.GetDeviceClientID(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		if current == clientID {
+			ids = append(ids, id)
+			continue
+		}
+		if err := updater.pubSubClient.RemoveSetMember(ctx, key, id); err != nil {
+			updater.logger.Errorf("删除 %v 关联的设备 %s 时发生了错误:%v", clientID, id, err)
+		}
+	}
+	return ids, nil
+}
+
 // CreateClientID 产生一个分布式全局唯一的clientID
-func (updater *DeviceConfigUpdater) CreateClientID() (string, error) {
-	return updater.pubSubClient.CreateClientID()
+func (updater *DeviceConfigUpdater) CreateClientID(ctx context.Context) (string, error) {
+	return updater.pubSubClient.CreateClientID(ctx)
 }
 
 // GetDeviceKey 辅助函数
 func GetDeviceKey(info *DeviceGeneralInfo) string {
 	return fmt.Sprintf("%s_%d_%s", conf.Username, info.DeviceClassID, info.DeviceID)
 }
+
+// deviceAttributes 设备基本信息相应的span属性
+func deviceAttributes(info *DeviceGeneralInfo) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("device.class_id", info.DeviceClassID),
+		attribute.String("device.id", info.DeviceID),
+	}
+}
+
+// parseStreamID 解析<毫秒时间戳>-<序号>格式的stream消息id
+func parseStreamID(id string) (ms, seq uint64, ok bool) {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+// compareStreamID 比较两个stream消息id的先后，无法解析的id视为最早
+func compareStreamID(a, b string) int {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	switch {
+	case aMs != bMs:
+		if aMs < bMs {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,392 @@
+package biz
+
+import (
+	"fmt"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldViolation 设备配置中不满足校验规则的字段
+type FieldViolation struct {
+	// 字段的路径，嵌套字段以.分隔
+	Field       string
+	Description string
+}
+
+// ConfigValidator 设备配置的校验器，用于实现validate.rules注解无法表达的规则，例如字段之间的约束
+type ConfigValidator interface {
+	// Validate 返回设备配置中不满足校验规则的字段，配置合法时返回空
+	Validate(config proto.Message) []*FieldViolation
+}
+
+// ConfigValidatorFunc 以函数实现的设备配置校验器
+type ConfigValidatorFunc func(config proto.Message) []*FieldViolation
+
+func (f ConfigValidatorFunc) Validate(config proto.Message) []*FieldViolation {
+	return f(config)
+}
+
+// RegisterConfigValidator 为设备类别注册设备配置的校验器，下发配置更新前依次执行
+// validate.rules注解的校验以及注册的校验器，需要在开始下发配置更新之前注册。
+// 设备类别配置的required_fields在创建DeviceConfigUpdater时注册为校验器
+func (updater *DeviceConfigUpdater) RegisterConfigValidator(deviceClassID int, validator ConfigValidator) {
+	updater.validators[deviceClassID] = append(updater.validators[deviceClassID], validator)
+}
+
+// ValidateDeviceConfig 校验将要下发给设备的配置，不满足校验规则时返回400错误，
+// 错误的metadata以字段路径为键保存各个字段不满足的规则
+func (updater *DeviceConfigUpdater) ValidateDeviceConfig(info *DeviceGeneralInfo, config proto.Message) error {
+	violations, err := updater.validateDeviceConfig(info.DeviceClassID, config)
+	if err != nil {
+		return err
+	}
+	return newValidationError(violations)
+}
+
+// validateConfigTemplate 校验批量下发的配置模板，模板中的设备id在下发时被替换为各个设备的id，因此不校验设备id
+func (updater *DeviceConfigUpdater) validateConfigTemplate(deviceClassID int, config proto.Message) error {
+	all, err := updater.validateDeviceConfig(deviceClassID, config)
+	if err != nil {
+		return err
+	}
+	var violations []*FieldViolation
+	for _, v := range all {
+		if v.Field != deviceConfigIDField {
+			violations = append(violations, v)
+		}
+	}
+	return newValidationError(violations)
+}
+
+// validateDeviceConfig 依次执行validate.rules注解的校验以及设备类别注册的校验器，
+// 设备配置使用了不支持的validate.rules规则时返回500错误
+func (updater *DeviceConfigUpdater) validateDeviceConfig(
+	deviceClassID int, config proto.Message) ([]*FieldViolation, error) {
+	violations, err := validateFieldRules(config.ProtoReflect(), "")
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range updater.validators[deviceClassID] {
+		violations = append(violations, v.Validate(config)...)
+	}
+	return violations, nil
+}
+
+// requiredFieldsValidator 返回检查paths指定的字段均已设置的校验器，嵌套字段以.分隔，标量字段为零值时视为未设置
+func requiredFieldsValidator(paths []string) ConfigValidator {
+	return ConfigValidatorFunc(func(config proto.Message) (violations []*FieldViolation) {
+		for _, path := range paths {
+			m, names := config.ProtoReflect(), strings.Split(path, ".")
+			for i, name := range names {
+				fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+				if fd == nil || i < len(names)-1 && (fd.Message() == nil || fd.IsList() || fd.IsMap()) {
+					violations = append(violations, &FieldViolation{Field: path, Description: "必须设置的字段不存在"})
+					break
+				}
+				if !m.Has(fd) {
+					violations = append(violations, &FieldViolation{Field: path, Description: "字段不能为空"})
+					break
+				}
+				if i < len(names)-1 {
+					m = m.Get(fd).Message()
+				}
+			}
+		}
+		return violations
+	})
+}
+
+// newValidationError 将不满足校验规则的字段转换为400错误，没有不满足规则的字段时返回nil
+func newValidationError(violations []*FieldViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(violations))
+	for _, v := range violations {
+		if d, ok := metadata[v.Field]; ok {
+			metadata[v.Field] = d + ";" + v.Description
+		} else {
+			metadata[v.Field] = v.Description
+		}
+	}
+	fields := make([]string, 0, len(metadata))
+	for f := range metadata {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	details := make([]string, 0, len(fields))
+	for _, f := range fields {
+		details = append(details, f+":"+metadata[f])
+	}
+	return errors.Newf(400, "Biz_Config_Invalid",
+		"设备配置不满足校验规则:%s", strings.Join(details, ",")).WithMetadata(metadata)
+}
+
+// validateFieldRules 按照字段上的validate.rules注解校验消息，prefix为消息在顶层配置中的路径。
+// 支持数值、bool、string、enum的const、范围以及in/not_in规则，string的长度、前后缀以及正则规则，
+// repeated的元素数量规则以及message的required与skip规则，字段使用了其余规则时返回500错误，避免规则被静默地忽略
+func validateFieldRules(m protoreflect.Message, prefix string) (violations []*FieldViolation, err error) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		rules, _ := proto.GetExtension(fd.Options(), validate.E_Rules).(*validate.FieldRules)
+		if unsupported := unsupportedRules(fd, rules); len(unsupported) != 0 {
+			return nil, errors.Newf(500, "Biz_Config_Error",
+				"设备配置的字段 %s 使用了不支持的校验规则:%s", path, strings.Join(unsupported, ","))
+		}
+
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+			if !m.Has(fd) {
+				if rules.GetMessage().GetRequired() {
+					violations = append(violations, &FieldViolation{Field: path, Description: "字段不能为空"})
+				}
+				continue
+			}
+			if !rules.GetMessage().GetSkip() {
+				nested, err := validateFieldRules(m.Get(fd).Message(), path+".")
+				if err != nil {
+					return nil, err
+				}
+				violations = append(violations, nested...)
+			}
+			continue
+		}
+		if rules == nil {
+			continue
+		}
+		for _, d := range checkFieldRules(fd, m.Get(fd), rules) {
+			violations = append(violations, &FieldViolation{Field: path, Description: d})
+		}
+	}
+	return violations, nil
+}
+
+// comparableRules 数值、bool以及enum规则中由checkComparableRules检查的规则
+var comparableRules = map[protoreflect.Name]bool{
+	"const": true, "lt": true, "lte": true, "gt": true, "gte": true, "in": true, "not_in": true,
+}
+
+// supportedRules 各类规则中支持的规则，未列出的规则类别(例如bytes、map以及well known types的规则)均不支持
+var supportedRules = map[protoreflect.Name]map[protoreflect.Name]bool{
+	"float": comparableRules, "double": comparableRules,
+	"int32": comparableRules, "int64": comparableRules, "uint32": comparableRules, "uint64": comparableRules,
+	"sint32": comparableRules, "sint64": comparableRules, "fixed32": comparableRules, "fixed64": comparableRules,
+	"sfixed32": comparableRules, "sfixed64": comparableRules,
+	"bool": {"const": true},
+	"enum": {"const": true, "defined_only": true, "in": true, "not_in": true},
+	"string": {
+		"const": true, "len": true, "min_len": true, "max_len": true, "prefix": true, "suffix": true,
+		"pattern": true, "in": true, "not_in": true,
+	},
+	"repeated": {"min_items": true, "max_items": true},
+}
+
+// unsupportedRules 返回字段的validate.rules注解中validateFieldRules不支持的规则，规则以<类别>.<规则>表示
+func unsupportedRules(fd protoreflect.FieldDescriptor, rules *validate.FieldRules) (unsupported []string) {
+	if rules == nil {
+		return nil
+	}
+	r := rules.ProtoReflect()
+	typeRules := r.WhichOneof(r.Descriptor().Oneofs().ByName("type"))
+	if typeRules == nil {
+		return nil
+	}
+	// 规则的类别需要与字段的类型一致，repeated字段只支持repeated规则
+	kind := typeRules.Name()
+	switch {
+	case fd.IsList():
+		if kind != "repeated" {
+			return []string{string(kind)}
+		}
+	case fd.IsMap() || kind != protoreflect.Name(fd.Kind().String()):
+		return []string{string(kind)}
+	}
+	supported := supportedRules[kind]
+	r.Get(typeRules).Message().Range(func(rule protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !supported[rule.Name()] {
+			unsupported = append(unsupported, string(kind)+"."+string(rule.Name()))
+		}
+		return true
+	})
+	return unsupported
+}
+
+// checkFieldRules 返回字段值不满足的规则的描述
+func checkFieldRules(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *validate.FieldRules) []string {
+	r := rules.ProtoReflect()
+	typeRules := r.WhichOneof(r.Descriptor().Oneofs().ByName("type"))
+	if typeRules == nil {
+		return nil
+	}
+	rm := r.Get(typeRules).Message()
+
+	if fd.IsList() {
+		if typeRules.Name() != "repeated" {
+			return nil
+		}
+		n := uint64(v.List().Len())
+		var violations []string
+		if min := rules.GetRepeated().MinItems; min != nil && n < *min {
+			violations = append(violations, fmt.Sprintf("元素数量不能少于%d", *min))
+		}
+		if max := rules.GetRepeated().MaxItems; max != nil && n > *max {
+			violations = append(violations, fmt.Sprintf("元素数量不能多于%d", *max))
+		}
+		return violations
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return checkStringRules(v.String(), rules.GetString_())
+	case protoreflect.EnumKind:
+		violations := checkComparableRules(rm, v, fd.Kind())
+		if rules.GetEnum().GetDefinedOnly() && fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			violations = append(violations, "必须为定义的枚举值")
+		}
+		return violations
+	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return nil
+	default:
+		return checkComparableRules(rm, v, fd.Kind())
+	}
+}
+
+// checkComparableRules 利用反射检查数值、bool以及enum规则中的const、lt、lte、gt、gte、in以及not_in，
+// 各类规则消息中这些字段的名称相同，且类型与被校验的字段一致
+func checkComparableRules(rm protoreflect.Message, v protoreflect.Value, kind protoreflect.Kind) (violations []string) {
+	fields := rm.Descriptor().Fields()
+	get := func(name protoreflect.Name) (protoreflect.Value, bool) {
+		fd := fields.ByName(name)
+		if fd == nil || !rm.Has(fd) {
+			return protoreflect.Value{}, false
+		}
+		return rm.Get(fd), true
+	}
+
+	if c, ok := get("const"); ok && compareValue(v, c, kind) != 0 {
+		violations = append(violations, fmt.Sprintf("必须等于%v", c.Interface()))
+	}
+	if lt, ok := get("lt"); ok && compareValue(v, lt, kind) >= 0 {
+		violations = append(violations, fmt.Sprintf("必须小于%v", lt.Interface()))
+	}
+	if lte, ok := get("lte"); ok && compareValue(v, lte, kind) > 0 {
+		violations = append(violations, fmt.Sprintf("不能大于%v", lte.Interface()))
+	}
+	if gt, ok := get("gt"); ok && compareValue(v, gt, kind) <= 0 {
+		violations = append(violations, fmt.Sprintf("必须大于%v", gt.Interface()))
+	}
+	if gte, ok := get("gte"); ok && compareValue(v, gte, kind) < 0 {
+		violations = append(violations, fmt.Sprintf("不能小于%v", gte.Interface()))
+	}
+	if in, ok := get("in"); ok && in.List().Len() != 0 && !listContains(in.List(), v, kind) {
+		violations = append(violations, fmt.Sprintf("必须为%s之一", formatList(in.List())))
+	}
+	if notIn, ok := get("not_in"); ok && listContains(notIn.List(), v, kind) {
+		violations = append(violations, fmt.Sprintf("不能为%s之一", formatList(notIn.List())))
+	}
+	return violations
+}
+
+// checkStringRules 检查string规则
+func checkStringRules(s string, rules *validate.StringRules) (violations []string) {
+	n := uint64(utf8.RuneCountInString(s))
+	if rules.Const != nil && s != *rules.Const {
+		violations = append(violations, fmt.Sprintf("必须等于%q", *rules.Const))
+	}
+	if rules.Len != nil && n != *rules.Len {
+		violations = append(violations, fmt.Sprintf("长度必须为%d", *rules.Len))
+	}
+	if rules.MinLen != nil && n < *rules.MinLen {
+		if *rules.MinLen == 1 {
+			violations = append(violations, "不能为空")
+		} else {
+			violations = append(violations, fmt.Sprintf("长度不能小于%d", *rules.MinLen))
+		}
+	}
+	if rules.MaxLen != nil && n > *rules.MaxLen {
+		violations = append(violations, fmt.Sprintf("长度不能大于%d", *rules.MaxLen))
+	}
+	if rules.Prefix != nil && !strings.HasPrefix(s, *rules.Prefix) {
+		violations = append(violations, fmt.Sprintf("必须以%q开头", *rules.Prefix))
+	}
+	if rules.Suffix != nil && !strings.HasSuffix(s, *rules.Suffix) {
+		violations = append(violations, fmt.Sprintf("必须以%q结尾", *rules.Suffix))
+	}
+	if rules.Pattern != nil {
+		if re, err := regexp.Compile(*rules.Pattern); err == nil && !re.MatchString(s) {
+			violations = append(violations, fmt.Sprintf("必须匹配%s", *rules.Pattern))
+		}
+	}
+	if len(rules.In) != 0 && !containsString(rules.In, s) {
+		violations = append(violations, fmt.Sprintf("必须为%v之一", rules.In))
+	}
+	if containsString(rules.NotIn, s) {
+		violations = append(violations, fmt.Sprintf("不能为%v之一", rules.NotIn))
+	}
+	return violations
+}
+
+// compareValue 按照字段类型比较两个值，a小于、等于、大于b时分别返回-1、0、1
+func compareValue(a, b protoreflect.Value, kind protoreflect.Kind) int {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int())
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return compareOrdered(a.Float() < b.Float(), a.Float() > b.Float())
+	case protoreflect.EnumKind:
+		// enum规则中的值以int32保存
+		return compareOrdered(int64(a.Enum()) < b.Int(), int64(a.Enum()) > b.Int())
+	case protoreflect.BoolKind:
+		return compareOrdered(!a.Bool() && b.Bool(), a.Bool() && !b.Bool())
+	default:
+		return 0
+	}
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func listContains(l protoreflect.List, v protoreflect.Value, kind protoreflect.Kind) bool {
+	for i := 0; i < l.Len(); i++ {
+		if compareValue(v, l.Get(i), kind) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func formatList(l protoreflect.List) string {
+	values := make([]string, 0, l.Len())
+	for i := 0; i < l.Len(); i++ {
+		values = append(values, fmt.Sprint(l.Get(i).Interface()))
+	}
+	return "[" + strings.Join(values, " ") + "]"
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package biz
+
+import (
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"sync"
+	"time"
+)
+
+// 设备读数缓存的缺省过期时间
+const defaultDeviceReadingStaleTimeout = 5 * time.Minute
+
+// DeviceReading 设备预警字段的最新读数
+type DeviceReading struct {
+	DeviceGeneralInfo
+	// 设备状态信息中携带的时间
+	Time time.Time
+	// 服务端接收到该读数的时间，用于判断读数是否过期
+	ReceivedAt time.Time
+	Fields     map[string]float64
+}
+
+// DeviceReadingCache 缓存当前实例接收到的各设备预警字段的最新读数，
+// 超过过期时间未更新的设备读数会被移除，以免设备停止上报后仍暴露旧的读数
+type DeviceReadingCache struct {
+	lock         sync.Mutex
+	readings     map[string]*DeviceReading
+	staleTimeout time.Duration
+}
+
+func NewDeviceReadingCache(c *conf.Server) *DeviceReadingCache {
+	staleTimeout := c.DeviceMetrics.GetStaleTimeout().AsDuration()
+	if staleTimeout <= 0 {
+		staleTimeout = defaultDeviceReadingStaleTimeout
+	}
+	return &DeviceReadingCache{
+		readings:     make(map[string]*DeviceReading),
+		staleTimeout: staleTimeout,
+	}
+}
+
+// Update 更新设备的最新读数，由于fields会被调用方复用，这里保存的是其副本
+func (c *DeviceReadingCache) Update(info *DeviceGeneralInfo, stateTime time.Time, fields map[string]float64) {
+	reading := &DeviceReading{
+		DeviceGeneralInfo: *info,
+		Time:              stateTime,
+		ReceivedAt:        time.Now(),
+		Fields:            make(map[string]float64, len(fields)),
+	}
+	for k, v := range fields {
+		reading.Fields[k] = v
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.readings[GetDeviceKey(info)] = reading
+}
+
+// Range 遍历未过期的设备读数，并移除已过期的设备读数
+func (c *DeviceReadingCache) Range(f func(reading *DeviceReading)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for key, reading := range c.readings {
+		if now.Sub(reading.ReceivedAt) > c.staleTimeout {
+			delete(c.readings, key)
+			continue
+		}
+		f(reading)
+	}
+}
@@ -0,0 +1,168 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync"
+	"time"
+)
+
+// 依赖组件健康检查的缺省间隔以及超时时间
+const (
+	defaultHealthCheckInterval = 5 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+)
+
+// 服务所依赖的组件名
+const (
+	DependencyRedis    = "redis"
+	DependencyInfluxdb = "influxdb"
+)
+
+type HealthRepo interface {
+	// CheckRedis 检查redis sentinel以及master是否可达
+	CheckRedis(ctx context.Context) error
+	// CheckInfluxdb 检查influxdb是否可达
+	CheckInfluxdb(ctx context.Context) error
+}
+
+// DependencyStatus 依赖组件最近一次检查的结果
+type DependencyStatus struct {
+	Name      string    `json:"name"`
+	Up        bool      `json:"up"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// HealthUsecase 定期检查依赖组件的状态，任一依赖组件不可用时服务处于未就绪状态
+type HealthUsecase struct {
+	repo     HealthRepo
+	interval time.Duration
+	timeout  time.Duration
+	lock     sync.RWMutex
+	statuses []*DependencyStatus
+	ready    bool
+	// 就绪状态发生变化时调用的函数
+	watchers []func(ready bool)
+	logger   *log.Helper
+}
+
+func NewHealthUsecase(c *conf.Server, repo UnionRepo, logger log.Logger) (*HealthUsecase, func()) {
+	u := &HealthUsecase{
+		repo:     repo,
+		interval: c.Health.GetCheckInterval().AsDuration(),
+		timeout:  c.Health.GetCheckTimeout().AsDuration(),
+		logger:   log.NewHelper(logger),
+	}
+	if u.interval <= 0 {
+		u.interval = defaultHealthCheckInterval
+	}
+	if u.timeout <= 0 {
+		u.timeout = defaultHealthCheckTimeout
+	}
+
+	// 首先同步地进行一次检查，保证启动后即可给出准确的就绪状态
+	u.check()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				u.check()
+			}
+		}
+	}()
+
+	return u, func() {
+		cancel()
+		<-done
+	}
+}
+
+// Ready 返回服务是否处于就绪状态
+func (u *HealthUsecase) Ready() bool {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	return u.ready
+}
+
+// Statuses 返回各依赖组件最近一次检查的结果
+func (u *HealthUsecase) Statuses() []DependencyStatus {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	statuses := make([]DependencyStatus, 0, len(u.statuses))
+	for _, s := range u.statuses {
+		statuses = append(statuses, *s)
+	}
+	return statuses
+}
+
+// Watch 注册在就绪状态变化时调用的函数，注册时会以当前的就绪状态调用一次f
+func (u *HealthUsecase) Watch(f func(ready bool)) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.watchers = append(u.watchers, f)
+	f(u.ready)
+}
+
+// check 检查各依赖组件的状态，并在就绪状态变化时通知watcher
+func (u *HealthUsecase) check() {
+	checks := []struct {
+		name  string
+		check func(ctx context.Context) error
+	}{
+		{DependencyRedis, u.repo.CheckRedis},
+		{DependencyInfluxdb, u.repo.CheckInfluxdb},
+	}
+
+	var (
+		statuses = make([]*DependencyStatus, 0, len(checks))
+		ready    = true
+	)
+	for _, c := range checks {
+		ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+		err := c.check(ctx)
+		cancel()
+
+		status := &DependencyStatus{Name: c.name, Up: err == nil, CheckedAt: time.Now()}
+		if err != nil {
+			status.Error = err.Error()
+			ready = false
+			monitor.DependencyUp.WithLabelValues(c.name).Set(0)
+		} else {
+			monitor.DependencyUp.WithLabelValues(c.name).Set(1)
+		}
+		statuses = append(statuses, status)
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for i, s := range statuses {
+		// 仅在依赖组件状态变化，或首次检查即不可用时记录日志
+		if i < len(u.statuses) && u.statuses[i].Up == s.Up || i >= len(u.statuses) && s.Up {
+			continue
+		}
+		if s.Up {
+			u.logger.Infof("依赖组件 %s 恢复可用", s.Name)
+		} else {
+			u.logger.Errorf("依赖组件 %s 不可用:%s", s.Name, s.Error)
+		}
+	}
+	u.statuses = statuses
+
+	if u.ready != ready {
+		u.ready = ready
+		for _, f := range u.watchers {
+			f(ready)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	utilApi "gitee.com/moyusir/util/api/util/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"sort"
+	"time"
+)
+
+// 推进灰度发布的缺省间隔、锁的缺省有效期、已结束灰度发布的缺省保留时间以及每个阶段等待设备确认的缺省超时时间
+const (
+	defaultRolloutCheckInterval = 5 * time.Second
+	defaultRolloutLockTTL       = 15 * time.Second
+	defaultRolloutRetention     = 7 * 24 * time.Hour
+	defaultRolloutAckTimeout    = 10 * time.Minute
+)
+
+// 灰度发布的状态
+const (
+	// RolloutStatusScheduled 灰度发布尚未到达开始时间
+	RolloutStatusScheduled = "scheduled"
+	// RolloutStatusRunning 灰度发布正在分阶段下发配置更新
+	RolloutStatusRunning = "running"
+	// RolloutStatusHalted 阶段中失败的设备过多或者设备产生了新的警告信息，灰度发布自动暂停
+	RolloutStatusHalted = "halted"
+	// RolloutStatusCompleted 全部阶段均已完成
+	RolloutStatusCompleted = "completed"
+	// RolloutStatusCancelled 灰度发布被取消
+	RolloutStatusCancelled = "cancelled"
+)
+
+// 灰度发布在redis hash中的各个field，其中rollout以及config只由推进灰度发布的实例写入，
+// 取消以及继续灰度发布的请求则写入control，在下一次推进时生效。下发的设备配置以ConfigCodec编码后单独保存在config中
+const (
+	rolloutField         = "rollout"
+	rolloutFieldConfig   = "config"
+	rolloutFieldControl  = "control"
+	rolloutControlCancel = "cancel"
+	rolloutControlResume = "resume"
+)
+
+// ConfigRollout 分阶段下发同一配置的灰度发布
+type ConfigRollout struct {
+	RolloutID     string          `json:"rolloutID"`
+	DeviceClassID int             `json:"deviceClassID"`
+	Selector      *DeviceSelector `json:"selector"`
+	// 设备配置的protobuf消息全名以及二进制信息，用于在推进灰度发布的实例中还原设备配置。
+	// 设备配置编码后保存在单独的field中，只有旧版本实例保存的灰度发布在json中包含设备配置
+	ConfigType string `json:"configType"`
+	Config     []byte `json:"config,omitempty"`
+	// 开始下发的时间
+	StartTime time.Time `json:"startTime"`
+	// 各个阶段，阶段的Percent为阶段结束时累计覆盖的设备百分比
+	Stages []*RolloutStage `json:"stages"`
+	// 每个阶段等待设备确认配置更新的超时时间，以及每个阶段允许失败或者超时未确认的设备数量
+	AckTimeout  time.Duration `json:"ackTimeout"`
+	MaxFailures int64         `json:"maxFailures"`
+
+	Status       string `json:"status"`
+	CurrentStage int    `json:"currentStage"`
+	// 开始下发时被选中的设备，按照设备id排序
+	DeviceIDs  []string  `json:"deviceIDs,omitempty"`
+	HaltReason string    `json:"haltReason,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// RolloutStage 灰度发布的阶段
+type RolloutStage struct {
+	Percent int64 `json:"percent"`
+	// 阶段中下发配置更新的设备数量
+	Devices int64 `json:"devices"`
+	// 阶段相应的批量更新任务的id
+	JobID     string    `json:"jobID,omitempty"`
+	StartTime time.Time `json:"startTime,omitempty"`
+}
+
+// Finished 返回灰度发布是否已经结束
+func (r *ConfigRollout) Finished() bool {
+	return r.Status == RolloutStatusCompleted || r.Status == RolloutStatusCancelled
+}
+
+type RolloutRepo interface {
+	// SaveRollout 将fields保存到key相应的hash中，ttl大于0时设置hash的过期时间
+	SaveRollout(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error
+	// GetRollout 查询key相应hash中的全部field，hash不存在时返回404错误
+	GetRollout(ctx context.Context, key string) (map[string]string, error)
+	// DeleteRolloutField 在key相应hash中field的值等于value时删除field
+	DeleteRolloutField(ctx context.Context, key, field, value string) error
+	// AddActiveRollout 将灰度发布的id加入key相应的set
+	AddActiveRollout(ctx context.Context, key, rolloutID string) error
+	// RemoveActiveRollout 将灰度发布的id从key相应的set中删除
+	RemoveActiveRollout(ctx context.Context, key, rolloutID string) error
+	// ListActiveRollouts 查询key相应的set中的全部灰度发布id
+	ListActiveRollouts(ctx context.Context, key string) ([]string, error)
+	LockRepo
+	ConfigCodec
+}
+
+// RolloutUsecase 持久化灰度发布，并由持有锁的实例定期推进各个灰度发布
+type RolloutUsecase struct {
+	repo RolloutRepo
+	uc   *ConfigUsecase
+	// 当前实例的标识，用于竞争推进灰度发布的锁
+	owner     string
+	interval  time.Duration
+	lockTTL   time.Duration
+	retention time.Duration
+	logger    *log.Helper
+}
+
+func NewRolloutUsecase(c *conf.Data, repo UnionRepo, uc *ConfigUsecase, logger log.Logger) (*RolloutUsecase, func()) {
+	u := &RolloutUsecase{
+		repo:      repo,
+		uc:        uc,
+		owner:     uuid.NewString(),
+		interval:  c.Rollout.GetCheckInterval().AsDuration(),
+		lockTTL:   c.Rollout.GetLockTtl().AsDuration(),
+		retention: c.Rollout.GetRetention().AsDuration(),
+		logger:    log.NewHelper(logger),
+	}
+	if u.interval <= 0 {
+		u.interval = defaultRolloutCheckInterval
+	}
+	if u.lockTTL <= u.interval {
+		u.lockTTL = 3 * u.interval
+	}
+	if u.retention <= 0 {
+		u.retention = defaultRolloutRetention
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				u.reconcile(ctx)
+			}
+		}
+	}()
+
+	return u, func() {
+		cancel()
+		<-done
+		// 主动释放锁，使其余实例无需等待锁过期即可接管灰度发布
+		ctx, cancel := context.WithTimeout(context.Background(), u.interval)
+		defer cancel()
+		if err := u.repo.ReleaseLock(ctx, GetConfigRolloutLockKey(), u.owner); err != nil {
+			u.logger.Errorf("释放推进灰度发布的锁时发生了错误:%v", err)
+		}
+	}
+}
+
+// CreateRollout 创建灰度发布，rollout中需要给出设备类别号、选择设备的条件、开始时间、各阶段的百分比、
+// 等待设备确认的超时时间以及允许失败的设备数量，config为下发给各个设备的配置
+func (u *RolloutUsecase) CreateRollout(
+	ctx context.Context, rollout *ConfigRollout, config proto.Message) (_ *ConfigRollout, err error) {
+	ctx, span := monitor.StartSpan(ctx, "RolloutUsecase.CreateRollout",
+		trace.WithAttributes(attribute.Int("device.class_id", rollout.DeviceClassID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	if err := validateDeviceSelector(rollout.Selector); err != nil {
+		return nil, err
+	}
+	if len(rollout.Stages) == 0 {
+		rollout.Stages = []*RolloutStage{{Percent: 100}}
+	}
+	for i, stage := range rollout.Stages {
+		if stage.Percent <= 0 || stage.Percent > 100 || (i > 0 && stage.Percent <= rollout.Stages[i-1].Percent) {
+			return nil, errors.New(400, "Biz_Config_Error", "各阶段的百分比需要在1到100之间且严格递增")
+		}
+	}
+	if rollout.Stages[len(rollout.Stages)-1].Percent != 100 {
+		return nil, errors.New(400, "Biz_Config_Error", "最后一个阶段的百分比需要为100")
+	}
+	if rollout.AckTimeout <= 0 {
+		rollout.AckTimeout = defaultRolloutAckTimeout
+	}
+	if rollout.MaxFailures < 0 {
+		return nil, errors.New(400, "Biz_Config_Error", "允许失败的设备数量不能为负数")
+	}
+	if err := u.uc.updater.validateConfigTemplate(rollout.DeviceClassID, config); err != nil {
+		return nil, err
+	}
+
+	rollout.Config, err = proto.Marshal(config)
+	if err != nil {
+		return nil, errors.Newf(500, "Biz_Config_Error", "序列化设备配置信息时发生了错误:%v", err)
+	}
+	now := time.Now()
+	rollout.RolloutID = uuid.NewString()
+	rollout.ConfigType = string(config.ProtoReflect().Descriptor().FullName())
+	rollout.Status = RolloutStatusScheduled
+	rollout.CreatedAt, rollout.UpdatedAt = now, now
+	if rollout.StartTime.IsZero() {
+		rollout.StartTime = now
+	}
+	span.SetAttributes(attribute.String("config.rollout_id", rollout.RolloutID))
+
+	if err := u.saveRollout(ctx, rollout); err != nil {
+		return nil, err
+	}
+	if err := u.repo.AddActiveRollout(ctx, GetActiveConfigRolloutsKey(), rollout.RolloutID); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// GetRollout 查询灰度发布
+func (u *RolloutUsecase) GetRollout(ctx context.Context, rolloutID string) (rollout *ConfigRollout, err error) {
+	ctx, span := monitor.StartSpan(ctx, "RolloutUsecase.GetRollout",
+		trace.WithAttributes(attribute.String("config.rollout_id", rolloutID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	rollout, _, err = u.getRollout(ctx, rolloutID)
+	return rollout, err
+}
+
+// CancelRollout 取消尚未结束的灰度发布，已经下发的配置更新不会被撤回，取消在下一次推进灰度发布时生效
+func (u *RolloutUsecase) CancelRollout(ctx context.Context, rolloutID string) (*ConfigRollout, error) {
+	return u.control(ctx, rolloutID, rolloutControlCancel)
+}
+
+// ResumeRollout 继续已经暂停的灰度发布，暂停时所处的阶段视为通过，在下一次推进灰度发布时生效
+func (u *RolloutUsecase) ResumeRollout(ctx context.Context, rolloutID string) (*ConfigRollout, error) {
+	return u.control(ctx, rolloutID, rolloutControlResume)
+}
+
+// control 记录取消或者继续灰度发布的命令
+func (u *RolloutUsecase) control(
+	ctx context.Context, rolloutID, command string) (rollout *ConfigRollout, err error) {
+	ctx, span := monitor.StartSpan(ctx, "RolloutUsecase.control",
+		trace.WithAttributes(
+			attribute.String("config.rollout_id", rolloutID),
+			attribute.String("config.rollout_control", command),
+		))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	rollout, _, err = u.getRollout(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+	if rollout.Finished() {
+		return nil, errors.Newf(409, "Biz_Config_Conflict", "灰度发布已经处于%s状态", rollout.Status)
+	}
+	if command == rolloutControlResume && rollout.Status != RolloutStatusHalted {
+		return nil, errors.Newf(409, "Biz_Config_Conflict", "只能继续已经暂停的灰度发布，灰度发布当前处于%s状态", rollout.Status)
+	}
+	err = u.repo.SaveRollout(ctx, GetConfigRolloutKey(rolloutID), map[string]string{rolloutFieldControl: command}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// reconcile 获得锁后推进全部尚未结束的灰度发布，未获得锁的实例不做任何处理
+func (u *RolloutUsecase) reconcile(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, u.lockTTL)
+	defer cancel()
+
+	leader, err := u.repo.AcquireLock(ctx, GetConfigRolloutLockKey(), u.owner, u.lockTTL)
+	if err != nil {
+		u.logger.Errorf("获取推进灰度发布的锁时发生了错误:%v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+	ids, err := u.repo.ListActiveRollouts(ctx, GetActiveConfigRolloutsKey())
+	if err != nil {
+		u.logger.Errorf("查询尚未结束的灰度发布时发生了错误:%v", err)
+		return
+	}
+	for _, id := range ids {
+		if err := u.advance(ctx, id); err != nil {
+			u.logger.Errorf("推进灰度发布 %s 时发生了错误:%v", id, err)
+		}
+	}
+}
+
+// advance 执行灰度发布的控制命令，并依据灰度发布所处的状态推进灰度发布
+func (u *RolloutUsecase) advance(ctx context.Context, rolloutID string) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "RolloutUsecase.advance",
+		trace.WithAttributes(attribute.String("config.rollout_id", rolloutID)))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	rollout, command, err := u.getRollout(ctx, rolloutID)
+	if errors.Code(err) == 404 {
+		return u.repo.RemoveActiveRollout(ctx, GetActiveConfigRolloutsKey(), rolloutID)
+	}
+	if err != nil {
+		return err
+	}
+	status := rollout.Status
+
+	switch {
+	case command == rolloutControlCancel && !rollout.Finished():
+		rollout.Status = RolloutStatusCancelled
+	case command == rolloutControlResume && rollout.Status == RolloutStatusHalted:
+		rollout.Status, rollout.HaltReason = RolloutStatusRunning, ""
+		u.nextStage(rollout)
+	}
+
+	switch rollout.Status {
+	case RolloutStatusScheduled:
+		if time.Now().Before(rollout.StartTime) {
+			break
+		}
+		if err := u.start(ctx, rollout); err != nil {
+			return err
+		}
+	case RolloutStatusRunning:
+		if err := u.runStage(ctx, rollout); err != nil {
+			return err
+		}
+	}
+
+	if rollout.Status != status {
+		span.SetAttributes(attribute.String("config.rollout_status", rollout.Status))
+		u.logger.Infof("灰度发布 %s 由%s状态变为%s状态", rolloutID, status, rollout.Status)
+	}
+	if err := u.saveRollout(ctx, rollout); err != nil {
+		return err
+	}
+	// 灰度发布保存后才删除已经执行的控制命令，并且只在命令未被改写时删除，避免推进期间写入的新命令丢失
+	if command != "" {
+		return u.repo.DeleteRolloutField(ctx, GetConfigRolloutKey(rolloutID), rolloutFieldControl, command)
+	}
+	return nil
+}
+
+// start 选择灰度发布的设备，并计算各个阶段的设备数量
+func (u *RolloutUsecase) start(ctx context.Context, rollout *ConfigRollout) error {
+	if _, err := rollout.config(); err != nil {
+		return err
+	}
+	ids, err := u.uc.selectDevices(ctx, rollout.DeviceClassID, rollout.Selector)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		rollout.Status, rollout.HaltReason = RolloutStatusHalted, "没有满足选择条件的设备"
+		return nil
+	}
+	sort.Strings(ids)
+	rollout.DeviceIDs = ids
+
+	var covered int64
+	for _, stage := range rollout.Stages {
+		// 向上取整，保证每个百分比不为0的阶段至少覆盖一台设备
+		end := (int64(len(ids))*stage.Percent + 99) / 100
+		stage.Devices = end - covered
+		covered = end
+	}
+	rollout.Status = RolloutStatusRunning
+	return u.runStage(ctx, rollout)
+}
+
+// runStage 开始当前阶段的下发，或者在当前阶段的设备全部确认、失败或者等待超时后，检查阶段的结果并决定继续或者暂停
+func (u *RolloutUsecase) runStage(ctx context.Context, rollout *ConfigRollout) error {
+	stage := rollout.Stages[rollout.CurrentStage]
+	devices := rollout.stageDevices(rollout.CurrentStage)
+	if stage.StartTime.IsZero() {
+		stage.StartTime = time.Now()
+		if len(devices) == 0 {
+			u.nextStage(rollout)
+			return nil
+		}
+		// 先保存阶段的批量更新任务id再开始任务，保存失败时任务不会开始，下一次推进时也就不会重复开始任务
+		stage.JobID = newBulkUpdateJobID()
+		if err := u.saveRollout(ctx, rollout); err != nil {
+			return err
+		}
+	}
+	if stage.JobID == "" {
+		u.nextStage(rollout)
+		return nil
+	}
+
+	job, err := u.uc.GetBulkUpdateJob(ctx, stage.JobID)
+	if errors.Code(err) == 404 {
+		// 任务id已经保存但任务尚未开始，或者开始任务时发生了错误，此时以保存的任务id开始任务。
+		// 任务记录超过保留时间被删除时不再重新开始，以免重复下发
+		if time.Since(stage.StartTime) > u.uc.bulkUpdateJobTTL {
+			rollout.Status = RolloutStatusHalted
+			rollout.HaltReason = fmt.Sprintf("第%d阶段的批量更新任务 %s 已过期", rollout.CurrentStage+1, stage.JobID)
+			return nil
+		}
+		config, err := rollout.config()
+		if err != nil {
+			return err
+		}
+		_, err = u.uc.startBulkUpdate(ctx, stage.JobID, rollout.DeviceClassID, devices, config)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	settled := job.Done && job.Acked+job.Failed >= job.Total
+	if !settled && time.Since(stage.StartTime) < rollout.AckTimeout {
+		return nil
+	}
+	// 等待超时时，尚未确认的设备同样视为失败
+	failures := job.Total - job.Acked
+	if failures > rollout.MaxFailures {
+		rollout.Status = RolloutStatusHalted
+		rollout.HaltReason = fmt.Sprintf(
+			"第%d阶段中%d台设备的配置更新失败或者超时未确认", rollout.CurrentStage+1, failures)
+		return nil
+	}
+	warnings, err := u.countWarnings(ctx, rollout.DeviceClassID, devices, stage.StartTime)
+	if err != nil {
+		return err
+	}
+	if warnings > 0 {
+		rollout.Status = RolloutStatusHalted
+		rollout.HaltReason = fmt.Sprintf("第%d阶段的设备产生了%d条新的警告信息", rollout.CurrentStage+1, warnings)
+		return nil
+	}
+	u.nextStage(rollout)
+	return nil
+}
+
+// nextStage 进入下一阶段，已经处于最后一个阶段时灰度发布完成
+func (u *RolloutUsecase) nextStage(rollout *ConfigRollout) {
+	if rollout.CurrentStage == len(rollout.Stages)-1 {
+		rollout.Status = RolloutStatusCompleted
+		return
+	}
+	rollout.CurrentStage++
+}
+
+// countWarnings 统计devices在since之后产生的警告信息数量，即WarningDetectUsecase检测到的预警字段读数越限。
+// 配置更新失败产生的警告信息已经计入失败的设备数，因此不重复统计
+func (u *RolloutUsecase) countWarnings(
+	ctx context.Context, deviceClassID int, devices []string, since time.Time) (int, error) {
+	msgs, err := u.uc.updater.pubSubClient.GetStreamMsgs(ctx, GetConfigUpdateWarningsKey())
+	if err != nil {
+		return 0, err
+	}
+	inStage := make(map[string]bool, len(devices))
+	for _, id := range devices {
+		inStage[id] = true
+	}
+
+	var count int
+	for _, m := range msgs {
+		warning := new(utilApi.Warning)
+		if err := protojson.Unmarshal([]byte(m.Value), warning); err != nil {
+			continue
+		}
+		if int(warning.DeviceClassId) == deviceClassID && inStage[warning.DeviceId] &&
+			warning.DeviceFieldName != configUpdateWarningFieldName && !warning.End.AsTime().Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// saveRollout 保存灰度发布，已经结束的灰度发布在保留时间过后被删除
+func (u *RolloutUsecase) saveRollout(ctx context.Context, rollout *ConfigRollout) error {
+	rollout.UpdatedAt = time.Now()
+	config, err := u.repo.EncodeConfig(rollout.Config, GetConfigRolloutKey(rollout.RolloutID), rolloutFieldConfig)
+	if err != nil {
+		return err
+	}
+	stored := *rollout
+	stored.Config = nil
+	value, err := json.Marshal(&stored)
+	if err != nil {
+		return errors.Newf(500, "Biz_Config_Error", "序列化灰度发布时发生了错误:%v", err)
+	}
+
+	var ttl time.Duration
+	if rollout.Finished() {
+		ttl = u.retention
+	}
+	err = u.repo.SaveRollout(ctx, GetConfigRolloutKey(rollout.RolloutID),
+		map[string]string{rolloutField: string(value), rolloutFieldConfig: config}, ttl)
+	if err != nil {
+		return err
+	}
+	if rollout.Finished() {
+		return u.repo.RemoveActiveRollout(ctx, GetActiveConfigRolloutsKey(), rollout.RolloutID)
+	}
+	return nil
+}
+
+// getRollout 查询灰度发布以及尚未执行的控制命令
+func (u *RolloutUsecase) getRollout(ctx context.Context, rolloutID string) (*ConfigRollout, string, error) {
+	if rolloutID == "" {
+		return nil, "", errors.New(400, "Biz_Config_Error", "灰度发布的id不能为空")
+	}
+	fields, err := u.repo.GetRollout(ctx, GetConfigRolloutKey(rolloutID))
+	if err != nil {
+		return nil, "", err
+	}
+	rollout := new(ConfigRollout)
+	if err := json.Unmarshal([]byte(fields[rolloutField]), rollout); err != nil {
+		return nil, "", errors.Newf(500, "Biz_Config_Error", "反序列化灰度发布时发生了错误:%v", err)
+	}
+	if config, ok := fields[rolloutFieldConfig]; ok {
+		rollout.Config, err = u.repo.DecodeConfig(config, GetConfigRolloutKey(rolloutID), rolloutFieldConfig)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return rollout, fields[rolloutFieldControl], nil
+}
+
+// config 依据protobuf消息全名还原灰度发布下发的设备配置
+func (r *ConfigRollout) config() (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(r.ConfigType))
+	if err != nil {
+		return nil, errors.Newf(500, "Biz_Config_Error", "未知的设备配置类型%s:%v", r.ConfigType, err)
+	}
+	config := mt.New().Interface()
+	if err := proto.Unmarshal(r.Config, config); err != nil {
+		return nil, errors.Newf(500, "Biz_Config_Error", "反序列化设备配置信息时发生了错误:%v", err)
+	}
+	return config, nil
+}
+
+// stageDevices 返回第i个阶段下发配置更新的设备
+func (r *ConfigRollout) stageDevices(i int) []string {
+	var start int64
+	for _, stage := range r.Stages[:i] {
+		start += stage.Devices
+	}
+	return r.DeviceIDs[start : start+r.Stages[i].Devices]
+}
@@ -0,0 +1,186 @@
+package biz
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	protoV1 "github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeviceShadow 设备影子，分别保存期望设备使用的配置以及设备上报的配置
+type DeviceShadow struct {
+	// 期望设备使用的配置，尚未下发过配置更新时为nil
+	Desired proto.Message
+	// 设备上报的配置，设备尚未上报配置时为nil
+	Reported proto.Message
+	// desired与reported之间存在差异的字段
+	Delta []*ConfigFieldDiff
+	// reported是否与desired一致，尚未下发过配置更新时视为一致
+	InSync bool
+	// desired配置相应的修订版本号，尚未下发过配置更新时为0
+	Revision int64
+}
+
+// GetDeviceShadow 查询设备影子，protoTemplate用于反序列化desired以及reported配置
+func (u *ConfigUsecase) GetDeviceShadow(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (shadow *DeviceShadow, err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.GetDeviceShadow",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	values, err := u.repo.BatchGetDeviceConfigs(ctx, GetDeviceDesiredConfigKey(info), info.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	desired := values[0]
+	values, err = u.repo.BatchGetDeviceConfigs(ctx, GetDeviceConfigKey(info), info.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	reported := values[0]
+	if desired == nil && reported == nil {
+		return nil, errors.Newf(404, "Biz_Config_NotFound", "设备 %s 的配置不存在", info.DeviceID)
+	}
+
+	shadow = new(DeviceShadow)
+	if shadow.Desired, err = unmarshalShadowConfig(desired, protoTemplate); err != nil {
+		return nil, err
+	}
+	if shadow.Reported, err = unmarshalShadowConfig(reported, protoTemplate); err != nil {
+		return nil, err
+	}
+	shadow.Delta, shadow.InSync = shadowDelta(shadow.Desired, shadow.Reported, protoTemplate)
+	if shadow.Revision, err = u.getDesiredRevision(ctx, info); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Bool("shadow.in_sync", shadow.InSync))
+	return shadow, nil
+}
+
+// SyncDeviceShadow 若reported与desired不一致，且待确认队列中没有尚未确认的desired配置，则将desired配置重新下发给设备以消除delta，
+// 由SaveInitialDeviceConfig在设备重新连接并上传的初始配置冲突、且desired配置生效时，
+// 以及由SyncClientDeviceShadows在设备的配置更新流建立时调用。
+// 由于proto3中未设置的标量字段无法与零值区分，这里下发的是完整的desired配置而非只包含delta字段的配置
+func (u *ConfigUsecase) SyncDeviceShadow(
+	ctx context.Context, info *DeviceGeneralInfo, protoTemplate proto.Message) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SyncDeviceShadow",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	shadow, err := u.GetDeviceShadow(ctx, info, protoTemplate)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("shadow.delta", len(shadow.Delta)))
+	if shadow.InSync {
+		return nil
+	}
+
+	// 待确认队列中已有desired配置时不重复下发，避免设备反复上传冲突的配置时待确认队列被相同的配置更新占满
+	desired := protoV1.MessageV1(shadow.Desired)
+	clientID, err := u.updater.GetDeviceClientID(ctx, info)
+	if err != nil {
+		return err
+	}
+	if clientID != "" {
+		pending, err := u.updater.hasPendingUpdate(ctx, clientID, info, desired)
+		if err != nil {
+			return err
+		}
+		span.SetAttributes(attribute.Bool("shadow.pending", pending))
+		if pending {
+			return nil
+		}
+	}
+
+	_, _, err = u.updater.UpdateDeviceConfig(ctx, info, desired)
+	return err
+}
+
+// SyncClientDeviceShadows 对与clientID相关联的设备类别下的设备逐一调用SyncDeviceShadow，
+// 由配置更新流在重发待确认的配置更新之后调用，使设备离线期间未能应用的desired配置在重新连接时下发。
+// 单个设备同步失败时只打印日志，继续同步其余的设备
+func (u *ConfigUsecase) SyncClientDeviceShadows(
+	ctx context.Context, clientID string, deviceClassID int, protoTemplate proto.Message) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "ConfigUsecase.SyncClientDeviceShadows",
+		trace.WithAttributes(
+			attribute.String("client.id", clientID),
+			attribute.Int("device.class_id", deviceClassID),
+		))
+	defer func() { monitor.EndSpan(span, err) }()
+
+	ids, err := u.updater.GetClientDeviceIDs(ctx, clientID, deviceClassID)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("client.devices", len(ids)))
+	for _, id := range ids {
+		info := &DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: id}
+		// 设备尚未保存任何配置时不存在影子
+		if err := u.SyncDeviceShadow(ctx, info, protoTemplate); err != nil && errors.Code(err) != 404 {
+			u.logger.Errorf("同步设备 %v 的影子时发生了错误:%v", id, err)
+		}
+	}
+	return nil
+}
+
+// saveDesiredConfig 保存期望设备使用的配置
+func (u *ConfigUsecase) saveDesiredConfig(ctx context.Context, info *DeviceGeneralInfo, config []byte) error {
+	return u.repo.SaveDeviceConfig(ctx, GetDeviceDesiredConfigKey(info), info.DeviceID, config)
+}
+
+// saveDesiredRevision 以整数保存desired配置相应的修订版本号，作为以expected_revision更新配置时的比较对象。
+// expected大于0时以比较并设置的方式保存，desired配置的修订版本号已经不等于expected时说明其他请求在检查版本号之后修改了配置，
+// 此时将版本号清零并返回409错误。revision为0即修订记录失败时同样将版本号清零，使以旧版本号为前提条件的更新无法通过检查
+func (u *ConfigUsecase) saveDesiredRevision(
+	ctx context.Context, info *DeviceGeneralInfo, expected, revision int64) error {
+	key := GetDeviceDesiredRevisionKey(info)
+	if expected <= 0 {
+		return u.repo.SetDeviceConfigSeq(ctx, key, info.DeviceID, revision)
+	}
+	swapped, err := u.repo.CompareAndSetDeviceConfigSeq(ctx, key, info.DeviceID, expected, revision)
+	if err != nil {
+		return err
+	}
+	if !swapped {
+		if err := u.repo.SetDeviceConfigSeq(ctx, key, info.DeviceID, 0); err != nil {
+			return err
+		}
+		return errors.Newf(409, "Biz_Config_Conflict",
+			"设备 %s 的配置在检查修订版本号之后被其他请求修改", info.DeviceID)
+	}
+	return nil
+}
+
+// getDesiredRevision 查询desired配置相应的修订版本号，尚未下发过配置更新时返回0
+func (u *ConfigUsecase) getDesiredRevision(ctx context.Context, info *DeviceGeneralInfo) (int64, error) {
+	return u.repo.GetDeviceConfigSeq(ctx, GetDeviceDesiredRevisionKey(info), info.DeviceID)
+}
+
+// shadowDelta 计算desired与reported之间的差异，desired为nil时视为一致
+func shadowDelta(desired, reported, protoTemplate proto.Message) ([]*ConfigFieldDiff, bool) {
+	if desired == nil {
+		return nil, true
+	}
+	if reported == nil {
+		reported = proto.Clone(protoTemplate)
+		proto.Reset(reported)
+	}
+	delta := diffConfigs(reported, desired)
+	return delta, len(delta) == 0
+}
+
+// unmarshalShadowConfig 反序列化影子中的配置，配置不存在时返回nil
+func unmarshalShadowConfig(value []byte, protoTemplate proto.Message) (proto.Message, error) {
+	if value == nil {
+		return nil, nil
+	}
+	configs, err := unmarshalDeviceConfigs([][]byte{value}, protoTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return configs[0], nil
+}
@@ -1,22 +1,43 @@
 package biz
 
 import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	utilApi "gitee.com/moyusir/util/api/util/v1"
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"strconv"
+	"sync"
 	"time"
 )
 
 // WarningDetectFieldLabelName 在创建设备预警字段相应ts的标签时使用的标签名
 const WarningDetectFieldLabelName = "field_id"
 
+// deviceWarningTTL 警告信息stream在没有新的警告信息时的有效期
+const deviceWarningTTL = 24 * time.Hour
+
 type WarningDetectUsecase struct {
-	repo   WarningDetectRepo
-	logger *log.Helper
+	repo     WarningDetectRepo
+	readings *DeviceReadingCache
+	// 以设备类别号以及预警字段名为键的读数合法范围
+	limits map[int]map[string]*conf.Data_DeviceClass_WarningLimit
+	// 读数处于越限状态的设备字段及其开始越限的时间，只在读数由正常变为越限时产生警告信息
+	violations sync.Map
+	logger     *log.Helper
 }
 
 type WarningDetectRepo interface {
 	// SaveDeviceState 保存设备完整状态信息以及预警字段信息
-	SaveDeviceState(measurement *DeviceStateMeasurement) error
+	SaveDeviceState(ctx context.Context, measurement *DeviceStateMeasurement) error
+	// AddStreamMsg 向指定的stream追加消息
+	AddStreamMsg(ctx context.Context,
+		stream, msg string, maxLen int64, ttl time.Duration) (id string, trimmed []*StreamMsg, err error)
 }
 
 // DeviceStateMeasurement 每个设备状态信息以measurement的形式保存到influxdb中
@@ -27,29 +48,97 @@ type DeviceStateMeasurement struct {
 	Fields map[string]float64
 }
 
-func NewWarningDetectUsecase(repo UnionRepo, logger log.Logger) *WarningDetectUsecase {
-	return &WarningDetectUsecase{
-		repo:   repo,
-		logger: log.NewHelper(logger),
+func NewWarningDetectUsecase(
+	c *conf.Data, repo UnionRepo, readings *DeviceReadingCache, logger log.Logger) *WarningDetectUsecase {
+	u := &WarningDetectUsecase{
+		repo:     repo,
+		readings: readings,
+		limits:   make(map[int]map[string]*conf.Data_DeviceClass_WarningLimit),
+		logger:   log.NewHelper(logger),
+	}
+	for classID, class := range c.GetDeviceClasses() {
+		if len(class.GetWarningLimits()) > 0 {
+			u.limits[int(classID)] = class.WarningLimits
+		}
 	}
+	return u
 }
 
 // SaveDeviceState 保存设备状态的完整信息以及预警字段信息,其中预警字段以<字段名>:<字段值>的map形式传入函数，
 // 非时间字段的设备字段被视作tag，也以map形式传入
 func (u *WarningDetectUsecase) SaveDeviceState(
+	ctx context.Context,
 	info *DeviceGeneralInfo,
 	time time.Time,
 	fields map[string]float64,
-	tags map[string]string) error {
+	tags map[string]string) (err error) {
+	ctx, span := monitor.StartSpan(ctx, "WarningDetectUsecase.SaveDeviceState",
+		trace.WithAttributes(deviceAttributes(info)...))
+	defer func() { monitor.EndSpan(span, err) }()
+
 	// 设备的预警字段信息以influxdb measurement的形式，保存到用户id相应的bucket以及设备id相应的measurement
 	// 中，并以tag deviceClassID区分设备类别，各个字段的信息以field的形式保存在measurement的field中，
 	// 非时间的预警字段则作为measurement的tag保存进influxdb
 	tags["deviceClassID"] = strconv.Itoa(info.DeviceClassID)
 
-	return u.repo.SaveDeviceState(&DeviceStateMeasurement{
+	err = u.repo.SaveDeviceState(ctx, &DeviceStateMeasurement{
 		Name:   info.DeviceID,
 		Time:   time,
 		Tags:   tags,
 		Fields: fields,
 	})
+	if err != nil {
+		return err
+	}
+
+	// 保存成功后更新设备的最新读数，用于暴露给prometheus抓取
+	u.readings.Update(info, time, fields)
+
+	// 设备状态已经保存，因此上报警告信息失败时只打印日志
+	if err := u.detectWarnings(ctx, info, time, fields); err != nil {
+		u.logger.Error(err)
+	}
+	return nil
+}
+
+// detectWarnings 依据设备类别配置的读数合法范围检查预警字段，读数由正常变为越限时向警告信息stream追加警告信息，
+// 灰度发布以其判断阶段中的设备在下发配置后是否健康
+func (u *WarningDetectUsecase) detectWarnings(
+	ctx context.Context, info *DeviceGeneralInfo, stateTime time.Time, fields map[string]float64) error {
+	limits := u.limits[info.DeviceClassID]
+	for field, value := range fields {
+		limit, ok := limits[field]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%d:%s:%s", info.DeviceClassID, info.DeviceID, field)
+		if (limit.Min == nil || value >= limit.Min.Value) && (limit.Max == nil || value <= limit.Max.Value) {
+			u.violations.Delete(key)
+			continue
+		}
+		if _, violating := u.violations.LoadOrStore(key, stateTime); violating {
+			continue
+		}
+
+		warning := &utilApi.Warning{
+			DeviceClassId:   int32(info.DeviceClassID),
+			DeviceId:        info.DeviceID,
+			DeviceFieldName: field,
+			WarningMessage:  fmt.Sprintf("字段 %s 的读数 %v 超出了合法范围", field, value),
+			Start:           timestamppb.New(stateTime),
+			End:             timestamppb.New(stateTime),
+		}
+		msg, err := protojson.Marshal(warning)
+		if err != nil {
+			return errors.Newf(500, "Biz_State_Error", "序列化设备 %s 的警告信息时发生了错误:%v", info.DeviceID, err)
+		}
+		_, _, err = u.repo.AddStreamMsg(
+			ctx, GetConfigUpdateWarningsKey(), string(msg), maxConfigUpdateWarnings, deviceWarningTTL)
+		if err != nil {
+			// 上报失败时下一次越限的读数重新上报
+			u.violations.Delete(key)
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// SaveBulkUpdateJob 利用hset保存批量更新任务中的各个field，并刷新hash的过期时间
+func (r *Repo) SaveBulkUpdateJob(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error {
+	values := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		values = append(values, k, v)
+	}
+
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	// 两条命令操作同一个键，可以在集群模式下以pipeline发送
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, values...)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "保存批量更新任务时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// GetBulkUpdateJob 利用hgetall查询批量更新任务的全部field
+func (r *Repo) GetBulkUpdateJob(ctx context.Context, key string) (map[string]string, error) {
+	ctx, span := startRedisSpan(ctx, "HGETALL", key)
+	fields, err := r.redisClient.HGetAll(ctx, key).Result()
+	if err == nil && len(fields) == 0 {
+		err = errors.New(404, "Repo_Config_NotFound", "批量更新任务不存在或者已经过期")
+		monitor.EndSpan(span, err)
+		return nil, err
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询批量更新任务时发生了错误:%v", err)
+	}
+	return fields, nil
+}
+
+// SaveDeviceLabels 利用hset以设备id为field保存设备标签的json
+func (r *Repo) SaveDeviceLabels(ctx context.Context, key, field, labels string) error {
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	err := r.redisClient.HSet(ctx, key, field, labels).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "保存设备 %s 的标签时发生了错误:%v", field, err)
+	}
+	return nil
+}
+
+// BatchGetDeviceLabels 利用hmget批量查询设备标签的json，未设置标签的设备以空字符串表示
+func (r *Repo) BatchGetDeviceLabels(ctx context.Context, key string, fields ...string) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	ctx, span := startRedisSpan(ctx, "HMGET", key)
+	values, err := r.redisClient.HMGet(ctx, key, fields...).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "批量查询设备标签时发生了错误:%v", err)
+	}
+
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i], _ = v.(string)
+	}
+	return labels, nil
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// auditRecord 配置审计记录在redis stream中保存的形式，设备配置同样以十六进制字符串或者加密后的字符串保存
+type auditRecord struct {
+	Time      time.Time `json:"time"`
+	Actor     string    `json:"actor"`
+	SourceIP  string    `json:"sourceIP,omitempty"`
+	PeerIP    string    `json:"peerIP,omitempty"`
+	Operation string    `json:"operation"`
+	DeviceID  string    `json:"deviceID"`
+	UpdateID  string    `json:"updateID,omitempty"`
+	OldConfig string    `json:"oldConfig,omitempty"`
+	NewConfig string    `json:"newConfig,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AddConfigAuditRecord 利用xadd向stream追加审计记录，并近似地将stream长度限制在maxLen以内。
+// 审计记录需要长期保留，因此不设置stream的过期时间
+func (r *Repo) AddConfigAuditRecord(ctx context.Context, key string, record *biz.ConfigAuditRecord, maxLen int64) error {
+	oldConfig, err := r.encodeAuditConfig(record.OldConfig, key, auditConfigField(record.DeviceID, auditOldConfig))
+	if err != nil {
+		return err
+	}
+	newConfig, err := r.encodeAuditConfig(record.NewConfig, key, auditConfigField(record.DeviceID, auditNewConfig))
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(&auditRecord{
+		Time:      record.Time,
+		Actor:     record.Actor,
+		SourceIP:  record.SourceIP,
+		PeerIP:    record.PeerIP,
+		Operation: record.Operation,
+		DeviceID:  record.DeviceID,
+		UpdateID:  record.UpdateID,
+		OldConfig: oldConfig,
+		NewConfig: newConfig,
+		Result:    record.Result,
+		Error:     record.Error,
+	})
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "序列化配置审计记录时发生了错误:%v", err)
+	}
+
+	ctx, span := startRedisSpan(ctx, "XADD", key)
+	id, err := r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: []string{streamMsgField, string(value)},
+	}).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "保存配置审计记录时发生了错误:%v", err)
+	}
+	record.ID = id
+	return nil
+}
+
+// RangeConfigAuditRecords 利用xrange按照从旧到新的顺序查询stream中的审计记录
+func (r *Repo) RangeConfigAuditRecords(
+	ctx context.Context, key, start, end string, count int64) ([]*biz.ConfigAuditRecord, error) {
+	ctx, span := startRedisSpan(ctx, "XRANGE", key)
+	values, err := r.redisClient.XRangeN(ctx, key, start, end, count).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询配置审计记录时发生了错误:%v", err)
+	}
+
+	records := make([]*biz.ConfigAuditRecord, 0, len(values))
+	for _, v := range values {
+		msg, ok := v.Values[streamMsgField].(string)
+		if !ok {
+			continue
+		}
+		record, err := r.decodeAuditRecord(key, v.ID, msg)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// 审计记录中变更前后的配置
+const (
+	auditOldConfig = "old"
+	auditNewConfig = "new"
+)
+
+// auditConfigField 审计记录中的配置以设备id以及变更前后作为加密的field，使变更前后的配置无法互换
+func auditConfigField(deviceID, side string) string {
+	return deviceID + ":" + side
+}
+
+func (r *Repo) encodeAuditConfig(config []byte, key, field string) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	return r.configCipher.Encode(config, key, field)
+}
+
+// decodeAuditRecord 将stream中保存的审计记录转换为biz层的审计记录
+func (r *Repo) decodeAuditRecord(key, id, msg string) (*biz.ConfigAuditRecord, error) {
+	var record auditRecord
+	if err := json.Unmarshal([]byte(msg), &record); err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "反序列化配置审计记录时发生了错误:%v", err)
+	}
+
+	configs := make([][]byte, 2)
+	for i, c := range []string{record.OldConfig, record.NewConfig} {
+		if c == "" {
+			continue
+		}
+		side := auditOldConfig
+		if i == 1 {
+			side = auditNewConfig
+		}
+		config, err := r.configCipher.Decode(c, key, auditConfigField(record.DeviceID, side))
+		if err != nil {
+			return nil, err
+		}
+		configs[i] = config
+	}
+	return &biz.ConfigAuditRecord{
+		ID:        id,
+		Time:      record.Time,
+		Actor:     record.Actor,
+		SourceIP:  record.SourceIP,
+		PeerIP:    record.PeerIP,
+		Operation: record.Operation,
+		DeviceID:  record.DeviceID,
+		UpdateID:  record.UpdateID,
+		OldConfig: configs[0],
+		NewConfig: configs[1],
+		Result:    record.Result,
+		Error:     record.Error,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+// ScanDeviceIDs 利用hscan遍历hash中的设备id
+func (r *Repo) ScanDeviceIDs(ctx context.Context, key string, cursor uint64, count int64) ([]string, uint64, error) {
+	ctx, span := startRedisSpan(ctx, "HSCAN", key)
+	kvs, next, err := r.redisClient.HScan(ctx, key, cursor, "", count).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, 0, errors.Newf(
+			500, "Repo_Config_Error", "遍历设备id时发生了错误:%v", err)
+	}
+
+	// hscan返回的结果为field与value交替排列的切片
+	ids := make([]string, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		ids = append(ids, kvs[i])
+	}
+	return ids, next, nil
+}
+
+// GetConfigVersion 利用hget查询hincrby产生的最新的修订版本号
+func (r *Repo) GetConfigVersion(ctx context.Context, versionKey, field string) (int64, error) {
+	ctx, span := startRedisSpan(ctx, "HGET", versionKey)
+	version, err := r.redisClient.HGet(ctx, versionKey, field).Int64()
+	if err == redis.Nil {
+		err = nil
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return 0, errors.Newf(
+			500, "Repo_Config_Error", "查询设备配置的修订版本号时发生了错误:%v", err)
+	}
+	return version, nil
+}
+
+// RestoreConfigRevisions 删除zset后以修订的版本号为score重新写入全部修订，再设置最新的修订版本号
+func (r *Repo) RestoreConfigRevisions(ctx context.Context, versionKey, field, revisionKey string,
+	version int64, revisions []*biz.ConfigRevision) error {
+	members := make([]*redis.Z, 0, len(revisions))
+	for _, revision := range revisions {
+		record, err := r.encodeConfigRevision(revisionKey, revision.Version, revision)
+		if err != nil {
+			return err
+		}
+		members = append(members, &redis.Z{Score: float64(revision.Version), Member: record})
+	}
+
+	// 两条命令操作同一个键，可以在集群模式下以pipeline发送
+	ctx, span := startRedisSpan(ctx, "ZADD", revisionKey)
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, revisionKey)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, revisionKey, members...)
+		}
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "恢复设备配置修订时发生了错误:%v", err)
+	}
+
+	ctx, span = startRedisSpan(ctx, "HSET", versionKey)
+	err = r.redisClient.HSet(ctx, versionKey, field, version).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "恢复设备配置的修订版本号时发生了错误:%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// webhook请求中携带事件id以及请求体签名的请求头
+	ConfigChangeEventIDHeader   = "X-Event-ID"
+	ConfigChangeSignatureHeader = "X-Signature"
+	// webhook各项配置的缺省值
+	defaultConfigWebhookTimeout      = 10 * time.Second
+	defaultConfigWebhookMaxRetries   = 3
+	defaultConfigWebhookCapacity     = 10000
+	defaultConfigWebhookMinBackoff   = 100 * time.Millisecond
+	defaultConfigWebhookMaxBackoff   = 10 * time.Second
+	defaultConfigWebhookDrainTimeout = 10 * time.Second
+)
+
+// configChangeEvent 配置变更事件在redis stream以及webhook请求体中的json形式
+type configChangeEvent struct {
+	// stream中的事件以消息id作为事件id，因此只在webhook请求体中携带
+	ID            string             `json:"id,omitempty"`
+	Time          time.Time          `json:"time"`
+	DeviceClassID int                `json:"deviceClassID"`
+	DeviceID      string             `json:"deviceID"`
+	Source        string             `json:"source"`
+	ClientID      string             `json:"clientID,omitempty"`
+	Revision      int64              `json:"revision,omitempty"`
+	Created       bool               `json:"created,omitempty"`
+	Config        json.RawMessage    `json:"config"`
+	Diff          []*configFieldDiff `json:"diff"`
+}
+
+type configFieldDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func newConfigChangeEvent(event *biz.ConfigChangeEvent) *configChangeEvent {
+	e := &configChangeEvent{
+		ID:            event.ID,
+		Time:          event.Time,
+		DeviceClassID: event.DeviceClassID,
+		DeviceID:      event.DeviceID,
+		Source:        event.Source,
+		ClientID:      event.ClientID,
+		Revision:      event.Revision,
+		Created:       event.Created,
+		Config:        event.Config,
+		Diff:          make([]*configFieldDiff, 0, len(event.Diff)),
+	}
+	for _, d := range event.Diff {
+		e.Diff = append(e.Diff, &configFieldDiff{Field: d.Field, From: d.From, To: d.To})
+	}
+	return e
+}
+
+// PublishConfigChange 利用xadd向stream追加配置变更事件，并近似地将stream长度限制在maxLen以内，
+// 追加成功后将携带了消息id的事件交给webhook客户端异步发送
+func (r *Repo) PublishConfigChange(
+	ctx context.Context, stream string, event *biz.ConfigChangeEvent, maxLen int64, ttl time.Duration) error {
+	value, err := json.Marshal(newConfigChangeEvent(event))
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "序列化配置变更事件时发生了错误:%v", err)
+	}
+
+	ctx, span := startRedisSpan(ctx, "XADD", stream)
+	start := time.Now()
+	var add *redis.StringCmd
+	_, err = r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		add = pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: maxLen,
+			Approx: true,
+			Values: []string{streamMsgField, string(value)},
+		})
+		pipe.Expire(ctx, stream, ttl)
+		return nil
+	})
+	monitor.RedisOperationSeconds.WithLabelValues("xadd", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "向stream追加配置变更事件时发生了错误:%v", err)
+	}
+	event.ID = add.Val()
+
+	if r.configWebhookClient != nil {
+		r.configWebhookClient.Send(event)
+	}
+	return nil
+}
+
+// ConfigWebhookData 将配置变更事件以json格式的POST请求发送给配置的webhook的客户端，
+// 每个webhook拥有独立的发送队列以及发送协程，按照发布的顺序依次发送，响应缓慢的webhook不会阻塞其余webhook
+type ConfigWebhookData struct {
+	retrier  *httpRetrier
+	webhooks []*configWebhook
+	// 关闭时等待剩余事件发送完毕的最长时间
+	drainTimeout time.Duration
+	// 发送协程共用的ctx，等待剩余事件发送超时后被取消
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	logger *log.Helper
+}
+
+type configWebhook struct {
+	url    string
+	secret []byte
+	// 订阅的设备类别，为nil时订阅全部设备类别
+	deviceClassIDs map[int]struct{}
+	// 等待发送给该webhook的事件队列
+	queue chan *configWebhookEvent
+}
+
+// configWebhookEvent 等待发送的配置变更事件
+type configWebhookEvent struct {
+	id   string
+	body []byte
+}
+
+// NewConfigWebhookData 实例化webhook客户端，未配置webhook时返回nil，表示只将配置变更事件追加到stream中
+func NewConfigWebhookData(c *conf.Data, logger log.Logger) (*ConfigWebhookData, func(), error) {
+	cc := c.ConfigChange
+	if len(cc.GetWebhooks()) == 0 {
+		return nil, func() {}, nil
+	}
+
+	timeout := cc.WebhookTimeout.AsDuration()
+	if timeout <= 0 {
+		timeout = defaultConfigWebhookTimeout
+	}
+	data := &ConfigWebhookData{
+		retrier: &httpRetrier{
+			client:     &http.Client{Timeout: timeout},
+			maxRetries: int(cc.WebhookMaxRetries),
+			minBackoff: defaultConfigWebhookMinBackoff,
+			maxBackoff: defaultConfigWebhookMaxBackoff,
+		},
+		drainTimeout: cc.WebhookDrainTimeout.AsDuration(),
+		logger:       log.NewHelper(logger),
+	}
+	if data.retrier.maxRetries <= 0 {
+		data.retrier.maxRetries = defaultConfigWebhookMaxRetries
+	}
+	if data.drainTimeout <= 0 {
+		data.drainTimeout = defaultConfigWebhookDrainTimeout
+	}
+	capacity := int(cc.WebhookCapacity)
+	if capacity <= 0 {
+		capacity = defaultConfigWebhookCapacity
+	}
+	for _, w := range cc.Webhooks {
+		if w.Url == "" {
+			return nil, nil, errors.New(500, "Data_Config_Error", "webhook的地址不能为空")
+		}
+		webhook := &configWebhook{url: w.Url, queue: make(chan *configWebhookEvent, capacity)}
+		if w.Secret != "" {
+			webhook.secret = []byte(w.Secret)
+		}
+		if len(w.DeviceClassIds) > 0 {
+			webhook.deviceClassIDs = make(map[int]struct{}, len(w.DeviceClassIds))
+			for _, id := range w.DeviceClassIds {
+				webhook.deviceClassIDs[int(id)] = struct{}{}
+			}
+		}
+		data.webhooks = append(data.webhooks, webhook)
+	}
+
+	data.ctx, data.cancel = context.WithCancel(context.Background())
+	for _, w := range data.webhooks {
+		data.wg.Add(1)
+		go data.run(w)
+	}
+
+	// 关闭队列后等待剩余事件发送完毕，超过drainTimeout时取消正在发送的请求并丢弃剩余事件
+	cleanup := func() {
+		data.once.Do(func() {
+			for _, w := range data.webhooks {
+				close(w.queue)
+			}
+		})
+		done := make(chan struct{})
+		go func() {
+			data.wg.Wait()
+			close(done)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), data.drainTimeout)
+		defer cancel()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			data.logger.Warnf("等待webhook发送剩余的配置变更事件超过了%s，剩余事件被丢弃", data.drainTimeout)
+			data.cancel()
+			<-done
+		}
+		data.cancel()
+	}
+
+	return data, cleanup, nil
+}
+
+// Send 将配置变更事件放入订阅了事件设备类别的各个webhook的发送队列，队列已满时丢弃事件，
+// 消费方可以从stream中补齐丢弃的事件
+func (d *ConfigWebhookData) Send(event *biz.ConfigChangeEvent) {
+	body, err := json.Marshal(newConfigChangeEvent(event))
+	if err != nil {
+		d.logger.Errorf("序列化配置变更事件 %s 时发生了错误:%v", event.ID, err)
+		return
+	}
+
+	e := &configWebhookEvent{id: event.ID, body: body}
+	for _, w := range d.webhooks {
+		if w.deviceClassIDs != nil {
+			if _, ok := w.deviceClassIDs[event.DeviceClassID]; !ok {
+				continue
+			}
+		}
+		select {
+		case w.queue <- e:
+		default:
+			d.logger.Warnf("webhook %s 的发送队列已满，丢弃了设备 %s 的配置变更事件 %s", w.url, event.DeviceID, event.ID)
+		}
+	}
+}
+
+// run 不断从webhook的队列中取出事件并依次发送，ctx被取消后丢弃队列中剩余的事件
+func (d *ConfigWebhookData) run(w *configWebhook) {
+	defer d.wg.Done()
+
+	var dropped int
+	for event := range w.queue {
+		if d.ctx.Err() != nil {
+			dropped++
+			continue
+		}
+		err := d.send(w, event)
+		monitor.ConfigChangeWebhooks.WithLabelValues(monitor.Result(err)).Inc()
+		if err != nil {
+			d.logger.Error(err)
+		}
+	}
+	if dropped > 0 {
+		d.logger.Warnf("关闭时丢弃了%d个等待发送给webhook %s 的配置变更事件", dropped, w.url)
+	}
+}
+
+// send 向webhook发送一个事件，对可恢复的错误按照指数退避进行重试
+func (d *ConfigWebhookData) send(w *configWebhook, event *configWebhookEvent) error {
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(event.body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(ConfigChangeEventIDHeader, event.id)
+		if w.secret != nil {
+			req.Header.Set(ConfigChangeSignatureHeader, SignConfigChangeEvent(w.secret, event.body))
+		}
+		return req, nil
+	}
+
+	err := d.retrier.do(d.ctx, newRequest, func(attempt int, err error) {
+		d.logger.Warnf("向webhook %s 第%d次发送配置变更事件失败，准备重试:%v", w.url, attempt, err)
+	})
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "向webhook %s 发送配置变更事件 %s 时发生了错误:%v", w.url, event.id, err)
+	}
+	return nil
+}
+
+// SignConfigChangeEvent 以secret作为HMAC-SHA256的密钥对webhook请求体签名，返回X-Signature请求头的值，
+// webhook的接收方以相同的方式计算签名并进行比较，从而校验请求的来源
+func SignConfigChangeEvent(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,159 @@
+package data
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"os"
+	"strings"
+)
+
+// 加密的设备配置以enc:<密钥id>:<十六进制的nonce与密文>的形式保存，
+// 未加密的设备配置为十六进制字符串，两者不会混淆
+const encryptedConfigPrefix = "enc:"
+
+// ConfigCipher 以AES-GCM加密保存到redis中的设备配置，包括设备上报的配置、desired配置、配置模板、
+// 配置修订以及审计记录中的配置。密文中记录了加密所用的密钥id，因此轮换密钥后以旧密钥加密的配置仍然可以读取
+type ConfigCipher struct {
+	// 加密新写入的设备配置所用的密钥id
+	activeKeyID string
+	aeads       map[string]cipher.AEAD
+}
+
+// NewConfigCipher 从配置指定的文件以及环境变量中读取密钥并实例化设备配置的加密器，
+// 未配置密钥时返回nil，表示设备配置以十六进制字符串保存
+func NewConfigCipher(c *conf.Data) (*ConfigCipher, error) {
+	ce := c.ConfigEncryption
+	if ce.GetKeyFile() == "" && ce.GetKeyEnv() == "" {
+		return nil, nil
+	}
+
+	var sources []string
+	if ce.KeyFile != "" {
+		content, err := os.ReadFile(ce.KeyFile)
+		if err != nil {
+			return nil, errors.Newf(500, "Data_Config_Error", "读取设备配置的加密密钥文件时发生了错误:%v", err)
+		}
+		sources = append(sources, string(content))
+	}
+	if ce.KeyEnv != "" {
+		value, ok := os.LookupEnv(ce.KeyEnv)
+		if !ok {
+			return nil, errors.Newf(500, "Data_Config_Error", "保存设备配置加密密钥的环境变量 %s 不存在", ce.KeyEnv)
+		}
+		sources = append(sources, value)
+	}
+
+	keys := make(map[string][]byte)
+	entries := strings.FieldsFunc(strings.Join(sources, "\n"), func(r rune) bool { return r == '\n' || r == ',' })
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.New(500, "Data_Config_Error", "设备配置的加密密钥的格式应为<密钥id>=<base64编码的密钥>")
+		}
+		id := strings.TrimSpace(kv[0])
+		if _, ok := keys[id]; ok {
+			return nil, errors.Newf(500, "Data_Config_Error", "设备配置的加密密钥 %s 重复", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, errors.Newf(500, "Data_Config_Error", "解码设备配置的加密密钥 %s 时发生了错误:%v", id, err)
+		}
+		keys[id] = key
+	}
+	return NewConfigCipherFromKeys(keys, ce.ActiveKeyId)
+}
+
+// NewConfigCipherFromKeys 以密钥id到密钥的映射实例化设备配置的加密器，密钥长度为16、24或32字节，
+// 分别对应AES-128、AES-192以及AES-256。只有一个密钥时activeKeyID可以为空
+func NewConfigCipherFromKeys(keys map[string][]byte, activeKeyID string) (*ConfigCipher, error) {
+	if len(keys) == 0 {
+		return nil, errors.New(500, "Data_Config_Error", "没有可用的设备配置加密密钥")
+	}
+
+	c := &ConfigCipher{activeKeyID: activeKeyID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, errors.Newf(500, "Data_Config_Error", "设备配置的加密密钥id %q 不能为空或者包含:", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.Newf(500, "Data_Config_Error", "设备配置的加密密钥 %s 不合法:%v", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Newf(500, "Data_Config_Error", "设备配置的加密密钥 %s 不合法:%v", id, err)
+		}
+		c.aeads[id] = aead
+		if activeKeyID == "" && len(keys) == 1 {
+			c.activeKeyID = id
+		}
+	}
+	if _, ok := c.aeads[c.activeKeyID]; !ok {
+		return nil, errors.Newf(500, "Data_Config_Error", "加密设备配置所用的密钥 %q 不存在", activeKeyID)
+	}
+	return c, nil
+}
+
+// Encode 将设备配置的protobuf二进制信息转换为redis中保存的字符串，未启用加密时为十六进制字符串。
+// 加密时以配置所在的redis键key以及field作为附加认证数据，被复制到其他键或者其他field下的密文无法解密
+func (c *ConfigCipher) Encode(value []byte, key, field string) (string, error) {
+	if c == nil {
+		return hex.EncodeToString(value), nil
+	}
+
+	aead := c.aeads[c.activeKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Newf(500, "Repo_Config_Error", "产生加密设备配置所需的nonce时发生了错误:%v", err)
+	}
+	sealed := aead.Seal(nonce, nonce, value, additionalData(key, field))
+	return encryptedConfigPrefix + c.activeKeyID + ":" + hex.EncodeToString(sealed), nil
+}
+
+// Decode 将redis中保存的字符串转换回设备配置的protobuf二进制信息，兼容启用加密之前保存的未加密配置。
+// key以及field需要与加密时相同
+func (c *ConfigCipher) Decode(v, key, field string) ([]byte, error) {
+	if !strings.HasPrefix(v, encryptedConfigPrefix) {
+		config, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, errors.Newf(
+				500, "Repo_Config_Error", "解码设备配置的十六进制字符串时发生了错误:%v", err)
+		}
+		return config, nil
+	}
+
+	parts := strings.SplitN(v[len(encryptedConfigPrefix):], ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New(500, "Repo_Config_Error", "加密的设备配置格式不合法")
+	}
+	if c == nil {
+		return nil, errors.New(500, "Repo_Config_Error", "设备配置已被加密，但没有配置解密所需的密钥")
+	}
+	aead, ok := c.aeads[parts[0]]
+	if !ok {
+		return nil, errors.Newf(500, "Repo_Config_Error", "解密设备配置所需的密钥 %s 不存在", parts[0])
+	}
+	sealed, err := hex.DecodeString(parts[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New(500, "Repo_Config_Error", "加密的设备配置格式不合法")
+	}
+	config, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData(key, field))
+	if err != nil {
+		return nil, errors.Newf(500, "Repo_Config_Error", "解密设备配置时发生了错误:%v", err)
+	}
+	return config, nil
+}
+
+// additionalData 以\x00分隔key与field，避免不同的key与field拼接得到相同的附加认证数据
+func additionalData(key, field string) []byte {
+	return []byte(key + "\x00" + field)
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+// UpdateConfigIndexes 利用srem以及sadd维护设备所属的索引，同一设备类别的索引位于同一slot中，因此可以在事务中执行
+func (r *Repo) UpdateConfigIndexes(ctx context.Context, deviceID string, removeKeys, addKeys []string) error {
+	keys := append(append([]string(nil), removeKeys...), addKeys...)
+	if len(keys) == 0 {
+		return nil
+	}
+	ctx, span := startRedisSpan(ctx, "MULTI", keys[0])
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range removeKeys {
+			pipe.SRem(ctx, key, deviceID)
+		}
+		for _, key := range addKeys {
+			pipe.SAdd(ctx, key, deviceID)
+		}
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "维护设备 %s 的配置索引时发生了错误:%v", deviceID, err)
+	}
+	return nil
+}
+
+// IntersectConfigIndexes 利用sinter求索引的交集
+func (r *Repo) IntersectConfigIndexes(ctx context.Context, keys ...string) ([]string, error) {
+	ctx, span := startRedisSpan(ctx, "SINTER", keys[0])
+	ids, err := r.redisClient.SInter(ctx, keys...).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询设备配置索引时发生了错误:%v", err)
+	}
+	return ids, nil
+}
+
+// MarkConfigIndexRepair 利用hset记录维护索引失败的设备
+func (r *Repo) MarkConfigIndexRepair(ctx context.Context, key, deviceID, value string) error {
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	err := r.redisClient.HSet(ctx, key, deviceID, value).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "记录设备 %s 等待修复配置索引时发生了错误:%v", deviceID, err)
+	}
+	return nil
+}
+
+// GetConfigIndexRepairs 利用hgetall查询等待修复索引的设备
+func (r *Repo) GetConfigIndexRepairs(ctx context.Context, key string) (map[string]string, error) {
+	ctx, span := startRedisSpan(ctx, "HGETALL", key)
+	repairs, err := r.redisClient.HGetAll(ctx, key).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询等待修复配置索引的设备时发生了错误:%v", err)
+	}
+	return repairs, nil
+}
+
+// UnmarkConfigIndexRepair 利用lua脚本在设备的失败时间仍为value时删除设备的修复记录
+func (r *Repo) UnmarkConfigIndexRepair(ctx context.Context, key, deviceID, value string) error {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", key)
+	err := deleteFieldIfEqualScript.Run(ctx, r.redisClient, []string{key}, deviceID, value).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除设备 %s 的配置索引修复记录时发生了错误:%v", deviceID, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+)
+
+// revisionRecord 配置修订在redis zset中保存的形式，设备配置同样以十六进制字符串或者加密后的字符串保存
+type revisionRecord struct {
+	Version  int64     `json:"version"`
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	ClientID string    `json:"clientID,omitempty"`
+	Config   string    `json:"config"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// SaveConfigRevision 利用hincrby产生设备配置的修订版本号，并以版本号为score将修订保存到zset中，
+// 保存后移除超出maxRevisions的旧修订
+func (r *Repo) SaveConfigRevision(ctx context.Context, versionKey, field, revisionKey string,
+	revision *biz.ConfigRevision, maxRevisions int64) (int64, error) {
+	ctx, span := startRedisSpan(ctx, "HINCRBY", versionKey)
+	version, err := r.redisClient.HIncrBy(ctx, versionKey, field, 1).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return 0, errors.Newf(
+			500, "Repo_Config_Error", "产生设备配置的修订版本号时发生了错误:%v", err)
+	}
+
+	record, err := r.encodeConfigRevision(revisionKey, version, revision)
+	if err != nil {
+		return 0, err
+	}
+
+	// 两条命令操作同一个键，可以在集群模式下以pipeline发送
+	ctx, span = startRedisSpan(ctx, "ZADD", revisionKey)
+	_, err = r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, revisionKey, &redis.Z{Score: float64(version), Member: string(record)})
+		if maxRevisions > 0 {
+			pipe.ZRemRangeByRank(ctx, revisionKey, 0, -(maxRevisions + 1))
+		}
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return 0, errors.Newf(
+			500, "Repo_Config_Error", "保存设备配置修订时发生了错误:%v", err)
+	}
+
+	revision.Version = version
+	return version, nil
+}
+
+// ListConfigRevisions 按照版本号从新到旧查询版本号小于before的至多count个修订，before为0时从最新的修订开始查询
+func (r *Repo) ListConfigRevisions(
+	ctx context.Context, revisionKey string, before, count int64) ([]*biz.ConfigRevision, error) {
+	max := "+inf"
+	if before > 0 {
+		max = "(" + strconv.FormatInt(before, 10)
+	}
+
+	ctx, span := startRedisSpan(ctx, "ZREVRANGEBYSCORE", revisionKey)
+	records, err := r.redisClient.ZRevRangeByScore(ctx, revisionKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   max,
+		Count: count,
+	}).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询设备配置修订时发生了错误:%v", err)
+	}
+
+	revisions := make([]*biz.ConfigRevision, 0, len(records))
+	for _, record := range records {
+		revision, err := r.decodeConfigRevision(revisionKey, record)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetConfigRevision 查询指定版本号的修订
+func (r *Repo) GetConfigRevision(ctx context.Context, revisionKey string, version int64) (*biz.ConfigRevision, error) {
+	score := strconv.FormatInt(version, 10)
+
+	ctx, span := startRedisSpan(ctx, "ZRANGEBYSCORE", revisionKey)
+	records, err := r.redisClient.ZRangeByScore(ctx, revisionKey, &redis.ZRangeBy{
+		Min: score,
+		Max: score,
+	}).Result()
+	if err == nil && len(records) == 0 {
+		err = errors.Newf(404, "Repo_Config_NotFound", "版本号为%d的设备配置修订不存在", version)
+		monitor.EndSpan(span, err)
+		return nil, err
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询设备配置修订时发生了错误:%v", err)
+	}
+
+	return r.decodeConfigRevision(revisionKey, records[0])
+}
+
+// encodeConfigRevision 将配置修订转换为zset中保存的修订记录，其中的配置以修订版本号作为加密的field
+func (r *Repo) encodeConfigRevision(revisionKey string, version int64, revision *biz.ConfigRevision) (string, error) {
+	config, err := r.configCipher.Encode(revision.Config, revisionKey, strconv.FormatInt(version, 10))
+	if err != nil {
+		return "", err
+	}
+	record, err := json.Marshal(&revisionRecord{
+		Version:  version,
+		Time:     revision.Time,
+		Source:   revision.Source,
+		ClientID: revision.ClientID,
+		Config:   config,
+		Detail:   revision.Detail,
+	})
+	if err != nil {
+		return "", errors.Newf(
+			500, "Repo_Config_Error", "序列化设备配置修订时发生了错误:%v", err)
+	}
+	return string(record), nil
+}
+
+// decodeConfigRevision 将zset中保存的修订记录转换回配置修订
+func (r *Repo) decodeConfigRevision(revisionKey, v string) (*biz.ConfigRevision, error) {
+	record := new(revisionRecord)
+	if err := json.Unmarshal([]byte(v), record); err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "反序列化设备配置修订时发生了错误:%v", err)
+	}
+	config, err := r.configCipher.Decode(record.Config, revisionKey, strconv.FormatInt(record.Version, 10))
+	if err != nil {
+		return nil, err
+	}
+	return &biz.ConfigRevision{
+		Version:  record.Version,
+		Time:     record.Time,
+		Source:   record.Source,
+		ClientID: record.ClientID,
+		Config:   config,
+		Detail:   record.Detail,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"strconv"
+	"time"
+)
+
+// MarkDeviceSeen 利用hsetnx记录设备首次出现的时间，返回设备是否为首次出现
+func (r *Repo) MarkDeviceSeen(ctx context.Context, key, field string) (bool, error) {
+	ctx, span := startRedisSpan(ctx, "HSETNX", key)
+	first, err := r.redisClient.HSetNX(ctx, key, field, strconv.FormatInt(time.Now().UnixNano(), 10)).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return false, errors.Newf(
+			500, "Repo_Config_Error", "记录首次出现的设备时发生了错误:%v", err)
+	}
+	return first, nil
+}
+
+// UnmarkDeviceSeen 利用hdel删除设备出现过的记录
+func (r *Repo) UnmarkDeviceSeen(ctx context.Context, key, field string) error {
+	ctx, span := startRedisSpan(ctx, "HDEL", key)
+	err := r.redisClient.HDel(ctx, key, field).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除设备出现过的记录时发生了错误:%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+// compareAndSetSeqScript 仅在hash的field上的序号等于ARGV[2]时将其设置为ARGV[3]，field不存在时视为0，返回是否设置成功
+var compareAndSetSeqScript = redis.NewScript(`
+local current = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+if current ~= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+return 1
+`)
+
+// IncrDeviceConfigSeq 利用hincrby自增设备的配置更新序号
+func (r *Repo) IncrDeviceConfigSeq(ctx context.Context, key, field string) (int64, error) {
+	ctx, span := startRedisSpan(ctx, "HINCRBY", key)
+	seq, err := r.redisClient.HIncrBy(ctx, key, field, 1).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return 0, errors.Newf(
+			500, "Repo_Config_Error", "自增设备 %s 的配置更新序号时发生了错误:%v", field, err)
+	}
+	return seq, nil
+}
+
+// GetDeviceConfigSeq 利用hget查询设备的配置更新序号
+func (r *Repo) GetDeviceConfigSeq(ctx context.Context, key, field string) (int64, error) {
+	ctx, span := startRedisSpan(ctx, "HGET", key)
+	seq, err := r.redisClient.HGet(ctx, key, field).Int64()
+	if err == redis.Nil {
+		err = nil
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return 0, errors.Newf(
+			500, "Repo_Config_Error", "查询设备 %s 的配置更新序号时发生了错误:%v", field, err)
+	}
+	return seq, nil
+}
+
+// SetDeviceConfigSeq 利用hset设置设备的配置更新序号
+func (r *Repo) SetDeviceConfigSeq(ctx context.Context, key, field string, seq int64) error {
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	err := r.redisClient.HSet(ctx, key, field, seq).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "设置设备 %s 的配置更新序号时发生了错误:%v", field, err)
+	}
+	return nil
+}
+
+// CompareAndSetDeviceConfigSeq 利用lua脚本比较并设置设备的序号
+func (r *Repo) CompareAndSetDeviceConfigSeq(ctx context.Context, key, field string, old, seq int64) (bool, error) {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", key)
+	swapped, err := compareAndSetSeqScript.Run(ctx, r.redisClient, []string{key}, field, old, seq).Bool()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return false, errors.Newf(
+			500, "Repo_Config_Error", "比较并设置设备 %s 的序号时发生了错误:%v", field, err)
+	}
+	return swapped, nil
+}
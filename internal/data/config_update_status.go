@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// SaveConfigUpdateStatus 利用hset保存配置更新状态中的各个field，并刷新hash的过期时间
+func (r *Repo) SaveConfigUpdateStatus(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error {
+	values := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		values = append(values, k, v)
+	}
+
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	// 两条命令操作同一个键，可以在集群模式下以pipeline发送
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, values...)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "保存配置更新的状态时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// GetConfigUpdateStatus 利用hgetall查询配置更新状态的全部field
+func (r *Repo) GetConfigUpdateStatus(ctx context.Context, key string) (map[string]string, error) {
+	ctx, span := startRedisSpan(ctx, "HGETALL", key)
+	fields, err := r.redisClient.HGetAll(ctx, key).Result()
+	if err == nil && len(fields) == 0 {
+		err = errors.New(404, "Repo_Config_NotFound", "配置更新不存在或者其状态已经过期")
+		monitor.EndSpan(span, err)
+		return nil, err
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询配置更新的状态时发生了错误:%v", err)
+	}
+	return fields, nil
+}
@@ -11,12 +11,17 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewRedisData, NewInfluxdbData, NewRepo)
+var ProviderSet = wire.NewSet(
+	NewRedisData, NewInfluxdbData, NewRemoteWriteData, NewConfigWebhookData, NewConfigCipher, NewRepo)
 
 // RedisData 连接redis的客户端
 type RedisData struct {
 	// redis连接客户端
 	*redis.ClusterClient
+	// 连接redis sentinel的客户端，用于健康检查
+	sentinel *redis.SentinelClient
+	// redis sentinel集群使用的master标识名
+	masterName string
 }
 
 // InfluxdbData 连接influxdb的客户端
@@ -29,12 +34,13 @@ type InfluxdbData struct {
 
 // NewRedisData 实例化redis数据库连接对象
 func NewRedisData(c *conf.Data) (*RedisData, func(), error) {
-	data := new(RedisData)
+	data := &RedisData{masterName: c.Redis.MasterName}
+	sentinelAddr := fmt.Sprintf("%s:%d", c.Redis.Host, c.Redis.SentinelPort)
 
 	// 实例化用于连接redis集群的客户端
 	data.ClusterClient = redis.NewFailoverClusterClient(&redis.FailoverOptions{
 		MasterName:            c.Redis.MasterName,
-		SentinelAddrs:         []string{fmt.Sprintf("%s:%d", c.Redis.Host, c.Redis.SentinelPort)},
+		SentinelAddrs:         []string{sentinelAddr},
 		RouteByLatency:        false,
 		RouteRandomly:         false,
 		SlaveOnly:             false,
@@ -44,14 +50,19 @@ func NewRedisData(c *conf.Data) (*RedisData, func(), error) {
 		MinIdleConns:          int(c.Redis.MinIdleConns),
 	})
 
+	data.sentinel = redis.NewSentinelClient(&redis.Options{Addr: sentinelAddr})
+
 	// 检测数据库联机是否成功
 	if err := data.Ping(context.Background()).Err(); err != nil {
+		data.Close()
+		data.sentinel.Close()
 		return nil, nil, err
 	}
 
 	// 用于关闭redis连接池的函数
 	cleanup := func() {
 		data.Close()
+		data.sentinel.Close()
 	}
 
 	return data, cleanup, nil
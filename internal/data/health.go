@@ -0,0 +1,33 @@
+package data
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/errors"
+	"strings"
+)
+
+// CheckRedis 先向redis sentinel查询master的地址，再检查master能否连通
+func (r *Repo) CheckRedis(ctx context.Context) error {
+	addr, err := r.redisClient.sentinel.GetMasterAddrByName(ctx, r.redisClient.masterName).Result()
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Health_Error", "向redis sentinel查询master %s 的地址时发生了错误:%v",
+			r.redisClient.masterName, err)
+	}
+	if err := r.redisClient.Ping(ctx).Err(); err != nil {
+		return errors.Newf(
+			500, "Repo_Health_Error", "连接redis master %s 时发生了错误:%v", strings.Join(addr, ":"), err)
+	}
+	return nil
+}
+
+// CheckInfluxdb 检查influxdb能否连通
+func (r *Repo) CheckInfluxdb(ctx context.Context) error {
+	ping, err := r.influxdbClient.Ping(ctx)
+	if err != nil {
+		return errors.Newf(500, "Repo_Health_Error", "连接influxdb时发生了错误:%v", err)
+	} else if !ping {
+		return errors.New(500, "Repo_Health_Error", "influxdb未就绪")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// httpRetrier 发送http请求并对可恢复的错误按照指数退避进行重试，由webhook客户端以及remote_write客户端共用
+type httpRetrier struct {
+	client     *http.Client
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// do 以newRequest产生的请求进行至多maxRetries+1次尝试，直到请求成功、遇到不可恢复的错误或者ctx被取消，
+// 每次准备重试时以本次尝试的序号(从1开始)以及错误调用onRetry。返回最后一次尝试的错误
+func (r *httpRetrier) do(ctx context.Context,
+	newRequest func(ctx context.Context) (*http.Request, error), onRetry func(attempt int, err error)) error {
+	var (
+		err     error
+		backoff = r.minBackoff
+	)
+	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+		if attempt > 0 {
+			onRetry(attempt, err)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > r.maxBackoff {
+				backoff = r.maxBackoff
+			}
+		}
+
+		var req *http.Request
+		req, err = newRequest(ctx)
+		if err != nil {
+			return err
+		}
+		var recoverable bool
+		recoverable, err = r.post(req)
+		if err == nil {
+			return nil
+		}
+		if !recoverable || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// post 发送一次请求，返回的布尔值表示错误是否可以通过重试恢复
+func (r *httpRetrier) post(req *http.Request) (recoverable bool, err error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		// 网络错误均视作可恢复的错误
+		return true, err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+	err = fmt.Errorf("server returned HTTP status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	// 5xx以及429可以重试，其余错误重试也不会成功
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+var (
+	// acquireLockScript 锁已经由owner持有时刷新过期时间，锁不存在时由owner获得锁，返回owner是否持有锁
+	acquireLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0
+`)
+	// releaseLockScript 仅在锁由owner持有时删除锁
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// AcquireLock 利用lua脚本获得或者续期owner持有的锁
+func (r *Repo) AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", key)
+	acquired, err := acquireLockScript.Run(ctx, r.redisClient, []string{key}, owner, ttl.Milliseconds()).Bool()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return false, errors.Newf(
+			500, "Repo_Config_Error", "获取锁时发生了错误:%v", err)
+	}
+	return acquired, nil
+}
+
+// ReleaseLock 利用lua脚本释放owner持有的锁
+func (r *Repo) ReleaseLock(ctx context.Context, key, owner string) error {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", key)
+	err := releaseLockScript.Run(ctx, r.redisClient, []string{key}, owner).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "释放锁时发生了错误:%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// stream消息中保存消息内容的field
+const streamMsgField = "msg"
+
+// addStreamMsgScript 向stream追加消息并刷新stream的过期时间，之后删除超出长度上限的最旧的消息并返回这些消息。
+// 与XADD的MAXLEN选项不同，被删除的消息会返回给调用方，以便记录这些配置更新的状态
+var addStreamMsgScript = redis.NewScript(`
+local id = redis.call("XADD", KEYS[1], "*", ARGV[1], ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+local excess = redis.call("XLEN", KEYS[1]) - tonumber(ARGV[3])
+local trimmed = {}
+if excess > 0 then
+	trimmed = redis.call("XRANGE", KEYS[1], "-", "+", "COUNT", excess)
+	for _, entry in ipairs(trimmed) do
+		redis.call("XDEL", KEYS[1], entry[1])
+	end
+end
+return {id, trimmed}
+`)
+
+// AddStreamMsg 利用lua脚本向stream追加消息，将stream长度限制在maxLen以内并返回被删除的消息，
+// 同时刷新stream的过期时间，使长时间未追加消息的stream被删除
+func (r *Repo) AddStreamMsg(ctx context.Context,
+	stream, msg string, maxLen int64, ttl time.Duration) (string, []*biz.StreamMsg, error) {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", stream)
+	start := time.Now()
+	result, err := addStreamMsgScript.Run(
+		ctx, r.redisClient, []string{stream}, streamMsgField, msg, maxLen, ttl.Milliseconds()).Slice()
+	monitor.RedisOperationSeconds.WithLabelValues("xadd", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return "", nil, errors.Newf(
+			500, "Repo_Config_Error", "向待确认队列追加配置更新时发生了错误:%v", err)
+	}
+
+	id, _ := result[0].(string)
+	entries, _ := result[1].([]interface{})
+	trimmed := make([]*biz.StreamMsg, 0, len(entries))
+	for _, e := range entries {
+		// 每条消息的格式为[id,[field,value,...]]
+		entry, ok := e.([]interface{})
+		if !ok || len(entry) != 2 {
+			continue
+		}
+		msgID, _ := entry[0].(string)
+		values, _ := entry[1].([]interface{})
+		for i := 0; i+1 < len(values); i += 2 {
+			if values[i] == streamMsgField {
+				value, _ := values[i+1].(string)
+				trimmed = append(trimmed, &biz.StreamMsg{ID: msgID, Value: value})
+			}
+		}
+	}
+	return id, trimmed, nil
+}
+
+// GetStreamMsgs 利用xrange按照从旧到新的顺序查询stream中的全部消息
+func (r *Repo) GetStreamMsgs(ctx context.Context, stream string) ([]*biz.StreamMsg, error) {
+	ctx, span := startRedisSpan(ctx, "XRANGE", stream)
+	values, err := r.redisClient.XRange(ctx, stream, "-", "+").Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询待确认队列中的配置更新时发生了错误:%v", err)
+	}
+
+	msgs := make([]*biz.StreamMsg, 0, len(values))
+	for _, v := range values {
+		if msg, ok := v.Values[streamMsgField].(string); ok {
+			msgs = append(msgs, &biz.StreamMsg{ID: v.ID, Value: msg})
+		}
+	}
+	return msgs, nil
+}
+
+// DeleteStreamMsgs 利用xdel删除stream中指定id的消息
+func (r *Repo) DeleteStreamMsgs(ctx context.Context, stream string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, span := startRedisSpan(ctx, "XDEL", stream)
+	err := r.redisClient.XDel(ctx, stream, ids...).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除待确认队列中的配置更新时发生了错误:%v", err)
+	}
+	return nil
+}
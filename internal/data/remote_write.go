@@ -0,0 +1,344 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// remote_write协议版本号
+	remoteWriteVersion = "0.1.0"
+	// remote_write各项配置的缺省值
+	defaultRemoteWriteTimeout       = 30 * time.Second
+	defaultRemoteWriteMaxRetries    = 3
+	defaultRemoteWriteMinBackoff    = 30 * time.Millisecond
+	defaultRemoteWriteMaxBackoff    = 5 * time.Second
+	defaultRemoteWriteMaxSamples    = 500
+	defaultRemoteWriteSendDeadline  = 5 * time.Second
+	defaultRemoteWriteCapacity      = 10000
+	defaultRemoteWriteDrainTimeout  = 10 * time.Second
+	defaultRemoteWriteMetricPrefix  = "device_state"
+	remoteWriteDeviceIDLabelName    = "deviceID"
+	remoteWriteUsernameLabelName    = "user"
+	remoteWriteMetricNameLabelName  = "__name__"
+	remoteWriteInvalidLabelNameChar = '_'
+)
+
+// RemoteWriteData 以prometheus remote_write协议输出设备状态的客户端，
+// 设备状态的每个预警字段转换为一个样本，以设备类别号、设备id以及tag作为样本的标签
+type RemoteWriteData struct {
+	retrier *httpRetrier
+	url     string
+	token   string
+	prefix  string
+	// 批量发送相关的配置
+	maxSamples   int
+	sendDeadline time.Duration
+	// 关闭时等待剩余样本发送完毕的最长时间
+	drainTimeout time.Duration
+	// 等待发送的样本队列
+	queue chan remoteWriteSeries
+	done  chan struct{}
+	// 发送请求使用的ctx，等待剩余样本发送超时后被取消
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+	logger *log.Helper
+}
+
+// remoteWriteLabel remote_write中的样本标签
+type remoteWriteLabel struct {
+	name, value string
+}
+
+// remoteWriteSeries 仅包含一个样本的时间序列
+type remoteWriteSeries struct {
+	labels    []remoteWriteLabel
+	value     float64
+	timestamp int64
+}
+
+// NewRemoteWriteData 实例化remote_write客户端，未配置接收端地址时返回nil，表示不启用remote_write输出
+func NewRemoteWriteData(c *conf.Data, logger log.Logger) (*RemoteWriteData, func(), error) {
+	rw := c.RemoteWrite
+	if rw == nil || rw.Url == "" {
+		return nil, func() {}, nil
+	}
+
+	retrier := &httpRetrier{
+		maxRetries: int(rw.MaxRetries),
+		minBackoff: rw.MinBackoff.AsDuration(),
+		maxBackoff: rw.MaxBackoff.AsDuration(),
+	}
+	data := &RemoteWriteData{
+		retrier:      retrier,
+		url:          rw.Url,
+		token:        rw.BearerToken,
+		prefix:       rw.MetricPrefix,
+		maxSamples:   int(rw.MaxSamplesPerSend),
+		sendDeadline: rw.BatchSendDeadline.AsDuration(),
+		drainTimeout: rw.DrainTimeout.AsDuration(),
+		done:         make(chan struct{}),
+		logger:       log.NewHelper(logger),
+	}
+	timeout := rw.Timeout.AsDuration()
+	if timeout <= 0 {
+		timeout = defaultRemoteWriteTimeout
+	}
+	retrier.client = &http.Client{Timeout: timeout}
+	if data.prefix == "" {
+		data.prefix = defaultRemoteWriteMetricPrefix
+	}
+	if retrier.maxRetries <= 0 {
+		retrier.maxRetries = defaultRemoteWriteMaxRetries
+	}
+	if retrier.minBackoff <= 0 {
+		retrier.minBackoff = defaultRemoteWriteMinBackoff
+	}
+	if retrier.maxBackoff < retrier.minBackoff {
+		retrier.maxBackoff = defaultRemoteWriteMaxBackoff
+	}
+	if data.maxSamples <= 0 {
+		data.maxSamples = defaultRemoteWriteMaxSamples
+	}
+	if data.sendDeadline <= 0 {
+		data.sendDeadline = defaultRemoteWriteSendDeadline
+	}
+	if data.drainTimeout <= 0 {
+		data.drainTimeout = defaultRemoteWriteDrainTimeout
+	}
+	capacity := int(rw.Capacity)
+	if capacity <= 0 {
+		capacity = defaultRemoteWriteCapacity
+	}
+	data.queue = make(chan remoteWriteSeries, capacity)
+
+	data.ctx, data.cancel = context.WithCancel(context.Background())
+	go data.run()
+
+	// 关闭队列后等待剩余样本发送完毕，超过drainTimeout时取消正在发送的请求并丢弃剩余样本
+	cleanup := func() {
+		data.once.Do(func() {
+			close(data.queue)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), data.drainTimeout)
+		defer cancel()
+		select {
+		case <-data.done:
+		case <-ctx.Done():
+			data.logger.Warnf("等待remote_write发送剩余的设备状态样本超过了%s，剩余样本被丢弃", data.drainTimeout)
+			data.cancel()
+			<-data.done
+		}
+		data.cancel()
+	}
+
+	return data, cleanup, nil
+}
+
+// Append 将设备状态的预警字段转换为样本并放入发送队列，队列已满时丢弃样本
+func (d *RemoteWriteData) Append(measurement *biz.DeviceStateMeasurement) {
+	// 由于measurement中的map会被调用方复用，这里需要在返回前完成样本的转换
+	labels := make([]remoteWriteLabel, 0, len(measurement.Tags)+3)
+	labels = append(labels,
+		remoteWriteLabel{name: remoteWriteUsernameLabelName, value: conf.Username},
+		remoteWriteLabel{name: remoteWriteDeviceIDLabelName, value: measurement.Name},
+	)
+	for k, v := range measurement.Tags {
+		name := sanitizeLabelName(k)
+		// tag不能覆盖用户、设备id以及指标名这些保留的标签，否则样本会被归属到错误的用户或设备
+		if isReservedLabelName(name) {
+			d.logger.Warnf("设备 %s 的tag %s 与remote_write的保留标签冲突，已被忽略", measurement.Name, k)
+			continue
+		}
+		labels = append(labels, remoteWriteLabel{name: name, value: v})
+	}
+
+	timestamp := measurement.Time.UnixNano() / int64(time.Millisecond)
+	for field, value := range measurement.Fields {
+		series := remoteWriteSeries{
+			labels: append(
+				[]remoteWriteLabel{{
+					name:  remoteWriteMetricNameLabelName,
+					value: sanitizeLabelName(fmt.Sprintf("%s_%s", d.prefix, field)),
+				}},
+				labels...,
+			),
+			value:     value,
+			timestamp: timestamp,
+		}
+		// remote_write要求标签按照标签名排序
+		sort.Slice(series.labels, func(i, j int) bool {
+			return series.labels[i].name < series.labels[j].name
+		})
+
+		select {
+		case d.queue <- series:
+		default:
+			d.logger.Warnf("remote_write发送队列已满，丢弃了设备 %s 的字段 %s 的样本", measurement.Name, field)
+		}
+	}
+}
+
+// run 不断从队列中取出样本，在样本数达到上限或等待超时时批量发送，ctx被取消后丢弃队列中剩余的样本
+func (d *RemoteWriteData) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.sendDeadline)
+	defer ticker.Stop()
+
+	batch := make([]remoteWriteSeries, 0, d.maxSamples)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if d.ctx.Err() != nil {
+			batch = batch[:0]
+			return
+		}
+		if err := d.send(batch); err != nil {
+			d.logger.Error(err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case series, ok := <-d.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, series)
+			if len(batch) >= d.maxSamples {
+				flush()
+				ticker.Reset(d.sendDeadline)
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send 发送一批样本，对可恢复的错误按照指数退避进行重试，ctx被取消时停止重试
+func (d *RemoteWriteData) send(batch []remoteWriteSeries) error {
+	body := snappy.Encode(nil, encodeWriteRequest(batch))
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Encoding", "snappy")
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+		if d.token != "" {
+			req.Header.Set("Authorization", "Bearer "+d.token)
+		}
+		return req, nil
+	}
+
+	err := d.retrier.do(d.ctx, newRequest, func(attempt int, err error) {
+		d.logger.Warnf("remote_write第%d次写入失败，准备重试:%v", attempt, err)
+	})
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_State_Error", "以remote_write协议输出%d个设备状态样本时发生了错误:%v", len(batch), err)
+	}
+	return nil
+}
+
+// encodeWriteRequest 将样本编码为prometheus.WriteRequest的protobuf二进制信息，
+// 标签相同的样本合并到同一时间序列中
+func encodeWriteRequest(batch []remoteWriteSeries) []byte {
+	var (
+		keys   []string
+		series = make(map[string][]remoteWriteSeries)
+	)
+	for _, s := range batch {
+		var key strings.Builder
+		for _, l := range s.labels {
+			key.WriteString(l.name)
+			key.WriteByte(0xff)
+			key.WriteString(l.value)
+			key.WriteByte(0xff)
+		}
+		k := key.String()
+		if _, ok := series[k]; !ok {
+			keys = append(keys, k)
+		}
+		series[k] = append(series[k], s)
+	}
+
+	var buf []byte
+	for _, k := range keys {
+		samples := series[k]
+		sort.SliceStable(samples, func(i, j int) bool {
+			return samples[i].timestamp < samples[j].timestamp
+		})
+
+		// TimeSeries: labels = 1, samples = 2
+		var ts []byte
+		for _, l := range samples[0].labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, l.name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, l.value)
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, label)
+		}
+		for _, s := range samples {
+			// Sample: value = 1, timestamp = 2
+			var sample []byte
+			sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+			sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+			sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+			sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+			ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, sample)
+		}
+
+		// WriteRequest: timeseries = 1
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, ts)
+	}
+	return buf
+}
+
+// isReservedLabelName 判断标签名是否为remote_write客户端自行设置的保留标签，
+// prometheus中以__开头的标签名同样被保留
+func isReservedLabelName(name string) bool {
+	switch name {
+	case remoteWriteUsernameLabelName, remoteWriteDeviceIDLabelName, remoteWriteMetricNameLabelName:
+		return true
+	default:
+		return strings.HasPrefix(name, "__")
+	}
+}
+
+// sanitizeLabelName 将不符合prometheus标签名规范([a-zA-Z_][a-zA-Z0-9_]*)的字符替换为下划线
+func sanitizeLabelName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		b[i] = remoteWriteInvalidLabelNameChar
+	}
+	return string(b)
+}
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"gitee.com/moyusir/data-collection/internal/biz"
 	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-redis/redis/v8"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 )
 
@@ -15,31 +20,143 @@ import (
 type Repo struct {
 	redisClient    *RedisData
 	influxdbClient *InfluxdbData
-	logger         *log.Helper
+	// 未启用remote_write输出时为nil
+	remoteWriteClient *RemoteWriteData
+	// 未配置webhook时为nil
+	configWebhookClient *ConfigWebhookData
+	// 未启用设备配置加密时为nil
+	configCipher *ConfigCipher
+	logger       *log.Helper
 }
 
 // NewRepo 实例化redis数据库操作对象
-func NewRepo(redisData *RedisData, influxdbData *InfluxdbData, logger log.Logger) biz.UnionRepo {
+func NewRepo(
+	redisData *RedisData,
+	influxdbData *InfluxdbData,
+	remoteWriteData *RemoteWriteData,
+	configWebhookData *ConfigWebhookData,
+	configCipher *ConfigCipher,
+	logger log.Logger) biz.UnionRepo {
 	return &Repo{
-		redisClient:    redisData,
-		influxdbClient: influxdbData,
-		logger:         log.NewHelper(logger),
+		redisClient:         redisData,
+		influxdbClient:      influxdbData,
+		remoteWriteClient:   remoteWriteData,
+		configWebhookClient: configWebhookData,
+		configCipher:        configCipher,
+		logger:              log.NewHelper(logger),
 	}
 }
 
+// EncodeConfig 将设备配置编码为十六进制字符串，启用加密时编码为加密后的字符串
+func (r *Repo) EncodeConfig(config []byte, key, field string) (string, error) {
+	return r.configCipher.Encode(config, key, field)
+}
+
+// DecodeConfig 将EncodeConfig编码得到的字符串还原为设备配置
+func (r *Repo) DecodeConfig(v, key, field string) ([]byte, error) {
+	return r.configCipher.Decode(v, key, field)
+}
+
 // SaveDeviceConfig 保存设备配置到redis的hash中
-func (r *Repo) SaveDeviceConfig(key, field string, value []byte) error {
-	// 这里将value转换为十六进制的字符串进行保存
-	v := fmt.Sprintf("%x", value)
-	if err := r.redisClient.HSet(context.Background(), key, field, v).Err(); err != nil {
+func (r *Repo) SaveDeviceConfig(ctx context.Context, key, field string, value []byte) error {
+	// 这里将value转换为十六进制的字符串进行保存，启用加密时保存的是加密后的字符串
+	v, err := r.configCipher.Encode(value, key, field)
+	if err != nil {
+		return err
+	}
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	start := time.Now()
+	err = r.redisClient.HSet(ctx, key, field, v).Err()
+	monitor.RedisOperationSeconds.WithLabelValues("hset", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
 		return errors.Newf(
 			500, "Repo_Config_Error", "设备配置保存时发生了错误:%v", err)
 	}
 	return nil
 }
 
+// GetDeviceConfig 查询redis hash中保存的设备配置，返回其protobuf二进制信息
+func (r *Repo) GetDeviceConfig(ctx context.Context, key, field string) ([]byte, error) {
+	ctx, span := startRedisSpan(ctx, "HGET", key)
+	v, err := r.redisClient.HGet(ctx, key, field).Result()
+	if err == redis.Nil {
+		err = errors.Newf(404, "Repo_Config_NotFound", "设备 %s 的配置不存在", field)
+		monitor.EndSpan(span, err)
+		return nil, err
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询设备配置时发生了错误:%v", err)
+	}
+	return r.configCipher.Decode(v, key, field)
+}
+
+// ListDeviceConfigs 利用hscan分页遍历redis hash中保存的设备配置，返回本页的设备配置以及下一页的游标，
+// 游标为0时表示遍历完毕
+func (r *Repo) ListDeviceConfigs(ctx context.Context, key string, cursor uint64, count int64) ([][]byte, uint64, error) {
+	ctx, span := startRedisSpan(ctx, "HSCAN", key)
+	kvs, next, err := r.redisClient.HScan(ctx, key, cursor, "", count).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, 0, errors.Newf(
+			500, "Repo_Config_Error", "遍历设备配置时发生了错误:%v", err)
+	}
+
+	// hscan返回的结果为field与value交替排列的切片
+	configs := make([][]byte, 0, len(kvs)/2)
+	for i := 1; i < len(kvs); i += 2 {
+		config, err := r.configCipher.Decode(kvs[i], key, kvs[i-1])
+		if err != nil {
+			return nil, 0, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, next, nil
+}
+
+// BatchGetDeviceConfigs 批量查询redis hash中保存的设备配置，不存在的设备配置以nil表示
+func (r *Repo) BatchGetDeviceConfigs(ctx context.Context, key string, fields ...string) ([][]byte, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	ctx, span := startRedisSpan(ctx, "HMGET", key)
+	values, err := r.redisClient.HMGet(ctx, key, fields...).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "批量查询设备配置时发生了错误:%v", err)
+	}
+
+	configs := make([][]byte, len(values))
+	for i, v := range values {
+		if str, ok := v.(string); ok {
+			configs[i], err = r.configCipher.Decode(str, key, fields[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return configs, nil
+}
+
+// DeleteDeviceConfig 利用hdel删除redis hash中保存的设备配置
+func (r *Repo) DeleteDeviceConfig(ctx context.Context, key, field string) error {
+	ctx, span := startRedisSpan(ctx, "HDEL", key)
+	err := r.redisClient.HDel(ctx, key, field).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除设备配置时发生了错误:%v", err)
+	}
+	return nil
+}
+
 // SaveDeviceState 保存设备状态的measurement
-func (r *Repo) SaveDeviceState(measurement *biz.DeviceStateMeasurement) error {
+func (r *Repo) SaveDeviceState(ctx context.Context, measurement *biz.DeviceStateMeasurement) error {
 
 	// 设备的预警字段信息以influxdb measurement的形式，保存到用户id相应的bucket以及设备id相应的measurement
 	// 中，并以tag deviceClassID区分设备类别，各个字段的信息以field的形式保存在measurement的field中，
@@ -55,14 +172,33 @@ func (r *Repo) SaveDeviceState(measurement *biz.DeviceStateMeasurement) error {
 	}
 	point.SortFields().SortTags()
 
-	err := writeAPI.WritePoint(context.Background(), point)
+	ctx, span := monitor.StartSpan(ctx, "influxdb.WritePoint",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String("influxdb"),
+			semconv.DBNameKey.String(conf.Username),
+			attribute.String("db.influxdb.measurement", measurement.Name),
+		),
+	)
+	start := time.Now()
+	err := writeAPI.WritePoint(ctx, point)
+	monitor.InfluxdbWriteSeconds.WithLabelValues(monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
 	if err != nil {
 		return errors.Newf(
 			500, "Repo_State_Error", "设备状态保存时发生了错误:%v", err)
 	}
 
+	// 写入influxdb成功后，再以remote_write协议输出设备状态，未启用时remoteWriteClient为nil
+	if r.remoteWriteClient != nil {
+		r.remoteWriteClient.Append(measurement)
+	}
+
 	{
 		now := time.Now().UTC()
+		monitor.IngestionLagSeconds.WithLabelValues(measurement.Tags["deviceClassID"]).
+			Observe(now.Sub(measurement.Time.UTC()).Seconds())
 		r.logger.Debugf(
 			"与时间:%s保存了时间信息为:%s的设备状态信息,时间差为:%s",
 			now.Format(time.RFC3339), measurement.Time.UTC().Format(time.RFC3339),
@@ -75,6 +211,12 @@ func (r *Repo) SaveDeviceState(measurement *biz.DeviceStateMeasurement) error {
 
 func (r *Repo) GetMsgChannel(ctx context.Context, name string) (msgChan <-chan string, err error) {
 	subscribe := r.redisClient.Subscribe(ctx, name)
+	// 等待订阅生效，保证返回后发布到该频道的消息不会丢失
+	if _, err := subscribe.Receive(ctx); err != nil {
+		subscribe.Close()
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "订阅频道 %v 时发生了错误:%v", name, err)
+	}
 	r.logger.Infof("订阅了频道:%v", name)
 	messages := subscribe.Channel()
 	messageChan := make(chan string, 100)
@@ -99,10 +241,12 @@ func (r *Repo) GetMsgChannel(ctx context.Context, name string) (msgChan <-chan s
 				}
 				if msg.Payload != "" {
 					messageChan <- msg.Payload
+					monitor.PubSubDelivered.Inc()
 				}
 				for _, m := range msg.PayloadSlice {
 					if m != "" {
 						messageChan <- m
+						monitor.PubSubDelivered.Inc()
 					}
 				}
 			}
@@ -112,19 +256,30 @@ func (r *Repo) GetMsgChannel(ctx context.Context, name string) (msgChan <-chan s
 	return messageChan, nil
 }
 
-func (r *Repo) PublishMsg(channel string, message ...string) error {
+func (r *Repo) PublishMsg(ctx context.Context, channel string, message ...string) error {
 	for _, msg := range message {
-		err := r.redisClient.Publish(context.Background(), channel, msg).Err()
+		ctx, span := startRedisSpan(ctx, "PUBLISH", channel)
+		start := time.Now()
+		err := r.redisClient.Publish(ctx, channel, msg).Err()
+		monitor.RedisOperationSeconds.WithLabelValues("publish", monitor.Result(err)).
+			Observe(time.Since(start).Seconds())
+		monitor.EndSpan(span, err)
 		if err != nil {
 			return errors.Newf(
 				500, "Repo_Config_Error", "发布消息时发生了错误:%v", err)
 		}
+		monitor.PubSubPublished.Inc()
 	}
 	return nil
 }
 
-func (r *Repo) AddFieldValuePair(key, field, value string) error {
-	err := r.redisClient.HSet(context.Background(), key, field, value).Err()
+func (r *Repo) AddFieldValuePair(ctx context.Context, key, field, value string) error {
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	start := time.Now()
+	err := r.redisClient.HSet(ctx, key, field, value).Err()
+	monitor.RedisOperationSeconds.WithLabelValues("hset", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
 	if err != nil {
 		return errors.Newf(
 			500, "Repo_Config_Error", "保存hash键值对时发生了错误:%v", err)
@@ -133,8 +288,55 @@ func (r *Repo) AddFieldValuePair(key, field, value string) error {
 	return nil
 }
 
-func (r *Repo) GetValueOfField(key, field string) (value string, err error) {
-	value, err = r.redisClient.HGet(context.Background(), key, field).Result()
+// AddSetMember 利用sadd向set添加成员
+func (r *Repo) AddSetMember(ctx context.Context, key, member string) error {
+	ctx, span := startRedisSpan(ctx, "SADD", key)
+	start := time.Now()
+	err := r.redisClient.SAdd(ctx, key, member).Err()
+	monitor.RedisOperationSeconds.WithLabelValues("sadd", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "向set添加成员时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// RemoveSetMember 利用srem删除set中的成员
+func (r *Repo) RemoveSetMember(ctx context.Context, key, member string) error {
+	ctx, span := startRedisSpan(ctx, "SREM", key)
+	start := time.Now()
+	err := r.redisClient.SRem(ctx, key, member).Err()
+	monitor.RedisOperationSeconds.WithLabelValues("srem", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除set中的成员时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// GetSetMembers 利用smembers查询set中的全部成员
+func (r *Repo) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	ctx, span := startRedisSpan(ctx, "SMEMBERS", key)
+	start := time.Now()
+	members, err := r.redisClient.SMembers(ctx, key).Result()
+	monitor.RedisOperationSeconds.WithLabelValues("smembers", monitor.Result(err)).
+		Observe(time.Since(start).Seconds())
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询set中的成员时发生了错误:%v", err)
+	}
+	return members, nil
+}
+
+func (r *Repo) GetValueOfField(ctx context.Context, key, field string) (value string, err error) {
+	ctx, span := startRedisSpan(ctx, "HGET", key)
+	value, err = r.redisClient.HGet(ctx, key, field).Result()
+	monitor.EndSpan(span, err)
 	if err != nil {
 		return "", errors.Newf(
 			500, "Repo_Config_Error", "查询hash键值对时发生了错误:%v", err)
@@ -143,10 +345,28 @@ func (r *Repo) GetValueOfField(key, field string) (value string, err error) {
 	return
 }
 
+// LookupValueOfField 查询hash中field的值，field不存在时返回false
+func (r *Repo) LookupValueOfField(ctx context.Context, key, field string) (string, bool, error) {
+	ctx, span := startRedisSpan(ctx, "HGET", key)
+	value, err := r.redisClient.HGet(ctx, key, field).Result()
+	if err == redis.Nil {
+		monitor.EndSpan(span, nil)
+		return "", false, nil
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return "", false, errors.Newf(
+			500, "Repo_Config_Error", "查询hash键值对时发生了错误:%v", err)
+	}
+	return value, true, nil
+}
+
 // CreateClientID 利用redis的自增函数产生分布式全局唯一的clientID
-func (r *Repo) CreateClientID() (string, error) {
+func (r *Repo) CreateClientID(ctx context.Context) (string, error) {
+	ctx, span := startRedisSpan(ctx, "HINCRBY", "clientID")
 	result, err := r.redisClient.HIncrBy(
-		context.Background(), "clientID", conf.Username, 1).Result()
+		ctx, "clientID", conf.Username, 1).Result()
+	monitor.EndSpan(span, err)
 	if err != nil {
 		return "", errors.Newf(
 			500, "Repo_Config_Error", "创建clientID时发生了错误:%v", err)
@@ -154,3 +374,15 @@ func (r *Repo) CreateClientID() (string, error) {
 
 	return fmt.Sprintf("%s_%d", conf.Username, result), nil
 }
+
+// startRedisSpan 开始一个redis命令相应的span
+func startRedisSpan(ctx context.Context, command, key string) (context.Context, trace.Span) {
+	return monitor.StartSpan(ctx, "redis."+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationKey.String(command),
+			attribute.String("db.redis.key", key),
+		),
+	)
+}
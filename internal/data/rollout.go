@@ -0,0 +1,105 @@
+package data
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// deleteFieldIfEqualScript 仅在hash中field的值等于期望值时删除field
+var deleteFieldIfEqualScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// SaveRollout 利用hset保存灰度发布中的各个field，ttl大于0时设置hash的过期时间
+func (r *Repo) SaveRollout(ctx context.Context, key string, fields map[string]string, ttl time.Duration) error {
+	values := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		values = append(values, k, v)
+	}
+
+	ctx, span := startRedisSpan(ctx, "HSET", key)
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, values...)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "保存灰度发布时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// GetRollout 利用hgetall查询灰度发布的全部field
+func (r *Repo) GetRollout(ctx context.Context, key string) (map[string]string, error) {
+	ctx, span := startRedisSpan(ctx, "HGETALL", key)
+	fields, err := r.redisClient.HGetAll(ctx, key).Result()
+	if err == nil && len(fields) == 0 {
+		err = errors.New(404, "Repo_Config_NotFound", "灰度发布不存在或者已经过期")
+		monitor.EndSpan(span, err)
+		return nil, err
+	}
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询灰度发布时发生了错误:%v", err)
+	}
+	return fields, nil
+}
+
+// DeleteRolloutField 利用lua脚本在灰度发布中field的值等于value时删除field
+func (r *Repo) DeleteRolloutField(ctx context.Context, key, field, value string) error {
+	ctx, span := startRedisSpan(ctx, "EVALSHA", key)
+	err := deleteFieldIfEqualScript.Run(ctx, r.redisClient, []string{key}, field, value).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除灰度发布的%s时发生了错误:%v", field, err)
+	}
+	return nil
+}
+
+// AddActiveRollout 利用sadd记录尚未结束的灰度发布
+func (r *Repo) AddActiveRollout(ctx context.Context, key, rolloutID string) error {
+	ctx, span := startRedisSpan(ctx, "SADD", key)
+	err := r.redisClient.SAdd(ctx, key, rolloutID).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "记录尚未结束的灰度发布时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// RemoveActiveRollout 利用srem删除已经结束的灰度发布
+func (r *Repo) RemoveActiveRollout(ctx context.Context, key, rolloutID string) error {
+	ctx, span := startRedisSpan(ctx, "SREM", key)
+	err := r.redisClient.SRem(ctx, key, rolloutID).Err()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return errors.Newf(
+			500, "Repo_Config_Error", "删除已经结束的灰度发布时发生了错误:%v", err)
+	}
+	return nil
+}
+
+// ListActiveRollouts 利用smembers查询尚未结束的灰度发布
+func (r *Repo) ListActiveRollouts(ctx context.Context, key string) ([]string, error) {
+	ctx, span := startRedisSpan(ctx, "SMEMBERS", key)
+	ids, err := r.redisClient.SMembers(ctx, key).Result()
+	monitor.EndSpan(span, err)
+	if err != nil {
+		return nil, errors.Newf(
+			500, "Repo_Config_Error", "查询尚未结束的灰度发布时发生了错误:%v", err)
+	}
+	return ids, nil
+}
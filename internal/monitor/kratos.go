@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ metrics.Counter  = (*counter)(nil)
+	_ metrics.Observer = (*observer)(nil)
+)
+
+// counter 将prometheus CounterVec适配为kratos metrics中间件使用的Counter
+type counter struct {
+	cv  *prometheus.CounterVec
+	lvs []string
+}
+
+// NewCounter 适配prometheus CounterVec
+func NewCounter(cv *prometheus.CounterVec) metrics.Counter {
+	return &counter{cv: cv}
+}
+
+func (c *counter) With(lvs ...string) metrics.Counter {
+	return &counter{cv: c.cv, lvs: lvs}
+}
+
+func (c *counter) Inc() {
+	c.cv.WithLabelValues(c.lvs...).Inc()
+}
+
+func (c *counter) Add(delta float64) {
+	c.cv.WithLabelValues(c.lvs...).Add(delta)
+}
+
+// observer 将prometheus HistogramVec适配为kratos metrics中间件使用的Observer
+type observer struct {
+	hv  *prometheus.HistogramVec
+	lvs []string
+}
+
+// NewObserver 适配prometheus HistogramVec
+func NewObserver(hv *prometheus.HistogramVec) metrics.Observer {
+	return &observer{hv: hv}
+}
+
+func (o *observer) With(lvs ...string) metrics.Observer {
+	return &observer{hv: o.hv, lvs: lvs}
+}
+
+func (o *observer) Observe(value float64) {
+	o.hv.WithLabelValues(o.lvs...).Observe(value)
+}
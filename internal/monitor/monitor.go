@@ -0,0 +1,203 @@
+package monitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 服务自身监控指标的命名空间
+const namespace = "data_collection"
+
+// 流式rpc的消息处理结果，作为MessagesTotal的result标签值
+const (
+	// ResultReceived 接收到客户端发送的消息
+	ResultReceived = "received"
+	// ResultAcked 消息处理成功，或客户端确认接收了配置更新消息
+	ResultAcked = "acked"
+	// ResultRejected 消息处理失败，或客户端拒绝了配置更新消息
+	ResultRejected = "rejected"
+)
+
+var (
+	// ServerRequests 通过kratos metrics中间件统计的请求数
+	ServerRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "requests_code_total",
+		Help:      "按照响应码统计的请求总数",
+	}, []string{"kind", "operation", "code", "reason"})
+	// ServerRequestSeconds 通过kratos metrics中间件统计的请求耗时
+	ServerRequestSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "requests_seconds",
+		Help:      "请求处理的耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "operation"})
+
+	// ActiveStreams 当前处于活跃状态的流式rpc数量
+	ActiveStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "stream",
+		Name:      "active",
+		Help:      "当前处于活跃状态的流式rpc数量",
+	}, []string{"rpc", "device_class_id"})
+	// StreamMessages 流式rpc中各类处理结果的消息数
+	StreamMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "stream",
+		Name:      "messages_total",
+		Help:      "流式rpc中接收、确认以及拒绝的消息总数",
+	}, []string{"rpc", "device_class_id", "result"})
+	// ConfigUpdateResends 配置更新消息被客户端拒绝后的重发次数
+	ConfigUpdateResends = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config_update",
+		Name:      "resends_total",
+		Help:      "配置更新消息的重发总数",
+	}, []string{"device_class_id"})
+	// ConfigUpdateFailures 达到最大发送次数后仍被客户端拒绝的配置更新数
+	ConfigUpdateFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config_update",
+		Name:      "failures_total",
+		Help:      "达到最大发送次数后仍被拒绝的配置更新总数",
+	}, []string{"device_class_id"})
+	// InitialConfigConflicts 设备上传的初始配置与desired配置冲突的次数，winner为生效的一方
+	InitialConfigConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "initial_conflicts_total",
+		Help:      "设备上传的初始配置与desired配置冲突的总次数",
+	}, []string{"device_class_id", "winner"})
+	// ConfigTemplatesApplied 以设备类别的配置模板作为首次出现的设备的desired配置并下发的次数
+	ConfigTemplatesApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "templates_applied_total",
+		Help:      "向首次出现的设备下发配置模板的总次数",
+	}, []string{"device_class_id"})
+	// ConfigUpdatesSuperseded 下发前被同一设备序号更大的配置更新取代的配置更新数
+	ConfigUpdatesSuperseded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config_update",
+		Name:      "superseded_total",
+		Help:      "被同一设备更新的配置更新取代而不再下发的配置更新总数",
+	}, []string{"device_class_id"})
+
+	// ConfigUpdatesExpired 超过截止时间仍未下发给设备而被丢弃的配置更新数
+	ConfigUpdatesExpired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config_update",
+		Name:      "expired_total",
+		Help:      "超过截止时间仍未下发给设备而被丢弃的配置更新总数",
+	}, []string{"device_class_id"})
+	// ConfigChangeWebhooks 向webhook发送配置变更事件的次数
+	ConfigChangeWebhooks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config_change",
+		Name:      "webhooks_total",
+		Help:      "向webhook发送配置变更事件的总次数",
+	}, []string{"result"})
+
+	// InfluxdbWriteSeconds 向influxdb写入设备状态的耗时
+	InfluxdbWriteSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "influxdb",
+		Name:      "write_seconds",
+		Help:      "向influxdb写入设备状态的耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+	// RedisOperationSeconds redis写操作的耗时
+	RedisOperationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "operation_seconds",
+		Help:      "redis写操作的耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+	// IngestionLagSeconds 设备状态信息携带的时间与其保存完毕的时间之差
+	IngestionLagSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "ingestion",
+		Name:      "lag_seconds",
+		Help:      "设备状态从产生到保存完毕的端到端延迟",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"device_class_id"})
+
+	// PubSubPublished 发布到redis频道的消息数
+	PubSubPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pubsub",
+		Name:      "messages_published_total",
+		Help:      "发布到redis频道的消息总数",
+	})
+	// PubSubDelivered 从redis订阅中接收并转交给订阅方的消息数
+	PubSubDelivered = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pubsub",
+		Name:      "messages_delivered_total",
+		Help:      "从redis订阅中接收并转交给订阅方的消息总数",
+	})
+	// PendingUpdatesReplayed 客户端重新连接时从待确认队列中重发的配置更新数
+	PendingUpdatesReplayed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pubsub",
+		Name:      "pending_updates_replayed_total",
+		Help:      "客户端重新连接时从待确认队列中重发的配置更新总数",
+	})
+	// PendingUpdatesExpired 因超过有效期而未重发的配置更新数
+	PendingUpdatesExpired = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pubsub",
+		Name:      "pending_updates_expired_total",
+		Help:      "因超过有效期而未重发的配置更新总数",
+	})
+	// PendingUpdatesDropped 因待确认队列超出长度上限而被丢弃的配置更新数
+	PendingUpdatesDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pubsub",
+		Name:      "pending_updates_dropped_total",
+		Help:      "因待确认队列超出长度上限而被丢弃的配置更新总数",
+	})
+
+	// DependencyUp 依赖组件最近一次健康检查的结果，1为可用，0为不可用
+	DependencyUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "dependency",
+		Name:      "up",
+		Help:      "依赖组件最近一次健康检查的结果",
+	}, []string{"dependency"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		ServerRequests,
+		ServerRequestSeconds,
+		ActiveStreams,
+		StreamMessages,
+		ConfigUpdateResends,
+		ConfigUpdateFailures,
+		InitialConfigConflicts,
+		ConfigTemplatesApplied,
+		ConfigUpdatesSuperseded,
+		ConfigUpdatesExpired,
+		ConfigChangeWebhooks,
+		InfluxdbWriteSeconds,
+		RedisOperationSeconds,
+		IngestionLagSeconds,
+		PubSubPublished,
+		PubSubDelivered,
+		PendingUpdatesReplayed,
+		PendingUpdatesExpired,
+		PendingUpdatesDropped,
+		DependencyUp,
+	)
+}
+
+// Result 将操作的错误转换为result标签值
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
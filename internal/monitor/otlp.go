@@ -0,0 +1,150 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// otlp导出请求的缺省超时时间
+const defaultOTLPTimeout = 10 * time.Second
+
+// otlp导出方式下可选的协议
+const (
+	OTLPProtocolHTTP = "http/protobuf"
+	OTLPProtocolGRPC = "grpc"
+)
+
+// NewOTLPExporter 按照配置创建以OTLP/HTTP或者OTLP/gRPC协议发送span的导出器，
+// 接收端地址的scheme为http时以明文连接接收端，否则使用TLS
+func NewOTLPExporter(ctx context.Context, c *conf.Trace) (sdktrace.SpanExporter, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || u.Host == "" {
+		return nil, errors.Newf(500, "Monitor_Trace_Error", "otlp接收端的地址 %s 不合法", c.Endpoint)
+	}
+	timeout := c.Timeout.AsDuration()
+	if timeout <= 0 {
+		timeout = defaultOTLPTimeout
+	}
+
+	var exporter *otlptrace.Exporter
+	switch c.Protocol {
+	case "", OTLPProtocolHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(u.Host),
+			otlptracehttp.WithHeaders(c.Headers),
+			otlptracehttp.WithTimeout(timeout),
+		}
+		if u.Path != "" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case OTLPProtocolGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(u.Host),
+			otlptracegrpc.WithHeaders(c.Headers),
+			otlptracegrpc.WithTimeout(timeout),
+		}
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, errors.Newf(500, "Monitor_Trace_Error", "不支持的otlp协议:%s", c.Protocol)
+	}
+	if err != nil {
+		return nil, errors.Newf(500, "Monitor_Trace_Error", "创建otlp导出器时发生了错误:%v", err)
+	}
+	return exporter, nil
+}
+
+// NewOTLPWriterExporter 创建将span以每行一个OTLP JSON格式的ExportTraceServiceRequest的形式写入w的导出器，
+// 输出的格式可以直接被otel collector的otlpjsonfile receiver读取
+func NewOTLPWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	exporter, err := otlptrace.New(context.Background(), &otlpWriterClient{w: w})
+	if err != nil {
+		return nil, errors.Newf(500, "Monitor_Trace_Error", "创建otlp导出器时发生了错误:%v", err)
+	}
+	return exporter, nil
+}
+
+// otlpWriterClient 以OTLP JSON格式将span写入w的otlptrace.Client
+type otlpWriterClient struct {
+	w io.Writer
+	// 保证写入的各行不会交错
+	lock sync.Mutex
+}
+
+func (c *otlpWriterClient) Start(context.Context) error {
+	return nil
+}
+
+func (c *otlpWriterClient) Stop(context.Context) error {
+	return nil
+}
+
+func (c *otlpWriterClient) UploadTraces(_ context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	body, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(
+		&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return err
+	}
+
+	// protojson以base64编码bytes字段，而OTLP JSON要求trace id与span id以十六进制编码
+	var request interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		return err
+	}
+	hexEncodeIDs(request)
+	if body, err = json.Marshal(request); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err = c.w.Write(append(body, '\n'))
+	return err
+}
+
+// otlpIDFields OTLP JSON中以十六进制编码的字段
+var otlpIDFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// hexEncodeIDs 将json解码得到的对象中base64编码的trace id与span id转换为十六进制编码
+func hexEncodeIDs(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, f := range v {
+			if s, ok := f.(string); ok && otlpIDFields[k] {
+				if id, err := base64.StdEncoding.DecodeString(s); err == nil {
+					v[k] = hex.EncodeToString(id)
+				}
+				continue
+			}
+			hexEncodeIDs(f)
+		}
+	case []interface{}:
+		for _, f := range v {
+			hexEncodeIDs(f)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+)
+
+// 服务内部创建span时使用的tracer名
+const tracerName = "gitee.com/moyusir/data-collection"
+
+// span的导出方式
+const (
+	ExporterOTLP   = "otlp"
+	ExporterFile   = "file"
+	ExporterStdout = "stdout"
+)
+
+// InitTracer 按照配置设置全局的TracerProvider以及传播器，返回用于导出剩余span并关闭导出器的函数，
+// 未配置导出方式时只设置传播器，此时服务仍会透传上游的追踪上下文
+func InitTracer(c *conf.Trace, serviceName, version, instanceID string) (func(), error) {
+	otel.SetTextMapPropagator(
+		propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	)
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+		cleanup  = func() {}
+	)
+	switch c.GetExporter() {
+	case "":
+		return cleanup, nil
+	case ExporterOTLP:
+		if c.Endpoint == "" {
+			return nil, errors.New(500, "Monitor_Trace_Error", "使用otlp导出span时需要配置接收端的地址")
+		}
+		exporter, err = NewOTLPExporter(context.Background(), c)
+	case ExporterFile:
+		f, openErr := os.OpenFile(c.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if openErr != nil {
+			return nil, errors.Newf(500, "Monitor_Trace_Error", "打开span导出文件时发生了错误:%v", openErr)
+		}
+		exporter, err = NewOTLPWriterExporter(f)
+		cleanup = func() { f.Close() }
+	case ExporterStdout:
+		exporter, err = NewOTLPWriterExporter(os.Stdout)
+	default:
+		return nil, errors.Newf(500, "Monitor_Trace_Error", "不支持的span导出方式:%s", c.Exporter)
+	}
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if r := c.GetSampleRatio(); r > 0 && r < 1 {
+		sampler = sdktrace.TraceIDRatioBased(r)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version),
+			semconv.ServiceInstanceIDKey.String(instanceID),
+			semconv.EnduserIDKey.String(conf.Username),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func() {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			otel.Handle(fmt.Errorf("关闭TracerProvider时发生了错误:%w", err))
+		}
+		cleanup()
+	}, nil
+}
+
+// StartSpan 以服务内部的tracer开始一个span
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan 记录操作的错误并结束span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StreamContext 流式rpc不经过kratos的中间件，这里从流的请求头中提取客户端传递的追踪上下文，
+// 作为流中各个消息处理span的父span
+func StreamContext(ctx context.Context) context.Context {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		return otel.GetTextMapPropagator().Extract(ctx, tr.RequestHeader())
+	}
+	return ctx
+}
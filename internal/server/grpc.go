@@ -3,24 +3,39 @@ package server
 import (
 	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
 	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"gitee.com/moyusir/data-collection/internal/service"
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/metrics"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	g "google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, cs *service.ConfigService, ws *service.WarningDetectService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, cs *service.ConfigService, ws *service.WarningDetectService, hs *service.HealthService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(
 				recovery.WithLogger(logger),
 			),
-			logging.Server(logger),
+			tracing.Server(),
+			// 与logging.Server相同，但日志中请求参数的敏感字段以掩码替换
+			service.Logging(logger),
+			metrics.Server(
+				metrics.WithSeconds(monitor.NewObserver(monitor.ServerRequestSeconds)),
+				metrics.WithRequests(monitor.NewCounter(monitor.ServerRequests)),
+			),
+			// 记录配置变更的审计记录所需的调用者身份以及来源ip
+			service.AuditActor(c.Audit, logger),
+			// 响应中设备配置的敏感字段以掩码替换
+			service.MaskSensitiveFields(),
 		),
+		// 使健康检查协议反映依赖组件的状态
+		grpc.UnaryInterceptor(hs.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(hs.StreamServerInterceptor()),
 		grpc.Options(
 			g.KeepaliveParams(keepalive.ServerParameters{
 				MaxConnectionIdle: c.Grpc.MaxIdleTime.AsDuration(),
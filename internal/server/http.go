@@ -3,21 +3,34 @@ package server
 import (
 	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
 	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"gitee.com/moyusir/data-collection/internal/service"
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/metrics"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewHTTPServer new a HTTP server.
-func NewHTTPServer(c *conf.Server, cs *service.ConfigService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, cs *service.ConfigService, dms *service.DeviceMetricsService, hs *service.HealthService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(
 				recovery.WithLogger(logger),
 			),
-			logging.Server(logger),
+			tracing.Server(),
+			// 与logging.Server相同，但日志中请求参数的敏感字段以掩码替换
+			service.Logging(logger),
+			metrics.Server(
+				metrics.WithSeconds(monitor.NewObserver(monitor.ServerRequestSeconds)),
+				metrics.WithRequests(monitor.NewCounter(monitor.ServerRequests)),
+			),
+			// 记录配置变更的审计记录所需的调用者身份以及来源ip
+			service.AuditActor(c.Audit, logger),
+			// 响应中设备配置的敏感字段以掩码替换
+			service.MaskSensitiveFields(),
 		),
 	}
 	if c.Http.Network != "" {
@@ -31,5 +44,14 @@ func NewHTTPServer(c *conf.Server, cs *service.ConfigService, logger log.Logger)
 	}
 	srv := http.NewServer(opts...)
 	v1.RegisterConfigHTTPServer(srv, cs)
+	// 存活与就绪检查端点
+	srv.HandleFunc("/healthz", hs.Healthz)
+	srv.HandleFunc("/readyz", hs.Readyz)
+	// 导出配置审计记录的端点
+	srv.HandleFunc("/configs/audit/export", cs.ExportConfigAuditRecords)
+	// 暴露设备最新读数的prometheus抓取端点
+	srv.Handle("/metrics/devices", dms)
+	// 暴露服务自身监控指标的prometheus抓取端点
+	srv.Handle("/metrics", promhttp.Handler())
 	return srv
 }
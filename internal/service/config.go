@@ -2,27 +2,47 @@ package service
 
 import (
 	"context"
+	"fmt"
 	pb "gitee.com/moyusir/data-collection/api/dataCollection/v1"
 	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
+	"strconv"
+	"time"
 )
 
 const CLIENT_ID_HEADER = "x-client-id"
 
+// WAIT_FOR_ACK_HEADER 配置更新请求中指定等待设备确认的超时时间的请求头，值的格式如10s
+const WAIT_FOR_ACK_HEADER = "x-wait-for-ack"
+
+// 等待设备确认配置更新的最长时间
+const maxWaitForAck = time.Minute
+
+// EXPIRES_AT_HEADER 配置更新请求中指定配置更新截止时间的请求头，值为RFC3339格式的时间
+const EXPIRES_AT_HEADER = "x-expires-at"
+
 type ConfigService struct {
 	pb.UnimplementedConfigServer
 	uc      *biz.ConfigUsecase
 	updater *biz.DeviceConfigUpdater
+	rollout *biz.RolloutUsecase
 	logger  *log.Helper
 }
 
-func NewConfigService(uc *biz.ConfigUsecase, updater *biz.DeviceConfigUpdater, logger log.Logger) (*ConfigService, error) {
+func NewConfigService(uc *biz.ConfigUsecase, updater *biz.DeviceConfigUpdater,
+	rollout *biz.RolloutUsecase, logger log.Logger) (*ConfigService, error) {
 	c := &ConfigService{
 		uc:      uc,
 		updater: updater,
+		rollout: rollout,
 		logger:  log.NewHelper(logger),
 	}
 
@@ -45,6 +65,13 @@ func (s *ConfigService) CreateInitialConfigSaveStream0(conn pb.Config_CreateInit
 		s.logger.Info("与未知用户建立了传输设备初始配置的grpc流")
 	}
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateInitialConfigSaveStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+	// 设备上传的初始配置引起的配置变更以clientID作为调用者
+	streamCtx := biz.WithAuditActor(monitor.StreamContext(conn.Context()), streamAuditActor(conn.Context(), clientID))
+
 	for {
 		var (
 			config *pb.DeviceConfig0
@@ -69,24 +96,38 @@ func (s *ConfigService) CreateInitialConfigSaveStream0(conn pb.Config_CreateInit
 					500, "Service_Config_Error",
 					"接收用户 %v 的初始设备配置信息时发生了错误:%v", clientID, err)
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
 
 			// 提取设备基本信息进行保存或路由的激活
 			info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID}
 			info.DeviceID = config.Id
 
+			// 流中的每条消息使用单独的span追踪
+			ctx, span := monitor.StartSpan(streamCtx, rpc+".Recv",
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(streamAttributes(clientID, info)...),
+			)
+
 			// 若clientID不为空，则建立关于该clientID的路由信息
 			// TODO 考虑错误处理
 			if clientID != "" {
-				err = s.updater.ConnectDeviceAndClientID(clientID, info)
+				err = s.updater.ConnectDeviceAndClientID(ctx, clientID, info)
 				if err != nil {
+					monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+					monitor.EndSpan(span, err)
 					return err
 				}
 			}
 
+			// 上传的配置与desired配置存在差异时，按照设备类别的冲突处理策略决定生效的配置
 			// TODO 设备初始配置保存出错时如何处理，使用怎样的错误模型返回？
-			if err = s.uc.SaveDeviceConfig(info, config); err != nil {
+			if err = s.uc.SaveInitialDeviceConfig(ctx, info, config, clientID, new(pb.DeviceConfig0)); err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
+			monitor.EndSpan(span, nil)
 		}
 	}
 }
@@ -104,7 +145,7 @@ func (s *ConfigService) CreateConfigUpdateStream0(conn pb.Config_CreateConfigUpd
 		clientID = value[0]
 	} else {
 		// 若请求头中不存在，则申请创建新的clientID，通过响应头并发送给客户端
-		id, err := s.updater.CreateClientID()
+		id, err := s.updater.CreateClientID(conn.Context())
 		if err != nil {
 			return err
 		} else {
@@ -123,6 +164,14 @@ func (s *ConfigService) CreateConfigUpdateStream0(conn pb.Config_CreateConfigUpd
 
 	s.logger.Infof("与 %v 建立了传输配置更新信息的grpc流", clientID)
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateConfigUpdateStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+
+	// 设备确认接收配置更新的审计记录以clientID作为调用者
+	actor := streamAuditActor(conn.Context(), clientID)
+
 	// 获得clientID对应的updateChannel
 	ctx, cancel := context.WithCancel(conn.Context())
 	updateChannel, err := s.updater.GetDeviceUpdateMsgChannel(ctx, clientID, new(pb.DeviceConfig0))
@@ -131,38 +180,90 @@ func (s *ConfigService) CreateConfigUpdateStream0(conn pb.Config_CreateConfigUpd
 	}
 	defer cancel()
 
+	// 待确认的配置更新已经交给updateChannel重发，之后再将desired配置下发给影子不一致的设备，
+	// 同步失败时设备的影子在下次连接或者上传冲突的初始配置时再次同步
+	if err := s.uc.SyncClientDeviceShadows(ctx, clientID, deviceClassID, new(pb.DeviceConfig0)); err != nil {
+		s.logger.Errorf("同步 %v 关联的设备影子时发生了错误:%v", clientID, err)
+	}
+
 	// 不断从相应的channel中获得配置更新信息，并发送给客户端
-	for c := range updateChannel {
-		config := c.(*pb.DeviceConfig0)
-		err := conn.Send(config)
-		if err != nil {
-			return errors.Newf(
-				500, "Service_Config_Error",
-				"向用户 %v 发送配置更新消息时发生了错误:%v", clientID, err)
-		}
+	for update := range updateChannel {
+		config := update.Config.(*pb.DeviceConfig0)
+		info.DeviceID = config.Id
+		// 延续发起配置更新的链路，追踪配置的下发直至接收到客户端的答复
+		ctx, span := monitor.StartSpan(biz.WithAuditActor(update.Context(conn.Context()), actor), rpc+".Send",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(streamAttributes(clientID, info)...),
+		)
 
-		reply, err := conn.Recv()
-		if err == io.EOF {
-			s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
-			return nil
-		}
-		if err != nil {
-			return errors.Newf(
-				500, "Service_Config_Error",
-				"接收用户 %v 传输的配置更新消息响应时发生了错误:%v", clientID, err)
+		// 客户端答复接收失败时，按照退避时间重发配置更新，直至达到最大发送次数
+		var reply *pb.ConfigUpdateReply
+		for {
+			err := conn.Send(config)
+			if err != nil {
+				err = errors.Newf(
+					500, "Service_Config_Error",
+					"向用户 %v 发送配置更新消息时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
+			}
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusSent, nil)
+
+			reply, err = conn.Recv()
+			if err == io.EOF {
+				s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
+				monitor.EndSpan(span, nil)
+				return nil
+			}
+			if err != nil {
+				err = errors.Newf(
+					500, "Service_Config_Error",
+					"接收用户 %v 传输的配置更新消息响应时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
+			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
+			span.SetAttributes(
+				attribute.Bool("reply.success", reply.Success),
+				attribute.Bool("reply.end", reply.End),
+			)
+			if reply.Success {
+				break
+			}
+
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+			backoff, retry := s.updater.RetryDeviceConfigUpdate(ctx, clientID, info, update, errors.New(
+				400, "Service_Config_Error", "客户端答复接收配置更新失败"))
+			// 客户端断开连接时，尚未失败的配置更新保留在待确认队列中，重新连接后重发
+			if !retry || reply.End {
+				break
+			}
+			monitor.ConfigUpdateResends.WithLabelValues(class).Inc()
+			span.AddEvent("resend", trace.WithAttributes(attribute.String("backoff", backoff.String())))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				monitor.EndSpan(span, nil)
+				return nil
+			}
 		}
-		// 当客户端给出发送不成功的答复时，尝试重发一次
-		// TODO 考虑配置最大重发次数?
-		if !reply.Success {
-			conn.Send(config)
-		} else {
+		if reply.Success {
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusAcked, nil)
 			// TODO 考虑设备配置保存失败时如何处理
-			info.DeviceID = config.Id
-			err := s.uc.SaveDeviceConfig(info, config)
+			err := s.uc.SaveAckedDeviceConfig(ctx, info, config, clientID, update.UpdateID())
 			if err != nil {
+				s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusFailed, err)
+				monitor.EndSpan(span, err)
 				return err
 			}
+			// 确认失败时配置更新会在客户端重新连接后重发，设备需要能够处理重复的配置更新
+			if err := s.updater.AckDeviceConfigUpdate(ctx, clientID, update); err != nil {
+				s.logger.Errorf("确认 %v 接收的配置更新时发生了错误:%v", clientID, err)
+			}
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusApplied, nil)
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
 		}
+		monitor.EndSpan(span, nil)
 		// 客户端表示需要断开连接
 		if reply.End {
 			s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
@@ -175,16 +276,290 @@ func (s *ConfigService) CreateConfigUpdateStream0(conn pb.Config_CreateConfigUpd
 func (s *ConfigService) UpdateDeviceConfig0(ctx context.Context, req *pb.DeviceConfig0) (*pb.ConfigServiceReply, error) {
 	// 设备类别号，代码生成时注入
 	deviceClassID := 0
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
 	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
-	// 查询节点，将配置更新信息发送到相应channel中
-	err := s.updater.UpdateDeviceConfig(info, req)
+	// 查询节点，将配置更新信息发送到相应channel中，并记录配置修订
+	updateID, err := s.uc.UpdateDeviceConfig(ctx, info, req)
 	if err != nil {
+		// 配置不满足校验规则等客户端错误直接返回，保留其中的字段级错误信息
+		if errors.Code(err) < 500 {
+			return nil, err
+		}
 		return nil, errors.Newf(500,
 			"Service_Config_Error",
 			"更新设备配置时发生了未知错误:%v", err,
 		)
 	}
 
+	return s.waitForAck(ctx, updateID)
+}
+
+func (s *ConfigService) PatchDeviceConfig0(ctx context.Context, req *pb.PatchDeviceConfigRequest0) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "更新的配置不能为空")
+	}
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Config.Id}
+	updateID, revision, err := s.uc.PatchDeviceConfig(
+		ctx, info, req.Config, req.UpdateMask.GetPaths(), req.ExpectedRevision, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := s.waitForAck(ctx, updateID)
+	if err != nil {
+		return nil, err
+	}
+	reply.Revision = revision
+	return reply, nil
+}
+
+func (s *ConfigService) GetDeviceConfig0(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceConfig0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	config := new(pb.DeviceConfig0)
+	if err := s.uc.GetDeviceConfig(ctx, info, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (s *ConfigService) ListDeviceConfigs0(ctx context.Context, req *pb.ListDeviceConfigsRequest) (*pb.ListDeviceConfigsReply0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	configs, next, err := s.uc.ListDeviceConfigs(
+		ctx, deviceClassID, req.PageSize, req.PageToken, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigsReply0{NextPageToken: next}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig0))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) BatchGetDeviceConfigs0(ctx context.Context, req *pb.BatchGetDeviceConfigsRequest) (*pb.BatchGetDeviceConfigsReply0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	configs, notFound, err := s.uc.BatchGetDeviceConfigs(
+		ctx, deviceClassID, req.Ids, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.BatchGetDeviceConfigsReply0{NotFoundIds: notFound}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig0))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) ListDeviceConfigRevisions0(ctx context.Context, req *pb.ListDeviceConfigRevisionsRequest) (*pb.ListDeviceConfigRevisionsReply0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	revisions, next, err := s.uc.ListConfigRevisions(ctx, info, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigRevisionsReply0{NextPageToken: next}
+	for _, r := range revisions {
+		config := new(pb.DeviceConfig0)
+		if err := r.UnmarshalConfig(config); err != nil {
+			return nil, err
+		}
+		reply.Revisions = append(reply.Revisions, &pb.DeviceConfigRevision0{
+			Version:  r.Version,
+			Time:     timestamppb.New(r.Time),
+			Source:   r.Source,
+			ClientId: r.ClientID,
+			Config:   config,
+			Detail:   r.Detail,
+		})
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) DiffDeviceConfigRevisions0(ctx context.Context, req *pb.DiffDeviceConfigRevisionsRequest) (*pb.DiffDeviceConfigRevisionsReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	diffs, err := s.uc.DiffConfigRevisions(ctx, info, req.FromVersion, req.ToVersion, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+	return newDiffReply(diffs), nil
+}
+
+func (s *ConfigService) RollbackDeviceConfig0(ctx context.Context, req *pb.RollbackDeviceConfigRequest) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	updateID, err := s.uc.RollbackDeviceConfig(ctx, info, req.Version, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+	return s.waitForAck(ctx, updateID)
+}
+
+func (s *ConfigService) GetDeviceShadow0(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceShadow0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	shadow, err := s.uc.GetDeviceShadow(ctx, info, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.DeviceShadow0{Delta: newFieldDiffs(shadow.Delta), InSync: shadow.InSync, Revision: shadow.Revision}
+	if shadow.Desired != nil {
+		reply.Desired = shadow.Desired.(*pb.DeviceConfig0)
+	}
+	if shadow.Reported != nil {
+		reply.Reported = shadow.Reported.(*pb.DeviceConfig0)
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SetDeviceLabels0(ctx context.Context, req *pb.DeviceLabels) (*pb.DeviceLabels, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	if err := s.uc.SetDeviceLabels(ctx, info, req.Labels); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *ConfigService) GetDeviceLabels0(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceLabels, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	labels, err := s.uc.GetDeviceLabels(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeviceLabels{Id: req.Id, Labels: labels}, nil
+}
+
+func (s *ConfigService) BulkUpdateDeviceConfig0(ctx context.Context, req *pb.BulkUpdateDeviceConfigRequest0) (*pb.BulkUpdateJob, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "批量更新的配置不能为空")
+	}
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.uc.BulkUpdateDeviceConfig(ctx, deviceClassID, newDeviceSelector(req.Selector), req.Config)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkUpdateJobReply(job), nil
+}
+
+func (s *ConfigService) CreateConfigRollout0(ctx context.Context, req *pb.CreateConfigRolloutRequest0) (*pb.ConfigRollout, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "灰度发布的配置不能为空")
+	}
+	rollout, err := newConfigRollout(deviceClassID, req.Selector, req.StartTime, req.Stages, req.AckTimeout, req.MaxFailures)
+	if err != nil {
+		return nil, err
+	}
+	rollout, err = s.rollout.CreateRollout(ctx, rollout, req.Config)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigRolloutReply(rollout), nil
+}
+
+func (s *ConfigService) ListConfigAuditRecords0(ctx context.Context, req *pb.ListConfigAuditRecordsRequest) (*pb.ListConfigAuditRecordsReply0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	filter, err := newConfigAuditFilter(req.DeviceId, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	records, next, err := s.uc.ListConfigAuditRecords(ctx, deviceClassID, filter, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListConfigAuditRecordsReply0{NextPageToken: next}
+	for _, r := range records {
+		record, err := newConfigAuditRecord0(r)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, record)
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SearchDeviceConfigs0(ctx context.Context, req *pb.SearchDeviceConfigsRequest) (*pb.ListDeviceConfigsReply0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	predicates, err := newConfigFieldPredicates(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	configs, next, err := s.uc.SearchDeviceConfigs(
+		ctx, deviceClassID, predicates, req.PageSize, req.PageToken, new(pb.DeviceConfig0))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigsReply0{NextPageToken: next}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig0))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SetConfigTemplate0(ctx context.Context, req *pb.DeviceConfig0) (*pb.DeviceConfig0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	if err := s.uc.SetConfigTemplate(ctx, deviceClassID, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *ConfigService) GetConfigTemplate0(ctx context.Context, req *pb.GetConfigTemplateRequest) (*pb.DeviceConfig0, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	template := new(pb.DeviceConfig0)
+	if err := s.uc.GetConfigTemplate(ctx, deviceClassID, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *ConfigService) DeleteConfigTemplate0(ctx context.Context, req *pb.GetConfigTemplateRequest) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 0
+	if err := s.uc.DeleteConfigTemplate(ctx, deviceClassID); err != nil {
+		return nil, err
+	}
 	return &pb.ConfigServiceReply{Success: true}, nil
 }
 
@@ -204,6 +579,13 @@ func (s *ConfigService) CreateInitialConfigSaveStream1(conn pb.Config_CreateInit
 		s.logger.Info("与未知用户建立了传输设备初始配置的grpc流")
 	}
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateInitialConfigSaveStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+	// 设备上传的初始配置引起的配置变更以clientID作为调用者
+	streamCtx := biz.WithAuditActor(monitor.StreamContext(conn.Context()), streamAuditActor(conn.Context(), clientID))
+
 	for {
 		var (
 			config *pb.DeviceConfig1
@@ -228,24 +610,38 @@ func (s *ConfigService) CreateInitialConfigSaveStream1(conn pb.Config_CreateInit
 					500, "Service_Config_Error",
 					"接收用户 %v 的初始设备配置信息时发生了错误:%v", clientID, err)
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
 
 			// 提取设备基本信息进行保存或路由的激活
 			info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID}
 			info.DeviceID = config.Id
 
+			// 流中的每条消息使用单独的span追踪
+			ctx, span := monitor.StartSpan(streamCtx, rpc+".Recv",
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(streamAttributes(clientID, info)...),
+			)
+
 			// 若clientID不为空，则建立关于该clientID的路由信息
 			// TODO 考虑错误处理
 			if clientID != "" {
-				err = s.updater.ConnectDeviceAndClientID(clientID, info)
+				err = s.updater.ConnectDeviceAndClientID(ctx, clientID, info)
 				if err != nil {
+					monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+					monitor.EndSpan(span, err)
 					return err
 				}
 			}
 
+			// 上传的配置与desired配置存在差异时，按照设备类别的冲突处理策略决定生效的配置
 			// TODO 设备初始配置保存出错时如何处理，使用怎样的错误模型返回？
-			if err = s.uc.SaveDeviceConfig(info, config); err != nil {
+			if err = s.uc.SaveInitialDeviceConfig(ctx, info, config, clientID, new(pb.DeviceConfig1)); err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
+			monitor.EndSpan(span, nil)
 		}
 	}
 }
@@ -263,7 +659,7 @@ func (s *ConfigService) CreateConfigUpdateStream1(conn pb.Config_CreateConfigUpd
 		clientID = value[0]
 	} else {
 		// 若请求头中不存在，则申请创建新的clientID，通过响应头并发送给客户端
-		id, err := s.updater.CreateClientID()
+		id, err := s.updater.CreateClientID(conn.Context())
 		if err != nil {
 			return err
 		} else {
@@ -282,6 +678,14 @@ func (s *ConfigService) CreateConfigUpdateStream1(conn pb.Config_CreateConfigUpd
 
 	s.logger.Infof("与 %v 建立了传输配置更新信息的grpc流", clientID)
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateConfigUpdateStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+
+	// 设备确认接收配置更新的审计记录以clientID作为调用者
+	actor := streamAuditActor(conn.Context(), clientID)
+
 	// 获得clientID对应的updateChannel
 	ctx, cancel := context.WithCancel(conn.Context())
 	updateChannel, err := s.updater.GetDeviceUpdateMsgChannel(ctx, clientID, new(pb.DeviceConfig1))
@@ -290,38 +694,90 @@ func (s *ConfigService) CreateConfigUpdateStream1(conn pb.Config_CreateConfigUpd
 	}
 	defer cancel()
 
+	// 待确认的配置更新已经交给updateChannel重发，之后再将desired配置下发给影子不一致的设备，
+	// 同步失败时设备的影子在下次连接或者上传冲突的初始配置时再次同步
+	if err := s.uc.SyncClientDeviceShadows(ctx, clientID, deviceClassID, new(pb.DeviceConfig1)); err != nil {
+		s.logger.Errorf("同步 %v 关联的设备影子时发生了错误:%v", clientID, err)
+	}
+
 	// 不断从相应的channel中获得配置更新信息，并发送给客户端
-	for c := range updateChannel {
-		config := c.(*pb.DeviceConfig1)
-		err := conn.Send(config)
-		if err != nil {
-			return errors.Newf(
-				500, "Service_Config_Error",
-				"向用户 %v 发送配置更新消息时发生了错误:%v", clientID, err)
-		}
+	for update := range updateChannel {
+		config := update.Config.(*pb.DeviceConfig1)
+		info.DeviceID = config.Id
+		// 延续发起配置更新的链路，追踪配置的下发直至接收到客户端的答复
+		ctx, span := monitor.StartSpan(biz.WithAuditActor(update.Context(conn.Context()), actor), rpc+".Send",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(streamAttributes(clientID, info)...),
+		)
 
-		reply, err := conn.Recv()
-		if err == io.EOF {
-			s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
-			return nil
-		}
-		if err != nil {
-			return errors.Newf(
-				500, "Service_Config_Error",
-				"接收用户 %v 传输的配置更新消息响应时发生了错误:%v", clientID, err)
+		// 客户端答复接收失败时，按照退避时间重发配置更新，直至达到最大发送次数
+		var reply *pb.ConfigUpdateReply
+		for {
+			err := conn.Send(config)
+			if err != nil {
+				err = errors.Newf(
+					500, "Service_Config_Error",
+					"向用户 %v 发送配置更新消息时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
+			}
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusSent, nil)
+
+			reply, err = conn.Recv()
+			if err == io.EOF {
+				s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
+				monitor.EndSpan(span, nil)
+				return nil
+			}
+			if err != nil {
+				err = errors.Newf(
+					500, "Service_Config_Error",
+					"接收用户 %v 传输的配置更新消息响应时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
+			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
+			span.SetAttributes(
+				attribute.Bool("reply.success", reply.Success),
+				attribute.Bool("reply.end", reply.End),
+			)
+			if reply.Success {
+				break
+			}
+
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+			backoff, retry := s.updater.RetryDeviceConfigUpdate(ctx, clientID, info, update, errors.New(
+				400, "Service_Config_Error", "客户端答复接收配置更新失败"))
+			// 客户端断开连接时，尚未失败的配置更新保留在待确认队列中，重新连接后重发
+			if !retry || reply.End {
+				break
+			}
+			monitor.ConfigUpdateResends.WithLabelValues(class).Inc()
+			span.AddEvent("resend", trace.WithAttributes(attribute.String("backoff", backoff.String())))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				monitor.EndSpan(span, nil)
+				return nil
+			}
 		}
-		// 当客户端给出发送不成功的答复时，尝试重发一次
-		// TODO 考虑配置最大重发次数?
-		if !reply.Success {
-			conn.Send(config)
-		} else {
+		if reply.Success {
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusAcked, nil)
 			// TODO 考虑设备配置保存失败时如何处理
-			info.DeviceID = config.Id
-			err := s.uc.SaveDeviceConfig(info, config)
+			err := s.uc.SaveAckedDeviceConfig(ctx, info, config, clientID, update.UpdateID())
 			if err != nil {
+				s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusFailed, err)
+				monitor.EndSpan(span, err)
 				return err
 			}
+			// 确认失败时配置更新会在客户端重新连接后重发，设备需要能够处理重复的配置更新
+			if err := s.updater.AckDeviceConfigUpdate(ctx, clientID, update); err != nil {
+				s.logger.Errorf("确认 %v 接收的配置更新时发生了错误:%v", clientID, err)
+			}
+			s.updater.SetUpdateStatus(ctx, update, biz.UpdateStatusApplied, nil)
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
 		}
+		monitor.EndSpan(span, nil)
 		// 客户端表示需要断开连接
 		if reply.End {
 			s.logger.Infof("关闭了 %v 的传输配置更新信息的grpc流", clientID)
@@ -334,15 +790,527 @@ func (s *ConfigService) CreateConfigUpdateStream1(conn pb.Config_CreateConfigUpd
 func (s *ConfigService) UpdateDeviceConfig1(ctx context.Context, req *pb.DeviceConfig1) (*pb.ConfigServiceReply, error) {
 	// 设备类别号，代码生成时注入
 	deviceClassID := 1
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
 	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
-	// 查询节点，将配置更新信息发送到相应channel中
-	err := s.updater.UpdateDeviceConfig(info, req)
+	// 查询节点，将配置更新信息发送到相应channel中，并记录配置修订
+	updateID, err := s.uc.UpdateDeviceConfig(ctx, info, req)
 	if err != nil {
+		// 配置不满足校验规则等客户端错误直接返回，保留其中的字段级错误信息
+		if errors.Code(err) < 500 {
+			return nil, err
+		}
 		return nil, errors.Newf(500,
 			"Service_Config_Error",
 			"更新设备配置时发生了未知错误:%v", err,
 		)
 	}
 
+	return s.waitForAck(ctx, updateID)
+}
+
+func (s *ConfigService) PatchDeviceConfig1(ctx context.Context, req *pb.PatchDeviceConfigRequest1) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "更新的配置不能为空")
+	}
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Config.Id}
+	updateID, revision, err := s.uc.PatchDeviceConfig(
+		ctx, info, req.Config, req.UpdateMask.GetPaths(), req.ExpectedRevision, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := s.waitForAck(ctx, updateID)
+	if err != nil {
+		return nil, err
+	}
+	reply.Revision = revision
+	return reply, nil
+}
+
+func (s *ConfigService) GetDeviceConfig1(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceConfig1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	config := new(pb.DeviceConfig1)
+	if err := s.uc.GetDeviceConfig(ctx, info, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (s *ConfigService) ListDeviceConfigs1(ctx context.Context, req *pb.ListDeviceConfigsRequest) (*pb.ListDeviceConfigsReply1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	configs, next, err := s.uc.ListDeviceConfigs(
+		ctx, deviceClassID, req.PageSize, req.PageToken, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigsReply1{NextPageToken: next}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig1))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) BatchGetDeviceConfigs1(ctx context.Context, req *pb.BatchGetDeviceConfigsRequest) (*pb.BatchGetDeviceConfigsReply1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	configs, notFound, err := s.uc.BatchGetDeviceConfigs(
+		ctx, deviceClassID, req.Ids, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.BatchGetDeviceConfigsReply1{NotFoundIds: notFound}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig1))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) ListDeviceConfigRevisions1(ctx context.Context, req *pb.ListDeviceConfigRevisionsRequest) (*pb.ListDeviceConfigRevisionsReply1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	revisions, next, err := s.uc.ListConfigRevisions(ctx, info, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigRevisionsReply1{NextPageToken: next}
+	for _, r := range revisions {
+		config := new(pb.DeviceConfig1)
+		if err := r.UnmarshalConfig(config); err != nil {
+			return nil, err
+		}
+		reply.Revisions = append(reply.Revisions, &pb.DeviceConfigRevision1{
+			Version:  r.Version,
+			Time:     timestamppb.New(r.Time),
+			Source:   r.Source,
+			ClientId: r.ClientID,
+			Config:   config,
+			Detail:   r.Detail,
+		})
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) DiffDeviceConfigRevisions1(ctx context.Context, req *pb.DiffDeviceConfigRevisionsRequest) (*pb.DiffDeviceConfigRevisionsReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	diffs, err := s.uc.DiffConfigRevisions(ctx, info, req.FromVersion, req.ToVersion, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+	return newDiffReply(diffs), nil
+}
+
+func (s *ConfigService) RollbackDeviceConfig1(ctx context.Context, req *pb.RollbackDeviceConfigRequest) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	updateID, err := s.uc.RollbackDeviceConfig(ctx, info, req.Version, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+	return s.waitForAck(ctx, updateID)
+}
+
+func (s *ConfigService) GetDeviceShadow1(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceShadow1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	shadow, err := s.uc.GetDeviceShadow(ctx, info, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.DeviceShadow1{Delta: newFieldDiffs(shadow.Delta), InSync: shadow.InSync, Revision: shadow.Revision}
+	if shadow.Desired != nil {
+		reply.Desired = shadow.Desired.(*pb.DeviceConfig1)
+	}
+	if shadow.Reported != nil {
+		reply.Reported = shadow.Reported.(*pb.DeviceConfig1)
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SetDeviceLabels1(ctx context.Context, req *pb.DeviceLabels) (*pb.DeviceLabels, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	if err := s.uc.SetDeviceLabels(ctx, info, req.Labels); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *ConfigService) GetDeviceLabels1(ctx context.Context, req *pb.GetDeviceConfigRequest) (*pb.DeviceLabels, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID, DeviceID: req.Id}
+	labels, err := s.uc.GetDeviceLabels(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeviceLabels{Id: req.Id, Labels: labels}, nil
+}
+
+func (s *ConfigService) BulkUpdateDeviceConfig1(ctx context.Context, req *pb.BulkUpdateDeviceConfigRequest1) (*pb.BulkUpdateJob, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "批量更新的配置不能为空")
+	}
+	ctx, err := withUpdateExpiry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.uc.BulkUpdateDeviceConfig(ctx, deviceClassID, newDeviceSelector(req.Selector), req.Config)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkUpdateJobReply(job), nil
+}
+
+func (s *ConfigService) CreateConfigRollout1(ctx context.Context, req *pb.CreateConfigRolloutRequest1) (*pb.ConfigRollout, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	if req.Config == nil {
+		return nil, errors.New(400, "Service_Config_Error", "灰度发布的配置不能为空")
+	}
+	rollout, err := newConfigRollout(deviceClassID, req.Selector, req.StartTime, req.Stages, req.AckTimeout, req.MaxFailures)
+	if err != nil {
+		return nil, err
+	}
+	rollout, err = s.rollout.CreateRollout(ctx, rollout, req.Config)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigRolloutReply(rollout), nil
+}
+
+func (s *ConfigService) ListConfigAuditRecords1(ctx context.Context, req *pb.ListConfigAuditRecordsRequest) (*pb.ListConfigAuditRecordsReply1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	filter, err := newConfigAuditFilter(req.DeviceId, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	records, next, err := s.uc.ListConfigAuditRecords(ctx, deviceClassID, filter, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListConfigAuditRecordsReply1{NextPageToken: next}
+	for _, r := range records {
+		record, err := newConfigAuditRecord1(r)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, record)
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SearchDeviceConfigs1(ctx context.Context, req *pb.SearchDeviceConfigsRequest) (*pb.ListDeviceConfigsReply1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	predicates, err := newConfigFieldPredicates(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	configs, next, err := s.uc.SearchDeviceConfigs(
+		ctx, deviceClassID, predicates, req.PageSize, req.PageToken, new(pb.DeviceConfig1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListDeviceConfigsReply1{NextPageToken: next}
+	for _, c := range configs {
+		reply.Configs = append(reply.Configs, c.(*pb.DeviceConfig1))
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) SetConfigTemplate1(ctx context.Context, req *pb.DeviceConfig1) (*pb.DeviceConfig1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	if err := s.uc.SetConfigTemplate(ctx, deviceClassID, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *ConfigService) GetConfigTemplate1(ctx context.Context, req *pb.GetConfigTemplateRequest) (*pb.DeviceConfig1, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	template := new(pb.DeviceConfig1)
+	if err := s.uc.GetConfigTemplate(ctx, deviceClassID, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *ConfigService) DeleteConfigTemplate1(ctx context.Context, req *pb.GetConfigTemplateRequest) (*pb.ConfigServiceReply, error) {
+	// 设备类别号，代码生成时注入
+	deviceClassID := 1
+	if err := s.uc.DeleteConfigTemplate(ctx, deviceClassID); err != nil {
+		return nil, err
+	}
 	return &pb.ConfigServiceReply{Success: true}, nil
 }
+
+func (s *ConfigService) GetConfigUpdateStatus(ctx context.Context, req *pb.GetConfigUpdateStatusRequest) (*pb.ConfigUpdateStatus, error) {
+	status, err := s.updater.GetConfigUpdateStatus(ctx, req.UpdateId)
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ConfigUpdateStatus{
+		UpdateId:      status.UpdateID,
+		DeviceClassId: int64(status.DeviceClassID),
+		DeviceId:      status.DeviceID,
+		ClientId:      status.ClientID,
+		Status:        status.Status,
+		Error:         status.Error,
+		CreateTime:    timestamppb.New(status.CreatedAt),
+		UpdateTime:    timestamppb.New(status.UpdatedAt),
+		Attempts:      status.Attempts,
+		Seq:           status.Seq,
+	}
+	if !status.ExpiresAt.IsZero() {
+		reply.ExpiresAt = timestamppb.New(status.ExpiresAt)
+	}
+	return reply, nil
+}
+
+func (s *ConfigService) GetBulkUpdateJob(ctx context.Context, req *pb.GetBulkUpdateJobRequest) (*pb.BulkUpdateJob, error) {
+	job, err := s.uc.GetBulkUpdateJob(ctx, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkUpdateJobReply(job), nil
+}
+
+func (s *ConfigService) GetConfigRollout(ctx context.Context, req *pb.GetConfigRolloutRequest) (*pb.ConfigRollout, error) {
+	rollout, err := s.rollout.GetRollout(ctx, req.RolloutId)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigRolloutReply(rollout), nil
+}
+
+func (s *ConfigService) CancelConfigRollout(ctx context.Context, req *pb.GetConfigRolloutRequest) (*pb.ConfigRollout, error) {
+	rollout, err := s.rollout.CancelRollout(ctx, req.RolloutId)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigRolloutReply(rollout), nil
+}
+
+func (s *ConfigService) ResumeConfigRollout(ctx context.Context, req *pb.GetConfigRolloutRequest) (*pb.ConfigRollout, error) {
+	rollout, err := s.rollout.ResumeRollout(ctx, req.RolloutId)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigRolloutReply(rollout), nil
+}
+
+// waitForAck 请求头中指定了等待设备确认的超时时间时，等待配置更新结束或者超时，并将结果填入响应中
+func (s *ConfigService) waitForAck(ctx context.Context, updateID string) (*pb.ConfigServiceReply, error) {
+	reply := &pb.ConfigServiceReply{Success: true, UpdateId: updateID}
+
+	var value string
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		value = tr.RequestHeader().Get(WAIT_FOR_ACK_HEADER)
+	}
+	if value == "" {
+		return reply, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return nil, errors.Newf(
+			400, "Service_Config_Error", "非法的%s请求头:%s", WAIT_FOR_ACK_HEADER, value)
+	}
+	if timeout > maxWaitForAck {
+		timeout = maxWaitForAck
+	}
+	// 不超过服务端的请求超时时间，并为查询配置更新最终的状态预留时间
+	if deadline, ok := ctx.Deadline(); ok {
+		if remain := time.Until(deadline) - time.Second; remain < timeout {
+			timeout = remain
+		}
+	}
+	if timeout <= 0 {
+		return reply, nil
+	}
+
+	status, err := s.updater.WaitForUpdateResult(ctx, updateID, timeout)
+	if err != nil {
+		return nil, err
+	}
+	reply.Status, reply.Error = status.Status, status.Error
+	reply.Success = status.Status == biz.UpdateStatusApplied
+	if !status.Done() {
+		reply.Error = fmt.Sprintf("等待设备确认配置更新超时，配置更新当前的状态为%s", status.Status)
+	}
+	return reply, nil
+}
+
+// withUpdateExpiry 请求头中指定了配置更新的截止时间时，返回携带了截止时间的ctx
+func withUpdateExpiry(ctx context.Context) (context.Context, error) {
+	var value string
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		value = tr.RequestHeader().Get(EXPIRES_AT_HEADER)
+	}
+	if value == "" {
+		return ctx, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Newf(
+			400, "Service_Config_Error", "非法的%s请求头:%s", EXPIRES_AT_HEADER, value)
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, errors.Newf(
+			400, "Service_Config_Error", "%s请求头指定的截止时间%s已经过去", EXPIRES_AT_HEADER, value)
+	}
+	return biz.WithUpdateExpiry(ctx, expiresAt), nil
+}
+
+// streamAttributes 流式rpc中各消息处理span的属性
+func streamAttributes(clientID string, info *biz.DeviceGeneralInfo) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("client.id", clientID),
+		attribute.Int("device.class_id", info.DeviceClassID),
+		attribute.String("device.id", info.DeviceID),
+	}
+}
+
+// newDiffReply 将修订之间存在差异的字段转换为响应
+func newDiffReply(diffs []*biz.ConfigFieldDiff) *pb.DiffDeviceConfigRevisionsReply {
+	return &pb.DiffDeviceConfigRevisionsReply{Diffs: newFieldDiffs(diffs)}
+}
+
+// newFieldDiffs 将存在差异的字段转换为响应中的形式
+func newFieldDiffs(diffs []*biz.ConfigFieldDiff) []*pb.DiffDeviceConfigRevisionsReply_FieldDiff {
+	fieldDiffs := make([]*pb.DiffDeviceConfigRevisionsReply_FieldDiff, 0, len(diffs))
+	for _, d := range diffs {
+		fieldDiffs = append(fieldDiffs, &pb.DiffDeviceConfigRevisionsReply_FieldDiff{
+			Field: d.Field,
+			From:  d.From,
+			To:    d.To,
+		})
+	}
+	return fieldDiffs
+}
+
+// newConfigFieldPredicates 解析查询设备配置的条件
+func newConfigFieldPredicates(filters []string) ([]*biz.ConfigFieldPredicate, error) {
+	predicates := make([]*biz.ConfigFieldPredicate, 0, len(filters))
+	for _, f := range filters {
+		p, err := biz.ParseConfigFieldPredicate(f)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+	return predicates, nil
+}
+
+// newDeviceSelector 将请求中选择设备的条件转换为biz层的形式
+func newDeviceSelector(selector *pb.DeviceSelector) *biz.DeviceSelector {
+	if selector == nil {
+		return nil
+	}
+	return &biz.DeviceSelector{
+		All:      selector.All,
+		Labels:   selector.Labels,
+		IDPrefix: selector.IdPrefix,
+	}
+}
+
+// newBulkUpdateJobReply 将批量更新任务的进度转换为响应
+func newBulkUpdateJobReply(job *biz.BulkUpdateJob) *pb.BulkUpdateJob {
+	return &pb.BulkUpdateJob{
+		JobId:         job.JobID,
+		DeviceClassId: int64(job.DeviceClassID),
+		Total:         job.Total,
+		Published:     job.Published,
+		Delivered:     job.Delivered,
+		Acked:         job.Acked,
+		Failed:        job.Failed,
+		Done:          job.Done,
+		CreateTime:    timestamppb.New(job.CreatedAt),
+	}
+}
+
+// newConfigRollout 将创建灰度发布的请求转换为biz层的形式
+func newConfigRollout(deviceClassID int, selector *pb.DeviceSelector, startTime *timestamppb.Timestamp,
+	stages []int64, ackTimeout string, maxFailures int64) (*biz.ConfigRollout, error) {
+	rollout := &biz.ConfigRollout{
+		DeviceClassID: deviceClassID,
+		Selector:      newDeviceSelector(selector),
+		MaxFailures:   maxFailures,
+	}
+	if startTime != nil {
+		rollout.StartTime = startTime.AsTime()
+	}
+	for _, percent := range stages {
+		rollout.Stages = append(rollout.Stages, &biz.RolloutStage{Percent: percent})
+	}
+	if ackTimeout != "" {
+		timeout, err := time.ParseDuration(ackTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, errors.Newf(400, "Service_Config_Error", "非法的ack_timeout:%s", ackTimeout)
+		}
+		rollout.AckTimeout = timeout
+	}
+	return rollout, nil
+}
+
+// newConfigRolloutReply 将灰度发布转换为响应
+func newConfigRolloutReply(rollout *biz.ConfigRollout) *pb.ConfigRollout {
+	reply := &pb.ConfigRollout{
+		RolloutId:     rollout.RolloutID,
+		DeviceClassId: int64(rollout.DeviceClassID),
+		Status:        rollout.Status,
+		StartTime:     timestamppb.New(rollout.StartTime),
+		Stages:        make([]*pb.ConfigRollout_Stage, 0, len(rollout.Stages)),
+		CurrentStage:  int64(rollout.CurrentStage),
+		Total:         int64(len(rollout.DeviceIDs)),
+		HaltReason:    rollout.HaltReason,
+		AckTimeout:    rollout.AckTimeout.String(),
+		MaxFailures:   rollout.MaxFailures,
+		CreateTime:    timestamppb.New(rollout.CreatedAt),
+		UpdateTime:    timestamppb.New(rollout.UpdatedAt),
+	}
+	for _, stage := range rollout.Stages {
+		s := &pb.ConfigRollout_Stage{
+			Percent: stage.Percent,
+			Devices: stage.Devices,
+			JobId:   stage.JobID,
+		}
+		if !stage.StartTime.IsZero() {
+			s.StartTime = timestamppb.New(stage.StartTime)
+		}
+		reply.Stages = append(reply.Stages, s)
+	}
+	return reply
+}
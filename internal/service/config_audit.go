@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	pb "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ACTOR_HEADER 由认证网关在认证通过后注入的调用者身份的请求头，配置审计记录以其作为调用者
+const ACTOR_HEADER = "x-actor"
+
+// 导出审计记录时单次查询的记录数
+const exportAuditPageSize = 1000
+
+// AuditActor 从请求头以及连接中提取调用者身份以及来源ip并保存到ctx中，用于记录配置变更的审计记录。
+// 只有直接建立连接的对端属于c中配置的受信任代理时，才以x-actor请求头(其次为Basic认证的用户名)作为调用者身份、
+// 以x-forwarded-for请求头作为来源ip，否则调用者身份为空，来源ip为对端ip。未配置受信任代理时启动时打印警告
+func AuditActor(c *conf.Server_Audit, logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(logger)
+	proxies := newTrustedProxies(c.GetTrustedProxies(), helper)
+	if len(proxies) == 0 {
+		helper.Warn("未配置受信任的认证网关(server.audit.trustedProxies)，配置审计记录中通过api发起的配置变更的调用者身份均为空")
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return handler(biz.WithAuditActor(ctx, requestAuditActor(ctx, proxies)), req)
+		}
+	}
+}
+
+// trustedProxies 受信任的认证网关或者反向代理的地址
+type trustedProxies []*net.IPNet
+
+// newTrustedProxies 解析以ip或者CIDR表示的受信任代理，不合法的地址被忽略
+func newTrustedProxies(addrs []string, logger *log.Helper) trustedProxies {
+	var proxies trustedProxies
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if !strings.Contains(addr, "/") {
+			if ip := net.ParseIP(addr); ip != nil {
+				bits := 8 * net.IPv4len
+				if ip.To4() == nil {
+					bits = 8 * net.IPv6len
+				}
+				proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		} else if _, n, err := net.ParseCIDR(addr); err == nil {
+			proxies = append(proxies, n)
+			continue
+		}
+		logger.Warnf("忽略了不合法的受信任代理地址 %q", addr)
+	}
+	return proxies
+}
+
+// contains 判断ip是否属于受信任的代理
+func (p trustedProxies) contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range p {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestAuditActor 提取请求的调用者身份以及来源ip，调用者身份相关的请求头只在对端为受信任的代理时采信
+func requestAuditActor(ctx context.Context, proxies trustedProxies) *biz.AuditActor {
+	actor := &biz.AuditActor{PeerIP: peerIP(ctx)}
+	tr, ok := transport.FromServerContext(ctx)
+	if ok {
+		if ht, ok := tr.(*khttp.Transport); ok {
+			actor.PeerIP = hostOf(ht.Request().RemoteAddr)
+		}
+	}
+	actor.SourceIP = actor.PeerIP
+	if !ok || !proxies.contains(actor.PeerIP) {
+		return actor
+	}
+
+	header := tr.RequestHeader()
+	actor.ID = header.Get(ACTOR_HEADER)
+	if actor.ID == "" {
+		actor.ID = basicAuthUser(header.Get("authorization"))
+	}
+	if forwarded := header.Get("x-forwarded-for"); forwarded != "" {
+		actor.SourceIP = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return actor
+}
+
+// streamAuditActor 设备经由grpc流产生的配置变更以clientID作为调用者身份
+func streamAuditActor(ctx context.Context, clientID string) *biz.AuditActor {
+	ip := peerIP(ctx)
+	return &biz.AuditActor{ID: clientID, SourceIP: ip, PeerIP: ip}
+}
+
+// basicAuthUser 返回Basic认证的用户名，不是Basic认证时返回空字符串
+func basicAuthUser(authorization string) string {
+	const prefix = "basic "
+	if len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(authorization[len(prefix):])
+	if err != nil {
+		return ""
+	}
+	return strings.SplitN(string(decoded), ":", 2)[0]
+}
+
+// peerIP 返回grpc连接对端的ip
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return hostOf(p.Addr.String())
+	}
+	return ""
+}
+
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// newConfigAuditFilter 将查询审计记录的请求参数转换为查询条件
+func newConfigAuditFilter(deviceID string, start, end *timestamppb.Timestamp) (*biz.ConfigAuditFilter, error) {
+	filter := &biz.ConfigAuditFilter{DeviceID: deviceID}
+	if start != nil {
+		filter.Start = start.AsTime()
+	}
+	if end != nil {
+		filter.End = end.AsTime()
+	}
+	if !filter.Start.IsZero() && !filter.End.IsZero() && filter.End.Before(filter.Start) {
+		return nil, errors.New(400, "Service_Config_Error", "审计记录的结束时间不能早于开始时间")
+	}
+	return filter, nil
+}
+
+func newConfigAuditRecord0(r *biz.ConfigAuditRecord) (*pb.ConfigAuditRecord0, error) {
+	record := &pb.ConfigAuditRecord0{
+		Id:        r.ID,
+		Time:      timestamppb.New(r.Time),
+		Actor:     r.Actor,
+		SourceIp:  r.SourceIP,
+		PeerIp:    r.PeerIP,
+		Operation: r.Operation,
+		DeviceId:  r.DeviceID,
+		UpdateId:  r.UpdateID,
+		Result:    r.Result,
+		Error:     r.Error,
+	}
+	oldConfig, newConfig := new(pb.DeviceConfig0), new(pb.DeviceConfig0)
+	hasOld, hasNew, err := r.UnmarshalConfigs(oldConfig, newConfig)
+	if err != nil {
+		return nil, err
+	}
+	if hasOld {
+		record.OldConfig = oldConfig
+	}
+	if hasNew {
+		record.NewConfig = newConfig
+	}
+	return record, nil
+}
+
+func newConfigAuditRecord1(r *biz.ConfigAuditRecord) (*pb.ConfigAuditRecord1, error) {
+	record := &pb.ConfigAuditRecord1{
+		Id:        r.ID,
+		Time:      timestamppb.New(r.Time),
+		Actor:     r.Actor,
+		SourceIp:  r.SourceIP,
+		PeerIp:    r.PeerIP,
+		Operation: r.Operation,
+		DeviceId:  r.DeviceID,
+		UpdateId:  r.UpdateID,
+		Result:    r.Result,
+		Error:     r.Error,
+	}
+	oldConfig, newConfig := new(pb.DeviceConfig1), new(pb.DeviceConfig1)
+	hasOld, hasNew, err := r.UnmarshalConfigs(oldConfig, newConfig)
+	if err != nil {
+		return nil, err
+	}
+	if hasOld {
+		record.OldConfig = oldConfig
+	}
+	if hasNew {
+		record.NewConfig = newConfig
+	}
+	return record, nil
+}
+
+// ExportConfigAuditRecords 以每行一个json对象的形式导出设备类别下的审计记录，用于合规审计的归档。
+// 查询参数包括必填的device_class_id，以及可选的device_id、RFC3339格式的start_time与end_time
+func (s *ConfigService) ExportConfigAuditRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	deviceClassID, err := strconv.Atoi(query.Get("device_class_id"))
+	if err != nil {
+		http.Error(w, "非法的设备类别号:"+query.Get("device_class_id"), http.StatusBadRequest)
+		return
+	}
+	filter := &biz.ConfigAuditFilter{DeviceID: query.Get("device_id")}
+	for _, t := range []struct {
+		name  string
+		value *time.Time
+	}{{"start_time", &filter.Start}, {"end_time", &filter.End}} {
+		if v := query.Get(t.name); v != "" {
+			if *t.value, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, fmt.Sprintf("非法的%s:%s", t.name, v), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// 各设备类别的审计记录转换函数，代码生成时注入
+	var convert func(*biz.ConfigAuditRecord) (proto.Message, error)
+	switch deviceClassID {
+	case 0:
+		convert = func(r *biz.ConfigAuditRecord) (proto.Message, error) { return newConfigAuditRecord0(r) }
+	case 1:
+		convert = func(r *biz.ConfigAuditRecord) (proto.Message, error) { return newConfigAuditRecord1(r) }
+	default:
+		http.Error(w, fmt.Sprintf("设备类别 %d 不存在", deviceClassID), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=config_audit_%d.jsonl", deviceClassID))
+	var pageToken string
+	for {
+		records, next, err := s.uc.ListConfigAuditRecords(r.Context(), deviceClassID, filter, exportAuditPageSize, pageToken)
+		if err != nil && pageToken == "" {
+			http.Error(w, err.Error(), int(errors.Code(err)))
+			return
+		}
+		if err != nil {
+			// 响应头已经发送时无法再返回错误码，只能中断导出
+			s.logger.Errorf("导出设备类别 %d 的审计记录时发生了错误:%v", deviceClassID, err)
+			return
+		}
+		for _, record := range records {
+			m, err := convert(record)
+			if err == nil {
+				// 导出端点不经过middleware，在这里将敏感字段替换为掩码
+				biz.MaskSensitiveFields(m)
+				var line []byte
+				if line, err = protojson.Marshal(m); err == nil {
+					_, err = w.Write(append(line, '\n'))
+				}
+			}
+			if err != nil {
+				s.logger.Errorf("导出设备类别 %d 的审计记录时发生了错误:%v", deviceClassID, err)
+				return
+			}
+		}
+		if next == "" {
+			return
+		}
+		pageToken = next
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// MaskSensitiveFields 将响应中设备配置的敏感字段替换为掩码。middleware只作用于一元调用，
+// 因此经由配置更新流下发给设备的配置不受影响
+func MaskSensitiveFields() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			if m, ok := reply.(proto.Message); ok && err == nil {
+				biz.MaskSensitiveFields(m)
+			}
+			return reply, err
+		}
+	}
+}
+
+// Logging 与kratos的logging.Server相同地记录每个请求的日志，区别在于日志中请求参数的敏感字段以掩码替换
+func Logging(logger log.Logger) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			var (
+				code      int32
+				reason    string
+				kind      string
+				operation string
+				level     = log.LevelInfo
+				stack     string
+			)
+			startTime := time.Now()
+			if info, ok := transport.FromServerContext(ctx); ok {
+				kind = info.Kind().String()
+				operation = info.Operation()
+			}
+			reply, err = handler(ctx, req)
+			if se := errors.FromError(err); se != nil {
+				code = se.Code
+				reason = se.Reason
+			}
+			if err != nil {
+				level, stack = log.LevelError, fmt.Sprintf("%+v", err)
+			}
+			_ = log.WithContext(ctx, logger).Log(level,
+				"kind", "server",
+				"component", kind,
+				"operation", operation,
+				"args", maskedArgs(req),
+				"code", code,
+				"reason", reason,
+				"stack", stack,
+				"latency", time.Since(startTime).Seconds(),
+			)
+			return
+		}
+	}
+}
+
+// maskedArgs 返回敏感字段被替换为掩码的请求参数的字符串形式
+func maskedArgs(req interface{}) string {
+	if m, ok := req.(proto.Message); ok {
+		m = proto.Clone(m)
+		biz.MaskSensitiveFields(m)
+		return fmt.Sprint(m)
+	}
+	if stringer, ok := req.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%+v", req)
+}
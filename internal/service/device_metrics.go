@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+)
+
+// 设备读数指标名前缀的缺省值，与remote_write输出的指标名保持一致
+const defaultDeviceMetricPrefix = "device_state"
+
+// 设备读数指标使用的标签名
+var deviceMetricLabelNames = []string{"user", "deviceClassID", "deviceID"}
+
+// DeviceMetricsService 以prometheus gauge的形式暴露各设备预警字段的最新读数，
+// 与服务自身的监控指标相互独立，使用单独的registry
+type DeviceMetricsService struct {
+	readings *biz.DeviceReadingCache
+	prefix   string
+	// 设备最近一次上报状态的时间
+	lastReportDesc *prometheus.Desc
+	handler        http.Handler
+	logger         *log.Helper
+}
+
+func NewDeviceMetricsService(c *conf.Server, readings *biz.DeviceReadingCache, logger log.Logger) *DeviceMetricsService {
+	prefix := c.DeviceMetrics.GetMetricPrefix()
+	if prefix == "" {
+		prefix = defaultDeviceMetricPrefix
+	}
+
+	s := &DeviceMetricsService{
+		readings: readings,
+		prefix:   prefix,
+		lastReportDesc: prometheus.NewDesc(
+			prefix+"_last_report_timestamp_seconds",
+			"设备最近一次上报的状态信息中携带的时间",
+			deviceMetricLabelNames, nil,
+		),
+		logger: log.NewHelper(logger),
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s)
+	s.handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:      s,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+
+	return s
+}
+
+// ServeHTTP 响应prometheus的抓取请求
+func (s *DeviceMetricsService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Describe 由于预警字段在运行时才能确定，这里不提供指标描述，即作为unchecked collector注册
+func (s *DeviceMetricsService) Describe(chan<- *prometheus.Desc) {}
+
+// Collect 将缓存中未过期的设备读数转换为gauge
+func (s *DeviceMetricsService) Collect(ch chan<- prometheus.Metric) {
+	s.readings.Range(func(reading *biz.DeviceReading) {
+		labels := []string{
+			conf.Username,
+			strconv.Itoa(reading.DeviceClassID),
+			reading.DeviceID,
+		}
+
+		for field, value := range reading.Fields {
+			desc := prometheus.NewDesc(
+				fmt.Sprintf("%s_%s", s.prefix, field),
+				fmt.Sprintf("设备预警字段%s的最新读数", field),
+				deviceMetricLabelNames, nil,
+			)
+			metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+			if err != nil {
+				s.logger.Errorf("创建设备 %s 的字段 %s 的指标时发生了错误:%v", reading.DeviceID, field, err)
+				continue
+			}
+			ch <- metric
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			s.lastReportDesc,
+			prometheus.GaugeValue,
+			float64(reading.Time.UnixNano())/1e9,
+			labels...,
+		)
+	})
+}
+
+// Println 实现promhttp.Logger接口，记录处理抓取请求时发生的错误
+func (s *DeviceMetricsService) Println(v ...interface{}) {
+	s.logger.Error(v...)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	pb "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+)
+
+// grpc健康检查协议中各方法的全名
+const (
+	healthCheckMethod = "/grpc.health.v1.Health/Check"
+	healthWatchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+// HealthService 通过grpc健康检查协议以及http的/healthz、/readyz暴露服务的存活与就绪状态，
+// 其中存活状态不受依赖组件影响，就绪状态在redis或influxdb不可用时下降
+type HealthService struct {
+	uc *biz.HealthUsecase
+	// kratos内置的health服务始终返回SERVING，这里通过拦截器将健康检查请求转交给
+	// 反映依赖组件状态的health.Server
+	grpcHealth *health.Server
+	logger     *log.Helper
+}
+
+// healthResponse /healthz以及/readyz的响应体
+type healthResponse struct {
+	Status       string                 `json:"status"`
+	Dependencies []biz.DependencyStatus `json:"dependencies"`
+}
+
+func NewHealthService(uc *biz.HealthUsecase, logger log.Logger) *HealthService {
+	s := &HealthService{
+		uc:         uc,
+		grpcHealth: health.NewServer(),
+		logger:     log.NewHelper(logger),
+	}
+	uc.Watch(s.setServingStatus)
+	return s
+}
+
+// setServingStatus 按照就绪状态设置整个服务以及各个grpc服务的状态
+func (s *HealthService) setServingStatus(ready bool) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !ready {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range []string{
+		"",
+		pb.Config_ServiceDesc.ServiceName,
+		pb.WarningDetect_ServiceDesc.ServiceName,
+	} {
+		s.grpcHealth.SetServingStatus(service, status)
+	}
+}
+
+// Healthz 存活检查，只要进程能够响应请求即返回200
+func (s *HealthService) Healthz(w http.ResponseWriter, r *http.Request) {
+	s.writeResponse(w, http.StatusOK, "ok")
+}
+
+// Readyz 就绪检查，任一依赖组件不可用时返回503
+func (s *HealthService) Readyz(w http.ResponseWriter, r *http.Request) {
+	if s.uc.Ready() {
+		s.writeResponse(w, http.StatusOK, "ready")
+	} else {
+		s.writeResponse(w, http.StatusServiceUnavailable, "not ready")
+	}
+}
+
+func (s *HealthService) writeResponse(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(&healthResponse{
+		Status:       status,
+		Dependencies: s.uc.Statuses(),
+	})
+	if err != nil {
+		s.logger.Errorf("写入健康检查的响应时发生了错误:%v", err)
+	}
+}
+
+// UnaryServerInterceptor 拦截grpc健康检查协议的Check请求
+func (s *HealthService) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == healthCheckMethod {
+			return s.grpcHealth.Check(ctx, req.(*grpc_health_v1.HealthCheckRequest))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 拦截grpc健康检查协议的Watch请求
+func (s *HealthService) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.FullMethod == healthWatchMethod {
+			req := new(grpc_health_v1.HealthCheckRequest)
+			if err := ss.RecvMsg(req); err != nil {
+				return err
+			}
+			return s.grpcHealth.Watch(req, &healthWatchServer{ss})
+		}
+		return handler(srv, ss)
+	}
+}
+
+// healthWatchServer 将grpc.ServerStream适配为Health_WatchServer
+type healthWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *healthWatchServer) Send(m *grpc_health_v1.HealthCheckResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
@@ -4,22 +4,28 @@ import (
 	"context"
 	pb "gitee.com/moyusir/data-collection/api/dataCollection/v1"
 	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/monitor"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 	"io"
+	"strconv"
 )
 
 type WarningDetectService struct {
 	pb.UnimplementedWarningDetectServer
 	uc      *biz.WarningDetectUsecase
+	config  *biz.ConfigUsecase
 	updater *biz.DeviceConfigUpdater
 	logger  *log.Helper
 }
 
-func NewWarningDetectService(uc *biz.WarningDetectUsecase, updater *biz.DeviceConfigUpdater, logger log.Logger) *WarningDetectService {
+func NewWarningDetectService(uc *biz.WarningDetectUsecase, config *biz.ConfigUsecase,
+	updater *biz.DeviceConfigUpdater, logger log.Logger) *WarningDetectService {
 	return &WarningDetectService{
 		uc:      uc,
+		config:  config,
 		updater: updater,
 		logger:  log.NewHelper(logger),
 	}
@@ -37,6 +43,8 @@ func (s *WarningDetectService) CreateStateInfoSaveStream0(conn pb.WarningDetect_
 		}
 		// 设备非时间，非id的字段，代码生成时注入
 		tags = map[string]string{}
+		// 当前流中已经检查过是否需要下发配置模板的设备
+		templateChecked = make(map[string]bool)
 	)
 
 	// 检查请求头中是否包含clientID
@@ -45,7 +53,7 @@ func (s *WarningDetectService) CreateStateInfoSaveStream0(conn pb.WarningDetect_
 		clientID = value[0]
 	} else {
 		// 若请求头中不存在，则申请创建新的clientID，通过响应头并发送给客户端
-		id, err := s.updater.CreateClientID()
+		id, err := s.updater.CreateClientID(conn.Context())
 		if err != nil {
 			return err
 		} else {
@@ -63,6 +71,13 @@ func (s *WarningDetectService) CreateStateInfoSaveStream0(conn pb.WarningDetect_
 
 	s.logger.Infof("与 %v 建立了传输设备状态信息的grpc流", clientID)
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateStateInfoSaveStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+	// 首次出现的设备下发配置模板的审计记录以clientID作为调用者
+	streamCtx := biz.WithAuditActor(monitor.StreamContext(conn.Context()), streamAuditActor(conn.Context(), clientID))
+
 	for {
 		var (
 			state *pb.DeviceState0
@@ -88,6 +103,7 @@ func (s *WarningDetectService) CreateStateInfoSaveStream0(conn pb.WarningDetect_
 					500, "Service_State_Error",
 					"接收用户 %v 传输的设备状态信息时发生了错误:%v", clientID, err)
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
 			// 提取设备状态信息进行路由激活以及保存
 			info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID}
 			info.DeviceID = state.Id
@@ -95,22 +111,44 @@ func (s *WarningDetectService) CreateStateInfoSaveStream0(conn pb.WarningDetect_
 			fields["Voltage"] = state.Voltage
 			fields["Current"] = state.Current
 
+			// 流中的每条消息使用单独的span追踪
+			ctx, span := monitor.StartSpan(streamCtx, rpc+".Recv",
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(streamAttributes(clientID, info)...),
+			)
+
 			// TODO 考虑路由激活以及保存设备状态出错时如何处理
-			err = s.updater.ConnectDeviceAndClientID(clientID, info)
+			err = s.updater.ConnectDeviceAndClientID(ctx, clientID, info)
 			if err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
-			err = s.uc.SaveDeviceState(info, state.Time.AsTime(), fields, tags)
+			// 首次出现且没有任何已保存配置的设备，以设备类别的配置模板作为其desired配置并下发，下发失败时只打印日志。
+			// 每个设备在一条流中只检查一次，下发失败的设备在重新建立流后再次检查
+			if !templateChecked[info.DeviceID] {
+				templateChecked[info.DeviceID] = true
+				if _, err := s.config.ApplyConfigTemplate(ctx, info, new(pb.DeviceConfig0)); err != nil {
+					s.logger.Errorf("向设备 %v 下发配置模板时发生了错误:%v", info.DeviceID, err)
+				}
+			}
+			err = s.uc.SaveDeviceState(ctx, info, state.Time.AsTime(), fields, tags)
 			if err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
 
 			err = conn.Send(&pb.WarningDetectServiceReply{Success: true})
 			if err != nil {
-				return errors.Newf(
+				err = errors.Newf(
 					500, "Service_State_Error",
 					"向用户 %v 发送传输设备状态的响应信息时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
+			monitor.EndSpan(span, nil)
 		}
 	}
 }
@@ -127,6 +165,8 @@ func (s *WarningDetectService) CreateStateInfoSaveStream1(conn pb.WarningDetect_
 		}
 		// 设备非时间，非id的字段，代码生成时注入
 		tags = map[string]string{}
+		// 当前流中已经检查过是否需要下发配置模板的设备
+		templateChecked = make(map[string]bool)
 	)
 
 	// 检查请求头中是否包含clientID
@@ -135,7 +175,7 @@ func (s *WarningDetectService) CreateStateInfoSaveStream1(conn pb.WarningDetect_
 		clientID = value[0]
 	} else {
 		// 若请求头中不存在，则申请创建新的clientID，通过响应头并发送给客户端
-		id, err := s.updater.CreateClientID()
+		id, err := s.updater.CreateClientID(conn.Context())
 		if err != nil {
 			return err
 		} else {
@@ -153,6 +193,13 @@ func (s *WarningDetectService) CreateStateInfoSaveStream1(conn pb.WarningDetect_
 
 	s.logger.Infof("与 %v 建立了传输设备状态信息的grpc流", clientID)
 
+	// 统计活跃的流以及流中消息的处理结果
+	rpc, class := "CreateStateInfoSaveStream", strconv.Itoa(deviceClassID)
+	monitor.ActiveStreams.WithLabelValues(rpc, class).Inc()
+	defer monitor.ActiveStreams.WithLabelValues(rpc, class).Dec()
+	// 首次出现的设备下发配置模板的审计记录以clientID作为调用者
+	streamCtx := biz.WithAuditActor(monitor.StreamContext(conn.Context()), streamAuditActor(conn.Context(), clientID))
+
 	for {
 		var (
 			state *pb.DeviceState1
@@ -178,6 +225,7 @@ func (s *WarningDetectService) CreateStateInfoSaveStream1(conn pb.WarningDetect_
 					500, "Service_State_Error",
 					"接收用户 %v 传输的设备状态信息时发生了错误:%v", clientID, err)
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultReceived).Inc()
 			// 提取设备状态信息进行路由激活以及保存
 			info := &biz.DeviceGeneralInfo{DeviceClassID: deviceClassID}
 			info.DeviceID = state.Id
@@ -185,22 +233,44 @@ func (s *WarningDetectService) CreateStateInfoSaveStream1(conn pb.WarningDetect_
 			fields["Voltage"] = state.Voltage
 			fields["Current"] = state.Current
 
+			// 流中的每条消息使用单独的span追踪
+			ctx, span := monitor.StartSpan(streamCtx, rpc+".Recv",
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(streamAttributes(clientID, info)...),
+			)
+
 			// TODO 考虑路由激活以及保存设备状态出错时如何处理
-			err = s.updater.ConnectDeviceAndClientID(clientID, info)
+			err = s.updater.ConnectDeviceAndClientID(ctx, clientID, info)
 			if err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
-			err = s.uc.SaveDeviceState(info, state.Time.AsTime(), fields, tags)
+			// 首次出现且没有任何已保存配置的设备，以设备类别的配置模板作为其desired配置并下发，下发失败时只打印日志。
+			// 每个设备在一条流中只检查一次，下发失败的设备在重新建立流后再次检查
+			if !templateChecked[info.DeviceID] {
+				templateChecked[info.DeviceID] = true
+				if _, err := s.config.ApplyConfigTemplate(ctx, info, new(pb.DeviceConfig1)); err != nil {
+					s.logger.Errorf("向设备 %v 下发配置模板时发生了错误:%v", info.DeviceID, err)
+				}
+			}
+			err = s.uc.SaveDeviceState(ctx, info, state.Time.AsTime(), fields, tags)
 			if err != nil {
+				monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultRejected).Inc()
+				monitor.EndSpan(span, err)
 				return err
 			}
 
 			err = conn.Send(&pb.WarningDetectServiceReply{Success: true})
 			if err != nil {
-				return errors.Newf(
+				err = errors.Newf(
 					500, "Service_State_Error",
 					"向用户 %v 发送传输设备状态的响应信息时发生了错误:%v", clientID, err)
+				monitor.EndSpan(span, err)
+				return err
 			}
+			monitor.StreamMessages.WithLabelValues(rpc, class, monitor.ResultAcked).Inc()
+			monitor.EndSpan(span, nil)
 		}
 	}
 }
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"testing"
+	"time"
+)
+
+// 测试依据标签以及id前缀选择设备，并在后台下发批量更新、汇总下发进度
+func TestConfigUsecase_BulkUpdate(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, &conf.Data{BulkUpdate: &conf.Data_BulkUpdate{Rate: 1000}})
+		labels            = map[string]string{"a_1": "east", "a_2": "west", "b_1": "east"}
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for id, zone := range labels {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: id}, biz.RevisionSourceInitial, "test_1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := uc.SetDeviceLabels(ctx, info, map[string]string{"zone": zone}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &v1.DeviceConfig0{Status: true}
+
+	if got, err := uc.GetDeviceLabels(ctx, &biz.DeviceGeneralInfo{DeviceID: "a_1"}); err != nil || got["zone"] != "east" {
+		t.Fatalf("unexpected labels:%v %v", got, err)
+	}
+	// 标签以json保存，而不是像设备配置一样经过编码
+	stored, err := repo.BatchGetDeviceLabels(ctx, biz.GetDeviceLabelKey(&biz.DeviceGeneralInfo{}), "a_1")
+	if err != nil || stored[0] != `{"zone":"east"}` {
+		t.Fatalf("unexpected stored labels:%v %v", stored, err)
+	}
+	if _, err := uc.BulkUpdateDeviceConfig(ctx, 0, &biz.DeviceSelector{}, config); errors.Code(err) != 400 {
+		t.Fatalf("expected 400 for an empty selector,got %v", err)
+	}
+	if _, err := uc.BulkUpdateDeviceConfig(ctx, 0, &biz.DeviceSelector{IDPrefix: "c_"}, config); errors.Code(err) != 404 {
+		t.Fatalf("expected 404 when no device matches,got %v", err)
+	}
+
+	// 标签与id前缀同时作为条件
+	job, err := uc.BulkUpdateDeviceConfig(ctx, 0, &biz.DeviceSelector{
+		Labels:   map[string]string{"zone": "east"},
+		IDPrefix: "a_",
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Total != 1 {
+		t.Fatalf("expected 1 device to be selected,got %d", job.Total)
+	}
+	if update := <-updates; update.Config.(*v1.DeviceConfig0).Id != "a_1" {
+		t.Fatalf("unexpected update:%v", update.Config)
+	}
+
+	job, err = uc.BulkUpdateDeviceConfig(ctx, 0, &biz.DeviceSelector{All: true}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Total != 3 {
+		t.Fatalf("expected 3 devices to be selected,got %d", job.Total)
+	}
+	// 模拟客户端，应用a_*设备的配置更新，拒绝其余设备的配置更新
+	for i := 0; i < 3; i++ {
+		update := <-updates
+		c := update.Config.(*v1.DeviceConfig0)
+		if !c.Status {
+			t.Fatalf("unexpected update:%v", c)
+		}
+		if c.Id[0] == 'a' {
+			updater.SetUpdateStatus(ctx, update, biz.UpdateStatusApplied, nil)
+		} else {
+			updater.SetUpdateStatus(ctx, update, biz.UpdateStatusFailed, errors.New(400, "", "rejected"))
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		job, err = uc.GetBulkUpdateJob(ctx, job.JobID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !job.Done || job.Published != 3 || job.Delivered != 2 || job.Acked != 2 || job.Failed != 1 {
+		t.Fatalf("unexpected job progress:%+v", job)
+	}
+
+	if _, err := uc.GetBulkUpdateJob(ctx, "unknown"); errors.Code(err) != 404 {
+		t.Fatalf("expected 404 for an unknown job,got %v", err)
+	}
+}
+
+// 测试实例关闭时中断的批量更新任务被标记为结束，以及执行实例退出后遗留的任务在启动时被标记为结束
+func TestConfigUsecase_BulkUpdateInterrupted(t *testing.T) {
+	var (
+		c       = &conf.Data{BulkUpdate: &conf.Data_BulkUpdate{Rate: 1}}
+		repo    = newMemoryRepo()
+		updater = biz.NewDeviceConfigUpdater(c, repo, log.DefaultLogger)
+		ctx     = context.Background()
+	)
+	uc, cleanup := biz.NewConfigUsecase(c, repo, updater, log.DefaultLogger)
+	for _, id := range []string{"a_1", "a_2", "a_3"} {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: id}, biz.RevisionSourceInitial, "test_1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 每秒只下发一个配置更新，收到第一个配置更新后关闭实例
+	job, err := uc.BulkUpdateDeviceConfig(ctx, 0, &biz.DeviceSelector{All: true}, &v1.DeviceConfig0{Status: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-updates
+	cleanup()
+	job, err = uc.GetBulkUpdateJob(ctx, job.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !job.Done || job.Published != 1 || job.Failed != 2 {
+		t.Fatalf("expected the interrupted job to be done with 2 failed devices,got %+v", job)
+	}
+	if active, err := repo.GetSetMembers(ctx, biz.GetActiveBulkUpdateJobsKey()); err != nil || len(active) != 0 {
+		t.Fatalf("expected no active job,got %v %v", active, err)
+	}
+
+	// 模拟执行实例已经退出的任务以及仍由其余实例执行的任务
+	for _, id := range []string{"crashed", "running"} {
+		err := repo.SaveBulkUpdateJob(ctx, biz.GetBulkUpdateJobKey(id), map[string]string{
+			"device_class_id": "0",
+			"total":           "5",
+			"published":       "2",
+			"publish_failed":  "0",
+			"done":            "false",
+		}, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddSetMember(ctx, biz.GetActiveBulkUpdateJobsKey(), id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.AcquireLock(ctx, biz.GetBulkUpdateJobLockKey("running"), "other", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	uc, cleanup = biz.NewConfigUsecase(c, repo, updater, log.DefaultLogger)
+	deadline := time.Now().Add(time.Second)
+	for {
+		job, err = uc.GetBulkUpdateJob(ctx, "crashed")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// 等待启动时的检查结束
+	cleanup()
+	if !job.Done || job.Failed != 3 {
+		t.Fatalf("expected the orphaned job to be done with 3 failed devices,got %+v", job)
+	}
+	if job, err = uc.GetBulkUpdateJob(ctx, "running"); err != nil || job.Done {
+		t.Fatalf("expected the job locked by another instance to keep running,got %+v %v", job, err)
+	}
+	active, err := repo.GetSetMembers(ctx, biz.GetActiveBulkUpdateJobsKey())
+	if err != nil || len(active) != 1 || active[0] != "running" {
+		t.Fatalf("expected only the running job to stay active,got %v %v", active, err)
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 测试导出的设备配置备份恢复到另一个集群后与原集群一致，并且重新下发的配置更新序号在备份的序号之上递增
+func TestConfigUsecase_BackupAndRestore(t *testing.T) {
+	export := func(uc *biz.ConfigUsecase) []*biz.DeviceConfigBackup {
+		var backups []*biz.DeviceConfigBackup
+		err := uc.ExportDeviceConfigs(context.Background(), 0, func(b *biz.DeviceConfigBackup) error {
+			backups = append(backups, b)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return backups
+	}
+
+	var (
+		_, updater, source       = newTestConfigUsecase(t, nil)
+		_, targetUpdater, target = newTestConfigUsecase(t, nil)
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// device_1既有上报配置也有desired配置，device_2只有desired配置
+	for _, id := range []string{"device_1", "device_2"} {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := source.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: id, Status: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	err := source.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, biz.RevisionSourceInitial, "test_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backups := export(source)
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 device backups,got %d", len(backups))
+	}
+	b := backups[0]
+	if b.DeviceID != "device_1" || b.Reported == nil || b.Desired == nil || b.ClientID != "test_1" ||
+		b.Seq != 1 || b.Version != 2 || b.DesiredRevision != 1 || len(b.Revisions) != 2 ||
+		b.Revisions[0].Source != biz.RevisionSourceUpdate {
+		t.Fatalf("unexpected backup %+v", b)
+	}
+	if backups[1].Reported != nil || backups[1].Desired == nil {
+		t.Fatalf("unexpected backup %+v", backups[1])
+	}
+
+	for _, b := range backups {
+		if err := target.ImportDeviceConfig(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if restored := export(target); !reflect.DeepEqual(restored, backups) {
+		t.Fatalf("restored backups differ from the exported ones:%+v", restored)
+	}
+
+	// 重新下发恢复的desired配置
+	updates, err := targetUpdater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := target.RepublishDeviceConfig(ctx, info, new(v1.DeviceConfig0)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case u := <-updates:
+		if c := u.Config.(*v1.DeviceConfig0); c.Id != info.DeviceID || !c.Status || c.Version != 2 {
+			t.Fatalf("unexpected republished config %v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the restored config to be republished")
+	}
+}
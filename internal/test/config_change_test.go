@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试设备上报的配置发生变化时发布包含字段差异的配置变更事件，配置未变化时不发布
+func TestConfigUsecase_ConfigChange(t *testing.T) {
+	var (
+		repo, _, uc = newTestConfigUsecase(t, nil)
+		ctx         = context.Background()
+		info        = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	for _, config := range []*v1.DeviceConfig0{
+		{Id: info.DeviceID},
+		{Id: info.DeviceID},
+		{Id: info.DeviceID, Status: true},
+	} {
+		if err := uc.SaveDeviceConfig(ctx, info, config, biz.RevisionSourceInitial, "test_1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events := repo.configChanges(biz.GetConfigChangeStreamKey())
+	if len(events) != 2 {
+		t.Fatalf("expected 2 change events,got %d", len(events))
+	}
+	created, changed := events[0], events[1]
+	if !created.Created || created.DeviceID != info.DeviceID || created.ClientID != "test_1" ||
+		created.Source != biz.RevisionSourceInitial || created.Revision != 1 {
+		t.Fatalf("unexpected event %+v", created)
+	}
+	if changed.Created || changed.Revision != 3 || len(changed.Diff) != 1 ||
+		changed.Diff[0].Field != "status" || changed.Diff[0].To != "true" {
+		t.Fatalf("unexpected event %+v", changed)
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal(changed.Config, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config["status"] != true {
+		t.Fatalf("unexpected config of the event:%s", changed.Config)
+	}
+}
+
+// 测试配置变更事件只发送给订阅了相应设备类别的webhook，请求携带事件id以及签名，失败时进行重试
+func TestConfigWebhookData_Send(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+		bodies   [][]byte
+		headers  []http.Header
+	)
+	subscribed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		// 第一次请求失败，由客户端重试
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies, headers = append(bodies, body), append(headers, r.Header)
+	}))
+	defer subscribed.Close()
+	unsubscribed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request to the webhook of another device class")
+	}))
+	defer unsubscribed.Close()
+
+	secret := "secret"
+	webhook, cleanup, err := data.NewConfigWebhookData(&conf.Data{ConfigChange: &conf.Data_ConfigChange{
+		Webhooks: []*conf.Data_ConfigChange_Webhook{
+			{Url: subscribed.URL, Secret: secret, DeviceClassIds: []int32{0}},
+			{Url: unsubscribed.URL, DeviceClassIds: []int32{1}},
+		},
+	}}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook.Send(&biz.ConfigChangeEvent{
+		ID:            "1-1",
+		Time:          time.Now(),
+		DeviceClassID: 0,
+		DeviceID:      "device_1",
+		Source:        biz.RevisionSourceAck,
+		Config:        []byte(`{"id":"device_1","status":true}`),
+		Diff:          []*biz.ConfigFieldDiff{{Field: "status", From: "false", To: "true"}},
+	})
+	// 等待队列中的事件发送完毕
+	cleanup()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if requests != 2 || len(bodies) != 1 {
+		t.Fatalf("expected 2 requests with 1 delivered event,got %d requests", requests)
+	}
+	if headers[0].Get(data.ConfigChangeEventIDHeader) != "1-1" ||
+		headers[0].Get(data.ConfigChangeSignatureHeader) != data.SignConfigChangeEvent([]byte(secret), bodies[0]) {
+		t.Fatalf("unexpected headers %v", headers[0])
+	}
+	var event struct {
+		ID       string `json:"id"`
+		DeviceID string `json:"deviceID"`
+		Diff     []struct {
+			Field, From, To string
+		} `json:"diff"`
+	}
+	if err := json.Unmarshal(bodies[0], &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "1-1" || event.DeviceID != "device_1" || len(event.Diff) != 1 || event.Diff[0].To != "true" {
+		t.Fatalf("unexpected event %s", bodies[0])
+	}
+}
+
+// 测试无响应的webhook不会阻塞其余webhook的发送，并且关闭时等待剩余事件发送的时间不超过drain timeout
+func TestConfigWebhookData_SlowWebhook(t *testing.T) {
+	var delivered int32
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&delivered, 1)
+	}))
+	defer fast.Close()
+
+	webhook, cleanup, err := data.NewConfigWebhookData(&conf.Data{ConfigChange: &conf.Data_ConfigChange{
+		Webhooks:            []*conf.Data_ConfigChange_Webhook{{Url: slow.URL}, {Url: fast.URL}},
+		WebhookDrainTimeout: durationpb.New(200 * time.Millisecond),
+	}}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1-1", "1-2", "1-3"} {
+		webhook.Send(&biz.ConfigChangeEvent{ID: id, Time: time.Now(), DeviceID: "device_1", Config: []byte("{}")})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&delivered) != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 3 events delivered to the fast webhook,got %d", atomic.LoadInt32(&delivered))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	start := time.Now()
+	cleanup()
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the drain to be bounded by the drain timeout,took %s", elapsed)
+	}
+}
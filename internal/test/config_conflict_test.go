@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"testing"
+	"time"
+)
+
+// 测试设备上传的初始配置与desired配置冲突时，各个冲突处理策略决定的生效配置以及冲突在配置历史中的记录
+func TestConfigUsecase_InitialConfigConflict(t *testing.T) {
+	cases := []struct {
+		policy string
+		// 设备上传的配置中的version，即设备已经应用的配置更新序号
+		version    int64
+		deviceWins bool
+	}{
+		{policy: biz.InitialConfigDeviceWins, deviceWins: true},
+		{policy: biz.InitialConfigServerWins, version: 1},
+		{policy: "", deviceWins: true},
+		{policy: biz.InitialConfigNewestRevisionWins, version: 0},
+		{policy: biz.InitialConfigNewestRevisionWins, version: 1, deviceWins: true},
+	}
+	for _, c := range cases {
+		var (
+			_, updater, uc = newTestConfigUsecase(t, &conf.Data{DeviceClasses: map[int32]*conf.Data_DeviceClass{
+				0: {InitialConfigPolicy: c.policy},
+			}})
+			info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 尚未下发过配置更新时不存在冲突
+		err = uc.SaveInitialDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, "test_1", new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 设备离线期间下发的配置更新
+		if _, err := uc.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: true}); err != nil {
+			t.Fatal(err)
+		}
+		// 设备确认了配置更新，但重新连接时上传的配置并未应用该配置更新
+		if err := updater.AckDeviceConfigUpdate(ctx, "test_1", <-updates); err != nil {
+			t.Fatal(err)
+		}
+
+		uploaded := &v1.DeviceConfig0{Id: info.DeviceID, Version: c.version}
+		if err := uc.SaveInitialDeviceConfig(ctx, info, uploaded, "test_1", new(v1.DeviceConfig0)); err != nil {
+			t.Fatal(err)
+		}
+		shadow, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shadow.Reported.(*v1.DeviceConfig0).Status {
+			t.Fatalf("%s:expected the reported config to be the uploaded one,got %v", c.policy, shadow.Reported)
+		}
+		if desired := shadow.Desired.(*v1.DeviceConfig0); desired.Status == c.deviceWins {
+			t.Fatalf("%s:unexpected desired config %v", c.policy, desired)
+		}
+
+		// 以desired配置为准时，desired配置被重新下发给设备
+		select {
+		case u := <-updates:
+			if c.deviceWins || !u.Config.(*v1.DeviceConfig0).Status {
+				t.Fatalf("%s:unexpected config update %v", c.policy, u.Config)
+			}
+		case <-time.After(100 * time.Millisecond):
+			if !c.deviceWins {
+				t.Fatalf("%s:expected the desired config to be sent again", c.policy)
+			}
+		}
+
+		// 重新下发的desired配置尚未确认时，再次上传冲突的配置不会重复下发
+		if !c.deviceWins {
+			if err := uc.SaveInitialDeviceConfig(ctx, info, uploaded, "test_1", new(v1.DeviceConfig0)); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case u := <-updates:
+				t.Fatalf("%s:expected the pending desired config not to be sent again,got %v", c.policy, u.Config)
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+
+		revisions, _, err := uc.ListConfigRevisions(ctx, info, 1, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := revisions[0]; r.Source != biz.RevisionSourceConflict || r.Detail == "" {
+			t.Fatalf("%s:expected the conflict to be recorded,got %+v", c.policy, r)
+		}
+		if c.deviceWins && shadow.Revision != revisions[0].Version {
+			t.Fatalf("%s:expected the desired revision to be the conflict revision,got %d", c.policy, shadow.Revision)
+		}
+		cancel()
+	}
+}
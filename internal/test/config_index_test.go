@@ -0,0 +1,179 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+	"reflect"
+	"testing"
+)
+
+// 测试按照配置字段查询设备配置，以及设备配置变更后所属索引的维护
+func TestConfigUsecase_SearchDeviceConfigs(t *testing.T) {
+	var (
+		_, _, uc = newTestConfigUsecase(t, nil)
+		ctx      = context.Background()
+	)
+	save := func(id string, status bool) {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: id, Status: status}, biz.RevisionSourceInitial, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 逐页查询，返回匹配的设备id
+	search := func(uc *biz.ConfigUsecase, pageSize int64, filters ...string) []string {
+		var predicates []*biz.ConfigFieldPredicate
+		for _, f := range filters {
+			p, err := biz.ParseConfigFieldPredicate(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			predicates = append(predicates, p)
+		}
+		var (
+			ids   []string
+			token string
+		)
+		for {
+			configs, next, err := uc.SearchDeviceConfigs(ctx, 0, predicates, pageSize, token, new(v1.DeviceConfig0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range configs {
+				ids = append(ids, c.(*v1.DeviceConfig0).Id)
+			}
+			if next == "" {
+				return ids
+			}
+			token = next
+		}
+	}
+
+	save("device_1", false)
+	save("device_2", true)
+	save("device_3", false)
+	save("device_4", false)
+
+	if ids := search(uc, 0, "status==false"); !reflect.DeepEqual(ids, []string{"device_1", "device_3", "device_4"}) {
+		t.Fatalf("unexpected search result %v", ids)
+	}
+	if ids := search(uc, 1, "status == false"); !reflect.DeepEqual(ids, []string{"device_1", "device_3", "device_4"}) {
+		t.Fatalf("unexpected paged search result %v", ids)
+	}
+	if ids := search(uc, 0, "status==false", "id==device_3"); !reflect.DeepEqual(ids, []string{"device_3"}) {
+		t.Fatalf("unexpected search result %v", ids)
+	}
+
+	// 配置变更后设备从原有的索引移动到新的索引
+	save("device_3", true)
+	if ids := search(uc, 0, "status==false"); !reflect.DeepEqual(ids, []string{"device_1", "device_4"}) {
+		t.Fatalf("unexpected search result after update %v", ids)
+	}
+	if ids := search(uc, 0, "status==true"); !reflect.DeepEqual(ids, []string{"device_2", "device_3"}) {
+		t.Fatalf("unexpected search result after update %v", ids)
+	}
+
+	// 不存在的字段、不支持查询的字段以及非法的字段值
+	for _, filter := range []string{"unknown==1", "version==1", "status==maybe"} {
+		p, err := biz.ParseConfigFieldPredicate(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = uc.SearchDeviceConfigs(ctx, 0, []*biz.ConfigFieldPredicate{p}, 0, "", new(v1.DeviceConfig0))
+		if e := errors.FromError(err); e.Code != 400 {
+			t.Fatalf("expected 400 for filter %s,got %v", filter, err)
+		}
+	}
+	if _, err := biz.ParseConfigFieldPredicate("status=false"); errors.FromError(err).Code != 400 {
+		t.Fatalf("expected 400 for malformed filter,got %v", err)
+	}
+
+	// 直接写入的设备配置在重建索引后才能被查询到
+	var (
+		rawRepo, _, rawUc = newTestConfigUsecase(t, nil)
+		key               = biz.GetDeviceConfigKey(&biz.DeviceGeneralInfo{DeviceClassID: 0})
+	)
+	for _, id := range []string{"device_1", "device_2"} {
+		value, err := proto.Marshal(&v1.DeviceConfig0{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rawRepo.SaveDeviceConfig(ctx, key, id, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ids := search(rawUc, 0, "status==false"); len(ids) != 0 {
+		t.Fatalf("expected no result before rebuilding indexes,got %v", ids)
+	}
+	if count, err := rawUc.RebuildConfigIndexes(ctx, 0, new(v1.DeviceConfig0)); err != nil || count != 2 {
+		t.Fatalf("unexpected rebuild result %d %v", count, err)
+	}
+	if ids := search(rawUc, 0, "status==false"); !reflect.DeepEqual(ids, []string{"device_1", "device_2"}) {
+		t.Fatalf("unexpected search result after rebuilding indexes %v", ids)
+	}
+}
+
+// failingIndexRepo 维护配置索引总是失败的repo
+type failingIndexRepo struct {
+	*memoryRepo
+}
+
+func (r *failingIndexRepo) UpdateConfigIndexes(context.Context, string, []string, []string) error {
+	return errors.New(500, "Repo_Config_Error", "index unavailable")
+}
+
+// 测试维护索引失败的设备被记录为等待修复，查询时仍然可以被查询到，并在重建索引后被修复
+func TestConfigUsecase_ConfigIndexRepair(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		failing, cleanup  = biz.NewConfigUsecase(&conf.Data{}, &failingIndexRepo{repo}, updater, log.DefaultLogger)
+		ctx               = context.Background()
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+		repairKey         = biz.GetDeviceConfigIndexRepairKey(info)
+	)
+	t.Cleanup(cleanup)
+	search := func(filter string) []proto.Message {
+		p, err := biz.ParseConfigFieldPredicate(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		configs, _, err := uc.SearchDeviceConfigs(ctx, 0, []*biz.ConfigFieldPredicate{p}, 0, "", new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return configs
+	}
+
+	if err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, biz.RevisionSourceInitial, "test"); err != nil {
+		t.Fatal(err)
+	}
+	err := failing.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: true}, biz.RevisionSourceInitial, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repairs, _ := repo.GetConfigIndexRepairs(ctx, repairKey); len(repairs) != 1 {
+		t.Fatalf("expected device_1 to be recorded for repair,got %v", repairs)
+	}
+	// 索引中设备仍然属于status==false，但查询结果以配置本身为准
+	if configs := search("status==true"); len(configs) != 1 {
+		t.Fatalf("expected the device waiting for repair to be found,got %v", configs)
+	}
+	if configs := search("status==false"); len(configs) != 0 {
+		t.Fatalf("unexpected search result %v", configs)
+	}
+
+	if _, err := uc.RebuildConfigIndexes(ctx, 0, new(v1.DeviceConfig0)); err != nil {
+		t.Fatal(err)
+	}
+	if repairs, _ := repo.GetConfigIndexRepairs(ctx, repairKey); len(repairs) != 0 {
+		t.Fatalf("expected repairs to be cleared after rebuilding indexes,got %v", repairs)
+	}
+	if configs := search("status==true"); len(configs) != 1 {
+		t.Fatalf("expected the repaired device to be found,got %v", configs)
+	}
+}
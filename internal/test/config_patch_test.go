@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// 测试以字段掩码部分更新设备配置，以及以expected_revision检查并发更新
+func TestConfigUsecase_PatchDeviceConfig(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		ctx               = context.Background()
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	patch := func(config *v1.DeviceConfig0, paths []string, expectedRevision int64) (int64, error) {
+		_, revision, err := uc.PatchDeviceConfig(ctx, info, config, paths, expectedRevision, new(v1.DeviceConfig0))
+		if err == nil {
+			<-updates
+		}
+		return revision, err
+	}
+
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, []string{"status"}, 0); errors.Code(err) != 404 {
+		t.Fatalf("expected 404 when patching a device without config,got %v", err)
+	}
+	err = uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, biz.RevisionSourceInitial, "test_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, []string{"unknown"}, 0); errors.Code(err) != 400 {
+		t.Fatalf("expected 400 for an unknown field in the mask,got %v", err)
+	}
+
+	// 以设备上报的配置为基础部分更新，id始终为设备id
+	revision, err := patch(&v1.DeviceConfig0{Status: true}, []string{"status"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadow, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desired := shadow.Desired.(*v1.DeviceConfig0); desired.Id != info.DeviceID || !desired.Status ||
+		shadow.Revision != revision || revision == 0 {
+		t.Fatalf("unexpected shadow:%+v", shadow)
+	}
+
+	// 以最新的修订版本号为前提条件的更新成功，之后以旧版本号更新时返回409
+	next, err := patch(&v1.DeviceConfig0{}, []string{"status"}, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next <= revision {
+		t.Fatalf("expected the revision to increase,got %d after %d", next, revision)
+	}
+	_, err = patch(&v1.DeviceConfig0{Status: true}, nil, revision)
+	if e := errors.FromError(err); e.Code != 409 || e.Metadata["current_revision"] != strconv.FormatInt(next, 10) {
+		t.Fatalf("expected 409 with the current revision,got %v", err)
+	}
+
+	// 其他请求正在部分更新配置时同样返回409
+	key := biz.GetDeviceConfigLockKey(info)
+	if _, err := repo.AcquireLock(ctx, key, "other", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, nil, next); errors.Code(err) != 409 {
+		t.Fatalf("expected 409 while the config is locked,got %v", err)
+	}
+	// 不以expected_revision为前提条件的部分更新同样需要持有设备配置锁
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, []string{"status"}, 0); errors.Code(err) != 409 {
+		t.Fatalf("expected 409 for a patch without expected revision while the config is locked,got %v", err)
+	}
+	if err := repo.ReleaseLock(ctx, key, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, nil, next); err != nil {
+		t.Fatal(err)
+	}
+
+	// 检查版本号之后其他请求修改了配置时，保存版本号的比较并设置失败，版本号被清零
+	racing := &racingRevisionRepo{memoryRepo: repo, key: biz.GetDeviceDesiredRevisionKey(info)}
+	other, cleanup := biz.NewConfigUsecase(&conf.Data{}, racing, updater, log.DefaultLogger)
+	t.Cleanup(cleanup)
+	current, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = other.PatchDeviceConfig(ctx, info, &v1.DeviceConfig0{}, nil, current.Revision, new(v1.DeviceConfig0))
+	if errors.Code(err) != 409 {
+		t.Fatalf("expected 409 when the revision changes after the check,got %v", err)
+	}
+	<-updates
+	if shadow, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0)); err != nil || shadow.Revision != 0 {
+		t.Fatalf("expected the revision to be cleared,got %+v %v", shadow, err)
+	}
+	if _, err := patch(&v1.DeviceConfig0{Status: true}, nil, current.Revision+1); errors.Code(err) != 409 {
+		t.Fatalf("expected 409 for the racing revision after it is cleared,got %v", err)
+	}
+}
+
+// racingRevisionRepo 在首次查询key上的修订版本号之后将其增加，模拟配置锁过期期间其他请求修改了配置
+type racingRevisionRepo struct {
+	*memoryRepo
+	key   string
+	raced bool
+}
+
+func (r *racingRevisionRepo) GetDeviceConfigSeq(ctx context.Context, key, field string) (int64, error) {
+	seq, err := r.memoryRepo.GetDeviceConfigSeq(ctx, key, field)
+	if err != nil || key != r.key || r.raced {
+		return seq, err
+	}
+	r.raced = true
+	return seq, r.memoryRepo.SetDeviceConfigSeq(ctx, key, field, seq+1)
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"testing"
+)
+
+// 测试设备配置的单个查询、分页查询以及批量查询
+func TestConfigUsecase_Read(t *testing.T) {
+	_, _, uc := newTestConfigUsecase(t, nil)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		config := &v1.DeviceConfig0{Id: fmt.Sprintf("device_%d", i), Status: i%2 == 0}
+		err := uc.SaveDeviceConfig(ctx, &biz.DeviceGeneralInfo{DeviceID: config.Id}, config, biz.RevisionSourceInitial, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := new(v1.DeviceConfig0)
+	err := uc.GetDeviceConfig(ctx, &biz.DeviceGeneralInfo{DeviceID: "device_2"}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Id != "device_2" || !config.Status {
+		t.Fatalf("unexpected config:%v", config)
+	}
+
+	// 以每页两个配置的大小遍历全部配置
+	var (
+		ids   []string
+		token string
+		pages int
+	)
+	for {
+		configs, next, err := uc.ListDeviceConfigs(ctx, 0, 2, token, new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		for _, c := range configs {
+			ids = append(ids, c.(*v1.DeviceConfig0).Id)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if pages != 3 || len(ids) != 5 || ids[0] != "device_0" || ids[4] != "device_4" {
+		t.Fatalf("unexpected pages:%d,ids:%v", pages, ids)
+	}
+	if _, _, err := uc.ListDeviceConfigs(ctx, 0, 2, "invalid", new(v1.DeviceConfig0)); err == nil {
+		t.Fatal("expected an error for invalid page token")
+	}
+
+	configs, notFound, err := uc.BatchGetDeviceConfigs(
+		ctx, 0, []string{"device_1", "device_9", "device_3"}, new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 || configs[0].(*v1.DeviceConfig0).Id != "device_1" ||
+		configs[1].(*v1.DeviceConfig0).Id != "device_3" {
+		t.Fatalf("unexpected configs:%v", configs)
+	}
+	if len(notFound) != 1 || notFound[0] != "device_9" {
+		t.Fatalf("unexpected not found ids:%v", notFound)
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"testing"
+)
+
+// 测试配置修订的记录、分页查询、比较以及回滚
+func TestConfigUsecase_Revision(t *testing.T) {
+	var (
+		_, updater, uc = newTestConfigUsecase(t, &conf.Data{ConfigRevision: &conf.Data_ConfigRevision{MaxRevisions: 3}})
+		ctx            = context.Background()
+		info           = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 依次记录四个修订，只保留最新的三个
+	for i, status := range []bool{true, false, true, false} {
+		config := &v1.DeviceConfig0{Id: info.DeviceID, Status: status}
+		if i == 0 {
+			err = uc.SaveDeviceConfig(ctx, info, config, biz.RevisionSourceInitial, "")
+		} else {
+			_, err = uc.UpdateDeviceConfig(ctx, info, config)
+			<-updates
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revisions, next, err := uc.ListConfigRevisions(ctx, info, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 || revisions[0].Version != 4 || revisions[1].Version != 3 || next != "3" {
+		t.Fatalf("unexpected first page:%v,next:%s", revisions, next)
+	}
+	if revisions[0].Source != biz.RevisionSourceUpdate || revisions[0].ClientID != "test_1" {
+		t.Fatalf("unexpected revision:%+v", revisions[0])
+	}
+	revisions, next, err = uc.ListConfigRevisions(ctx, info, 2, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 1 || revisions[0].Version != 2 || next != "" {
+		t.Fatalf("unexpected second page:%v,next:%s", revisions, next)
+	}
+
+	diffs, err := uc.DiffConfigRevisions(ctx, info, 3, 4, new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Field != "status" || diffs[0].From != "true" || diffs[0].To != "false" {
+		t.Fatalf("unexpected diffs:%v", diffs)
+	}
+	if _, err := uc.DiffConfigRevisions(ctx, info, 1, 4, new(v1.DeviceConfig0)); err == nil {
+		t.Fatal("expected an error for a trimmed revision")
+	}
+
+	// 回滚到版本3，重新下发该版本的配置并记录新的修订
+	if _, err := uc.RollbackDeviceConfig(ctx, info, 3, new(v1.DeviceConfig0)); err != nil {
+		t.Fatal(err)
+	}
+	update := <-updates
+	if config := update.Config.(*v1.DeviceConfig0); !config.Status {
+		t.Fatalf("unexpected rollback config:%v", config)
+	}
+	revisions, _, err = uc.ListConfigRevisions(ctx, info, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revisions[0].Version != 5 || revisions[0].Source != biz.RevisionSourceRollback {
+		t.Fatalf("unexpected rollback revision:%+v", revisions[0])
+	}
+}
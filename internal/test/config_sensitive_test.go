@@ -0,0 +1,306 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"gitee.com/moyusir/data-collection/internal/service"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试设备配置的加密与解密，包括密钥轮换以及加密之前保存的未加密配置的读取
+func TestConfigCipher(t *testing.T) {
+	var (
+		oldKey = bytes.Repeat([]byte{1}, 32)
+		newKey = bytes.Repeat([]byte{2}, 16)
+		value  = []byte("device config")
+	)
+	t.Setenv("CONFIG_ENCRYPTION_KEYS", "k1="+base64.StdEncoding.EncodeToString(oldKey))
+	old, err := data.NewConfigCipher(&conf.Data{ConfigEncryption: &conf.Data_ConfigEncryption{KeyEnv: "CONFIG_ENCRYPTION_KEYS"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := old.Encode(value, "configs", "device_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encrypted, "enc:k1:") || strings.Contains(encrypted, hex.EncodeToString(value)) {
+		t.Fatalf("unexpected encrypted config %s", encrypted)
+	}
+
+	// 轮换到新密钥后，以旧密钥加密的配置仍然可以读取，新写入的配置以新密钥加密
+	rotated, err := data.NewConfigCipherFromKeys(map[string][]byte{"k1": oldKey, "k2": newKey}, "k2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{encrypted, hex.EncodeToString(value)} {
+		if decrypted, err := rotated.Decode(v, "configs", "device_1"); err != nil || !bytes.Equal(decrypted, value) {
+			t.Fatalf("unexpected decrypted config %q %v", decrypted, err)
+		}
+	}
+	if reencrypted, err := rotated.Encode(value, "configs", "device_1"); err != nil || !strings.HasPrefix(reencrypted, "enc:k2:") {
+		t.Fatalf("unexpected encrypted config %s %v", reencrypted, err)
+	}
+
+	// 缺少密钥、密文被篡改、密文被复制到其他键或者field下以及未配置密钥时无法读取
+	onlyNew, err := data.NewConfigCipherFromKeys(map[string][]byte{"k2": newKey}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := encrypted[:len(encrypted)-2] + "00"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "ff"
+	}
+	for _, c := range []struct {
+		cipher     *data.ConfigCipher
+		value      string
+		key, field string
+	}{
+		{onlyNew, encrypted, "configs", "device_1"},
+		{old, tampered, "configs", "device_1"},
+		{old, encrypted, "configs", "device_2"},
+		{old, encrypted, "desired", "device_1"},
+		{nil, encrypted, "configs", "device_1"},
+	} {
+		if _, err := c.cipher.Decode(c.value, c.key, c.field); err == nil {
+			t.Fatalf("expected decoding %s under %s/%s to fail", c.value, c.key, c.field)
+		}
+	}
+
+	// 多个密钥时需要指定加密所用的密钥
+	if _, err := data.NewConfigCipherFromKeys(map[string][]byte{"k1": oldKey, "k2": newKey}, ""); err == nil {
+		t.Fatal("expected an error without active key id")
+	}
+	if _, err := data.NewConfigCipherFromKeys(map[string][]byte{"k1": []byte("short")}, ""); err == nil {
+		t.Fatal("expected an error for invalid key length")
+	}
+}
+
+// 测试启用加密时待确认队列与频道中的配置更新消息以及灰度发布中的设备配置均以密文保存，并且可以正常还原
+func TestConfigCipher_UpdateMessages(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+		config            = &v1.DeviceConfig0{Id: info.DeviceID, Status: true}
+		err               error
+	)
+	repo.cipher, err = data.NewConfigCipherFromKeys(map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uc.UpdateDeviceConfig(ctx, info, config); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1"))
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("expected 1 pending update,got %v %v", msgs, err)
+	}
+	message := new(biz.ConfigUpdateMessage)
+	if err := json.Unmarshal([]byte(msgs[0].Value), message); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(message.Config, "enc:k1:") {
+		t.Fatalf("expected the pending update to be encrypted,got %s", message.Config)
+	}
+	select {
+	case update := <-updates:
+		if c := update.Config.(*v1.DeviceConfig0); c.Id != info.DeviceID || !c.Status {
+			t.Fatalf("unexpected update %v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the encrypted update to be delivered")
+	}
+
+	rollouts, cleanup := biz.NewRolloutUsecase(&conf.Data{}, repo, uc, log.DefaultLogger)
+	defer cleanup()
+	rollout, err := rollouts.CreateRollout(ctx, &biz.ConfigRollout{
+		Selector: &biz.DeviceSelector{All: true},
+		Stages:   []*biz.RolloutStage{{Percent: 100}},
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := repo.GetRollout(ctx, biz.GetConfigRolloutKey(rollout.RolloutID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(fields["config"], "enc:k1:") || strings.Contains(fields["rollout"], `"config"`) {
+		t.Fatalf("expected the rollout config to be encrypted,got %v", fields)
+	}
+	stored, err := rollouts.GetRollout(ctx, rollout.RolloutID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stored.Config, rollout.Config) {
+		t.Fatalf("unexpected rollout config %x", stored.Config)
+	}
+}
+
+// newSensitiveConfigType 返回与设备配置结构相同但包含敏感字段password以及pin的动态消息类型
+func newSensitiveConfigType(t *testing.T) protoreflect.MessageType {
+	sensitive := new(descriptorpb.FieldOptions)
+	proto.SetExtension(sensitive, v1.E_Sensitive, true)
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type,
+		opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			Options:  opts,
+		}
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/sensitive_config.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("SensitiveConfig"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+				field("password", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, sensitive),
+				field("status", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL, nil),
+				field("version", 4, descriptorpb.FieldDescriptorProto_TYPE_INT64, nil),
+				field("pin", 5, descriptorpb.FieldDescriptorProto_TYPE_INT64, sensitive),
+			},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dynamicpb.NewMessageType(fd.Messages().Get(0))
+}
+
+// 测试敏感字段在响应以及配置差异中以掩码替换，提交的掩码以及update_mask之外的敏感字段保留当前的值，并且敏感字段不建立索引
+func TestConfigUsecase_SensitiveFields(t *testing.T) {
+	var (
+		mt                = newSensitiveConfigType(t)
+		fields            = mt.Descriptor().Fields()
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+		ctx               = context.Background()
+	)
+	newConfig := func(password string, status bool) proto.Message {
+		m := mt.New()
+		m.Set(fields.ByName("id"), protoreflect.ValueOfString(info.DeviceID))
+		m.Set(fields.ByName("password"), protoreflect.ValueOfString(password))
+		m.Set(fields.ByName("status"), protoreflect.ValueOfBool(status))
+		m.Set(fields.ByName("pin"), protoreflect.ValueOfInt64(1234))
+		return m.Interface()
+	}
+	password := func(m proto.Message) string {
+		return m.ProtoReflect().Get(fields.ByName("password")).String()
+	}
+	pin := func(m proto.Message) int64 {
+		return m.ProtoReflect().Get(fields.ByName("pin")).Int()
+	}
+
+	// 经过middleware的响应中string敏感字段被替换为掩码，其余类型的敏感字段被清除，其余字段不变
+	handler := service.MaskSensitiveFields()(func(context.Context, interface{}) (interface{}, error) {
+		return newConfig("secret", true), nil
+	})
+	reply, err := handler(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked := reply.(proto.Message)
+	if password(masked) != biz.MaskedValue || pin(masked) != 0 || !masked.ProtoReflect().Get(fields.ByName("status")).Bool() {
+		t.Fatalf("unexpected masked reply %v", masked)
+	}
+
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.UpdateDeviceConfig(ctx, info, newConfig("secret", true)); err != nil {
+		t.Fatal(err)
+	}
+	// 以update_mask修改查询得到的配置时，未包含在update_mask中的敏感字段保留当前的值
+	masked.ProtoReflect().Set(fields.ByName("status"), protoreflect.ValueOfBool(false))
+	_, _, err = uc.PatchDeviceConfig(ctx, info, masked, []string{"status"}, 0, mt.New().Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadow, err := uc.GetDeviceShadow(ctx, info, mt.New().Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password(shadow.Desired) != "secret" || pin(shadow.Desired) != 1234 ||
+		shadow.Desired.ProtoReflect().Get(fields.ByName("status")).Bool() {
+		t.Fatalf("unexpected desired config %v", shadow.Desired)
+	}
+
+	// 提交完整的配置时，只有值为掩码的敏感字段保留当前的值，未设置的其余类型的敏感字段被清除
+	if _, err := uc.UpdateDeviceConfig(ctx, info, masked); err != nil {
+		t.Fatal(err)
+	}
+	if shadow, err = uc.GetDeviceShadow(ctx, info, mt.New().Interface()); err != nil {
+		t.Fatal(err)
+	}
+	if password(shadow.Desired) != "secret" || pin(shadow.Desired) != 0 {
+		t.Fatalf("unexpected desired config %v", shadow.Desired)
+	}
+
+	// 敏感字段的差异以掩码表示
+	if err := uc.SaveDeviceConfig(ctx, info, newConfig("other", false), biz.RevisionSourceInitial, "test_1"); err != nil {
+		t.Fatal(err)
+	}
+	shadow, err = uc.GetDeviceShadow(ctx, info, mt.New().Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shadow.InSync || len(shadow.Delta) != 2 {
+		t.Fatalf("unexpected shadow delta %+v", shadow.Delta)
+	}
+	for _, d := range shadow.Delta {
+		switch d.Field {
+		case "password":
+			if d.From != biz.MaskedValue || d.To != biz.MaskedValue {
+				t.Fatalf("unexpected shadow delta %+v", d)
+			}
+		case "pin":
+			if d.From == "1234" || d.To == "1234" {
+				t.Fatalf("sensitive field value leaked into shadow delta %+v", d)
+			}
+		default:
+			t.Fatalf("unexpected shadow delta %+v", d)
+		}
+	}
+
+	// 敏感字段不建立索引，也不能作为查询条件
+	for key := range repo.sets {
+		if strings.Contains(key, "other") {
+			t.Fatalf("sensitive field value leaked into index key %s", key)
+		}
+	}
+	_, _, err = uc.SearchDeviceConfigs(ctx, 0,
+		[]*biz.ConfigFieldPredicate{{Field: "password", Value: "other"}}, 0, "", mt.New().Interface())
+	if errors.Code(err) != 400 {
+		t.Fatalf("expected 400 when searching by sensitive field,got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"testing"
+	"time"
+)
+
+// 测试首次出现且没有任何已保存配置的设备以设备类别的配置模板作为desired配置，并通过配置更新流下发
+func TestConfigUsecase_ConfigTemplate(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	connect := func(id string) *biz.DeviceGeneralInfo {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+	expectTemplate := func(info *biz.DeviceGeneralInfo) {
+		select {
+		case u := <-updates:
+			if c := u.Config.(*v1.DeviceConfig0); c.Id != info.DeviceID || !c.Status {
+				t.Fatalf("expected the template to be sent to %s,got %v", info.DeviceID, c)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the template to be sent to %s", info.DeviceID)
+		}
+	}
+
+	if err := uc.SetConfigTemplate(ctx, 0, &v1.DeviceConfig0{Version: -1}); errors.Code(err) != 400 {
+		t.Fatalf("expected 400 for an invalid template,got %v", err)
+	}
+	if err := uc.SetConfigTemplate(ctx, 0, &v1.DeviceConfig0{Status: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 通过设备状态首次出现的设备
+	info := connect("device_1")
+	if applied, err := uc.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); err != nil || !applied {
+		t.Fatalf("expected the template to be applied,got %v %v", applied, err)
+	}
+	expectTemplate(info)
+	revisions, _, err := uc.ListConfigRevisions(ctx, info, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revisions[0].Source != biz.RevisionSourceTemplate {
+		t.Fatalf("expected a template revision,got %+v", revisions[0])
+	}
+	if applied, _ := uc.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); applied {
+		t.Fatal("expected the template to be applied only once")
+	}
+
+	// 通过初始配置流首次出现的设备，上传的配置保存为reported配置，模板保存为desired配置
+	info = connect("device_2")
+	err = uc.SaveInitialDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTemplate(info)
+	shadow, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shadow.Reported.(*v1.DeviceConfig0).Status || !shadow.Desired.(*v1.DeviceConfig0).Status {
+		t.Fatalf("unexpected shadow %+v", shadow)
+	}
+
+	// 已经保存了配置的设备不会下发模板
+	info = connect("device_3")
+	err = uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, biz.RevisionSourceInitial, "test_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied, err := uc.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); err != nil || applied {
+		t.Fatalf("expected no template for a device with stored config,got %v %v", applied, err)
+	}
+
+	// 设备尚未与clientID关联时模板下发失败，模板不会保存为desired配置，关联后重新检查时下发模板
+	info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_5"}
+	if _, err := uc.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); err == nil {
+		t.Fatal("expected an error for a device without client id")
+	}
+	if applied, err := uc.ApplyConfigTemplate(ctx, connect(info.DeviceID), new(v1.DeviceConfig0)); err != nil || !applied {
+		t.Fatalf("expected the template to be applied after connecting,got %v %v", applied, err)
+	}
+	expectTemplate(info)
+
+	// 各个实例共享设备出现过的记录，已经出现过的设备即使没有已保存的配置，也不会被其他实例再次下发模板
+	info = connect("device_6")
+	if applied, err := uc.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); err != nil || !applied {
+		t.Fatalf("expected the template to be applied,got %v %v", applied, err)
+	}
+	expectTemplate(info)
+	for _, key := range []string{biz.GetDeviceConfigKey(info), biz.GetDeviceDesiredConfigKey(info)} {
+		if err := repo.DeleteDeviceConfig(ctx, key, info.DeviceID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other, cleanup := biz.NewConfigUsecase(&conf.Data{}, repo, updater, log.DefaultLogger)
+	t.Cleanup(cleanup)
+	if applied, err := other.ApplyConfigTemplate(ctx, info, new(v1.DeviceConfig0)); err != nil || applied {
+		t.Fatalf("expected the template not to be applied by another instance,got %v %v", applied, err)
+	}
+
+	// 删除模板后首次出现的设备不会下发模板
+	if err := uc.DeleteConfigTemplate(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.GetConfigTemplate(ctx, 0, new(v1.DeviceConfig0)); errors.Code(err) != 404 {
+		t.Fatalf("expected 404 for a deleted template,got %v", err)
+	}
+	if applied, err := uc.ApplyConfigTemplate(ctx, connect("device_4"), new(v1.DeviceConfig0)); err != nil || applied {
+		t.Fatalf("expected no template after deletion,got %v %v", applied, err)
+	}
+	select {
+	case u := <-updates:
+		t.Fatalf("unexpected config update %v", u.Config)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
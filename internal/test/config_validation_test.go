@@ -0,0 +1,119 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"testing"
+)
+
+// 测试下发配置更新前按照validate.rules注解以及注册的校验器校验设备配置
+func TestDeviceConfigUpdater_Validation(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		ctx               = context.Background()
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	// 字段之间的约束:id为locked的设备不能开启
+	updater.RegisterConfigValidator(0, biz.ConfigValidatorFunc(func(config proto.Message) []*biz.FieldViolation {
+		if c := config.(*v1.DeviceConfig0); c.Id == "locked" && c.Status {
+			return []*biz.FieldViolation{{Field: "status", Description: "锁定的设备不能开启"}}
+		}
+		return nil
+	}))
+	expectInvalid := func(config *v1.DeviceConfig0, fields ...string) {
+		_, err := uc.UpdateDeviceConfig(ctx, info, config)
+		e := errors.FromError(err)
+		if e.Code != 400 {
+			t.Fatalf("expected 400 for %v,got %v", config, err)
+		}
+		for _, f := range fields {
+			if e.Metadata[f] == "" {
+				t.Fatalf("expected the violation of field %s,got %v", f, e.Metadata)
+			}
+		}
+	}
+
+	expectInvalid(&v1.DeviceConfig0{Version: -1}, "id", "version")
+	expectInvalid(&v1.DeviceConfig0{Id: "locked", Status: true}, "status")
+	// 不满足校验规则的配置既不会保存为desired配置，也不会下发
+	if _, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0)); errors.Code(err) != 404 {
+		t.Fatalf("expected no desired config to be saved,got %v", err)
+	}
+	if pending, _ := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1")); len(pending) != 0 {
+		t.Fatalf("expected no update to be published,got %d", len(pending))
+	}
+	if _, err := uc.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 批量更新的配置模板中的设备id在下发时被替换，因此不校验设备id
+	err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID}, biz.RevisionSourceInitial, "test_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector := &biz.DeviceSelector{IDPrefix: "device_"}
+	if _, err := uc.BulkUpdateDeviceConfig(ctx, 0, selector, &v1.DeviceConfig0{Version: -1}); errors.Code(err) != 400 {
+		t.Fatalf("expected 400 for an invalid bulk update,got %v", err)
+	}
+	if _, err := uc.BulkUpdateDeviceConfig(ctx, 0, selector, &v1.DeviceConfig0{Status: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 测试设备类别配置的required_fields注册为校验器，以及使用了不支持的validate.rules规则的配置被拒绝
+func TestDeviceConfigUpdater_ValidationRules(t *testing.T) {
+	var (
+		_, updater, uc = newTestConfigUsecase(t, &conf.Data{DeviceClasses: map[int32]*conf.Data_DeviceClass{
+			0: {RequiredFields: []string{"status", "missing"}},
+		}})
+		ctx  = context.Background()
+		info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	_, err := uc.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID})
+	if e := errors.FromError(err); e.Code != 400 || e.Metadata["status"] == "" || e.Metadata["missing"] == "" {
+		t.Fatalf("expected the violations of the required fields,got %v", err)
+	}
+
+	// 以string.email注解的字段，该规则不被支持
+	opts := new(descriptorpb.FieldOptions)
+	proto.SetExtension(opts, validate.E_Rules, &validate.FieldRules{Type: &validate.FieldRules_String_{
+		String_: &validate.StringRules{WellKnown: &validate.StringRules_Email{Email: true}},
+	}})
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/unsupported_rules.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("UnsupportedRulesConfig"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("email"),
+				JsonName: proto.String("email"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options:  opts,
+			}},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := dynamicpb.NewMessage(fd.Messages().Get(0))
+	if err := updater.ValidateDeviceConfig(&biz.DeviceGeneralInfo{DeviceClassID: 1}, config); errors.Code(err) != 500 {
+		t.Fatalf("expected 500 for an unsupported rule,got %v", err)
+	}
+}
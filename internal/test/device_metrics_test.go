@@ -0,0 +1,54 @@
+package test
+
+import (
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/service"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试/metrics/devices能否暴露设备的最新读数，并移除过期的设备读数
+func TestDeviceMetricsService(t *testing.T) {
+	c := &conf.Server{DeviceMetrics: &conf.Server_DeviceMetrics{
+		StaleTimeout: durationpb.New(100 * time.Millisecond),
+	}}
+	readings := biz.NewDeviceReadingCache(c)
+	s := service.NewDeviceMetricsService(c, readings, log.DefaultLogger)
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics/devices", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("unexpected status code:%d", recorder.Code)
+		}
+		return recorder.Body.String()
+	}
+
+	fields := map[string]float64{"Voltage": 220, "Current": 5}
+	readings.Update(&biz.DeviceGeneralInfo{DeviceClassID: 1, DeviceID: "device_1"}, time.Now(), fields)
+	// 调用方复用map时不应影响已缓存的读数
+	fields["Voltage"] = 0
+
+	body := scrape()
+	for _, expected := range []string{
+		`device_state_Voltage{deviceClassID="1",deviceID="device_1",user="` + conf.Username + `"} 220`,
+		`device_state_Current{deviceClassID="1",deviceID="device_1",user="` + conf.Username + `"} 5`,
+		`device_state_last_report_timestamp_seconds{deviceClassID="1",deviceID="device_1",user="` + conf.Username + `"}`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %s in:\n%s", expected, body)
+		}
+	}
+
+	// 超过过期时间后，设备读数不应再被暴露
+	time.Sleep(200 * time.Millisecond)
+	if body := scrape(); strings.Contains(body, "device_1") {
+		t.Errorf("stale reading is still exposed:\n%s", body)
+	}
+}
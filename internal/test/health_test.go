@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/service"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// healthRepo 可以手动设置redis是否可用的repo
+type healthRepo struct {
+	*memoryRepo
+	lock     sync.Mutex
+	redisErr error
+}
+
+func (r *healthRepo) setRedisErr(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.redisErr = err
+}
+
+func (r *healthRepo) CheckRedis(context.Context) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.redisErr
+}
+
+// 测试依赖组件不可用时就绪状态下降而存活状态保持不变，并在恢复后重新就绪
+func TestHealthService(t *testing.T) {
+	repo := &healthRepo{memoryRepo: newMemoryRepo()}
+	uc, cleanup := biz.NewHealthUsecase(&conf.Server{Health: &conf.Server_Health{
+		CheckInterval: durationpb.New(10 * time.Millisecond),
+	}}, repo, log.DefaultLogger)
+	defer cleanup()
+	hs := service.NewHealthService(uc, log.DefaultLogger)
+
+	grpcCheck := func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+		reply, err := hs.UnaryServerInterceptor()(context.Background(),
+			&grpc_health_v1.HealthCheckRequest{},
+			&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+			func(context.Context, interface{}) (interface{}, error) {
+				t.Fatal("health check should not reach the handler")
+				return nil, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reply.(*grpc_health_v1.HealthCheckResponse).Status
+	}
+	httpCheck := func(handler http.HandlerFunc) (int, map[string]bool) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		var body struct {
+			Dependencies []biz.DependencyStatus `json:"dependencies"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		up := make(map[string]bool)
+		for _, d := range body.Dependencies {
+			up[d.Name] = d.Up
+		}
+		return w.Code, up
+	}
+	// 等待后台的健康检查反映依赖组件的状态
+	waitReady := func(ready bool) {
+		deadline := time.Now().Add(time.Second)
+		for uc.Ready() != ready {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected ready to be %v", ready)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	if status := grpcCheck(); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING,got %v", status)
+	}
+	if code, _ := httpCheck(hs.Readyz); code != http.StatusOK {
+		t.Fatalf("expected readyz to return 200,got %d", code)
+	}
+
+	repo.setRedisErr(errors.New("sentinel unreachable"))
+	waitReady(false)
+	if status := grpcCheck(); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING,got %v", status)
+	}
+	code, up := httpCheck(hs.Readyz)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("expected readyz to return 503,got %d", code)
+	}
+	if up[biz.DependencyRedis] || !up[biz.DependencyInfluxdb] {
+		t.Fatalf("unexpected dependency status:%v", up)
+	}
+	if code, _ := httpCheck(hs.Healthz); code != http.StatusOK {
+		t.Fatalf("expected healthz to return 200,got %d", code)
+	}
+
+	repo.setRedisErr(nil)
+	waitReady(true)
+	if status := grpcCheck(); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING,got %v", status)
+	}
+}
@@ -0,0 +1,666 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryRepo 以内存实现的repo，用于在不依赖redis以及influxdb的情况下测试biz层的逻辑，
+// 各个方法以互斥锁保护，以便模拟设备的goroutine与服务端并发访问
+type memoryRepo struct {
+	lock     sync.Mutex
+	hash     map[string]string
+	configs  map[string]map[string][]byte
+	channels map[string]chan string
+	// 各个键下按版本号从旧到新排列的配置修订
+	revisions map[string][]*biz.ConfigRevision
+	versions  map[string]int64
+	streams   map[string][]*biz.StreamMsg
+	streamSeq int64
+	audits    map[string][]*biz.ConfigAuditRecord
+	changes   map[string][]*biz.ConfigChangeEvent
+	statuses  map[string]map[string]string
+	jobs      map[string]map[string]string
+	rollouts  map[string]map[string]string
+	sets      map[string]map[string]bool
+	repairs   map[string]map[string]string
+	locks     map[string]string
+	// 编码设备配置所用的加密器，为nil时以十六进制字符串编码
+	cipher *data.ConfigCipher
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		hash:      make(map[string]string),
+		configs:   make(map[string]map[string][]byte),
+		channels:  make(map[string]chan string),
+		revisions: make(map[string][]*biz.ConfigRevision),
+		versions:  make(map[string]int64),
+		streams:   make(map[string][]*biz.StreamMsg),
+		audits:    make(map[string][]*biz.ConfigAuditRecord),
+		changes:   make(map[string][]*biz.ConfigChangeEvent),
+		statuses:  make(map[string]map[string]string),
+		jobs:      make(map[string]map[string]string),
+		rollouts:  make(map[string]map[string]string),
+		sets:      make(map[string]map[string]bool),
+		repairs:   make(map[string]map[string]string),
+		locks:     make(map[string]string),
+	}
+}
+
+func (r *memoryRepo) channel(name string) chan string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.channels[name]; !ok {
+		r.channels[name] = make(chan string, 10)
+	}
+	return r.channels[name]
+}
+
+func (r *memoryRepo) EncodeConfig(config []byte, key, field string) (string, error) {
+	return r.cipher.Encode(config, key, field)
+}
+
+func (r *memoryRepo) DecodeConfig(v, key, field string) ([]byte, error) {
+	return r.cipher.Decode(v, key, field)
+}
+
+func (r *memoryRepo) SaveDeviceConfig(_ context.Context, key, field string, value []byte) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.configs[key]; !ok {
+		r.configs[key] = make(map[string][]byte)
+	}
+	r.configs[key][field] = value
+	return nil
+}
+
+func (r *memoryRepo) GetDeviceConfig(_ context.Context, key, field string) ([]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.configs[key][field], nil
+}
+
+// ListDeviceConfigs 以排序后的下标作为游标
+func (r *memoryRepo) ListDeviceConfigs(_ context.Context, key string, cursor uint64, count int64) ([][]byte, uint64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	fields := make([]string, 0, len(r.configs[key]))
+	for f := range r.configs[key] {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var configs [][]byte
+	for i := cursor; i < uint64(len(fields)); i++ {
+		if int64(len(configs)) == count {
+			return configs, i, nil
+		}
+		configs = append(configs, r.configs[key][fields[i]])
+	}
+	return configs, 0, nil
+}
+
+// ScanDeviceIDs 与ListDeviceConfigs相同，以排序后的下标作为游标
+func (r *memoryRepo) ScanDeviceIDs(_ context.Context, key string, cursor uint64, count int64) ([]string, uint64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	fields := make([]string, 0, len(r.configs[key]))
+	for f := range r.configs[key] {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var ids []string
+	for i := cursor; i < uint64(len(fields)); i++ {
+		if int64(len(ids)) == count {
+			return ids, i, nil
+		}
+		ids = append(ids, fields[i])
+	}
+	return ids, 0, nil
+}
+
+func (r *memoryRepo) BatchGetDeviceConfigs(_ context.Context, key string, fields ...string) ([][]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	configs := make([][]byte, len(fields))
+	for i, f := range fields {
+		configs[i] = r.configs[key][f]
+	}
+	return configs, nil
+}
+
+func (r *memoryRepo) DeleteDeviceConfig(_ context.Context, key, field string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.configs[key], field)
+	return nil
+}
+
+func (r *memoryRepo) SaveConfigRevision(_ context.Context, versionKey, field, revisionKey string,
+	revision *biz.ConfigRevision, maxRevisions int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.versions[versionKey+field]++
+	revision.Version = r.versions[versionKey+field]
+	revisions := append(r.revisions[revisionKey], revision)
+	if int64(len(revisions)) > maxRevisions {
+		revisions = revisions[int64(len(revisions))-maxRevisions:]
+	}
+	r.revisions[revisionKey] = revisions
+	return revision.Version, nil
+}
+
+func (r *memoryRepo) ListConfigRevisions(
+	_ context.Context, revisionKey string, before, count int64) ([]*biz.ConfigRevision, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var result []*biz.ConfigRevision
+	revisions := r.revisions[revisionKey]
+	for i := len(revisions) - 1; i >= 0 && int64(len(result)) < count; i-- {
+		if before == 0 || revisions[i].Version < before {
+			result = append(result, revisions[i])
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepo) GetConfigVersion(_ context.Context, versionKey, field string) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.versions[versionKey+field], nil
+}
+
+func (r *memoryRepo) RestoreConfigRevisions(_ context.Context, versionKey, field, revisionKey string,
+	version int64, revisions []*biz.ConfigRevision) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.revisions[revisionKey] = append([]*biz.ConfigRevision(nil), revisions...)
+	r.versions[versionKey+field] = version
+	return nil
+}
+
+func (r *memoryRepo) GetConfigRevision(_ context.Context, revisionKey string, version int64) (*biz.ConfigRevision, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, revision := range r.revisions[revisionKey] {
+		if revision.Version == version {
+			return revision, nil
+		}
+	}
+	return nil, errors.NotFound("Repo_Config_NotFound", "revision not found")
+}
+
+func (r *memoryRepo) SaveDeviceState(context.Context, *biz.DeviceStateMeasurement) error { return nil }
+
+func (r *memoryRepo) GetMsgChannel(_ context.Context, name string) (<-chan string, error) {
+	return r.channel(name), nil
+}
+
+func (r *memoryRepo) PublishMsg(_ context.Context, channel string, message ...string) error {
+	// 在锁外发送消息，避免通道写满时阻塞其他方法
+	c := r.channel(channel)
+	for _, msg := range message {
+		c <- msg
+	}
+	return nil
+}
+
+func (r *memoryRepo) AddFieldValuePair(_ context.Context, key, field, value string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hash[key+field] = value
+	return nil
+}
+
+// GetValueOfField 与redis的hget相同，field不存在时返回错误
+func (r *memoryRepo) GetValueOfField(_ context.Context, key, field string) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.hash[key+field]
+	if !ok {
+		return "", errors.New(500, "Repo_Config_Error", "查询hash键值对时发生了错误:redis: nil")
+	}
+	return value, nil
+}
+
+func (r *memoryRepo) LookupValueOfField(_ context.Context, key, field string) (string, bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.hash[key+field]
+	return value, ok, nil
+}
+
+func (r *memoryRepo) AddSetMember(_ context.Context, key, member string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.sets[key] == nil {
+		r.sets[key] = make(map[string]bool)
+	}
+	r.sets[key][member] = true
+	return nil
+}
+
+func (r *memoryRepo) RemoveSetMember(_ context.Context, key, member string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.sets[key], member)
+	return nil
+}
+
+// GetSetMembers 按照字典序返回set中的成员
+func (r *memoryRepo) GetSetMembers(_ context.Context, key string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	members := make([]string, 0, len(r.sets[key]))
+	for m := range r.sets[key] {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (r *memoryRepo) CreateClientID(context.Context) (string, error) {
+	return "test_1", nil
+}
+
+// AddStreamMsg 以当前的毫秒时间戳以及自增序号产生消息id
+func (r *memoryRepo) AddStreamMsg(_ context.Context,
+	stream, msg string, maxLen int64, _ time.Duration) (string, []*biz.StreamMsg, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.streamSeq++
+	id := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), r.streamSeq)
+	msgs := append(r.streams[stream], &biz.StreamMsg{ID: id, Value: msg})
+	var trimmed []*biz.StreamMsg
+	if int64(len(msgs)) > maxLen {
+		trimmed = append(trimmed, msgs[:int64(len(msgs))-maxLen]...)
+		msgs = msgs[int64(len(msgs))-maxLen:]
+	}
+	r.streams[stream] = msgs
+	return id, trimmed, nil
+}
+
+func (r *memoryRepo) GetStreamMsgs(_ context.Context, stream string) ([]*biz.StreamMsg, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*biz.StreamMsg(nil), r.streams[stream]...), nil
+}
+
+func (r *memoryRepo) DeleteStreamMsgs(_ context.Context, stream string, ids ...string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var msgs []*biz.StreamMsg
+	for _, m := range r.streams[stream] {
+		deleted := false
+		for _, id := range ids {
+			deleted = deleted || m.ID == id
+		}
+		if !deleted {
+			msgs = append(msgs, m)
+		}
+	}
+	r.streams[stream] = msgs
+	return nil
+}
+
+func (r *memoryRepo) AddConfigAuditRecord(_ context.Context, key string, record *biz.ConfigAuditRecord, _ int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.streamSeq++
+	record.ID = fmt.Sprintf("%d-%d", time.Now().UnixMilli(), r.streamSeq)
+	r.audits[key] = append(r.audits[key], record)
+	return nil
+}
+
+func (r *memoryRepo) UpdateConfigIndexes(_ context.Context, deviceID string, removeKeys, addKeys []string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, key := range removeKeys {
+		delete(r.sets[key], deviceID)
+	}
+	for _, key := range addKeys {
+		if _, ok := r.sets[key]; !ok {
+			r.sets[key] = make(map[string]bool)
+		}
+		r.sets[key][deviceID] = true
+	}
+	return nil
+}
+
+func (r *memoryRepo) IntersectConfigIndexes(_ context.Context, keys ...string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var ids []string
+	for id := range r.sets[keys[0]] {
+		in := true
+		for _, key := range keys[1:] {
+			in = in && r.sets[key][id]
+		}
+		if in {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryRepo) MarkConfigIndexRepair(_ context.Context, key, deviceID, value string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.repairs[key]; !ok {
+		r.repairs[key] = make(map[string]string)
+	}
+	r.repairs[key][deviceID] = value
+	return nil
+}
+
+func (r *memoryRepo) GetConfigIndexRepairs(_ context.Context, key string) (map[string]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	repairs := make(map[string]string, len(r.repairs[key]))
+	for id, value := range r.repairs[key] {
+		repairs[id] = value
+	}
+	return repairs, nil
+}
+
+func (r *memoryRepo) UnmarkConfigIndexRepair(_ context.Context, key, deviceID, value string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.repairs[key][deviceID] == value {
+		delete(r.repairs[key], deviceID)
+	}
+	return nil
+}
+
+func (r *memoryRepo) PublishConfigChange(
+	_ context.Context, stream string, event *biz.ConfigChangeEvent, _ int64, _ time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.streamSeq++
+	event.ID = fmt.Sprintf("%d-%d", time.Now().UnixMilli(), r.streamSeq)
+	r.changes[stream] = append(r.changes[stream], event)
+	return nil
+}
+
+// configChanges 返回stream中的全部配置变更事件
+func (r *memoryRepo) configChanges(stream string) []*biz.ConfigChangeEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*biz.ConfigChangeEvent(nil), r.changes[stream]...)
+}
+
+// RangeConfigAuditRecords 与xrange相同，不完整的消息id在作为起点以及终点时分别以最小以及最大的序号补全
+func (r *memoryRepo) RangeConfigAuditRecords(
+	_ context.Context, key, start, end string, count int64) ([]*biz.ConfigAuditRecord, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var records []*biz.ConfigAuditRecord
+	for _, record := range r.audits[key] {
+		if int64(len(records)) == count {
+			break
+		}
+		if compareStreamID(record.ID, start, false) >= 0 && compareStreamID(record.ID, end, true) <= 0 {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// compareStreamID 比较完整的消息id与xrange的边界，返回-1、0、1
+func compareStreamID(id, bound string, isEnd bool) int {
+	switch bound {
+	case "-":
+		return 1
+	case "+":
+		return -1
+	}
+	parse := func(s string, defaultSeq uint64) (ms, seq uint64) {
+		parts := strings.SplitN(s, "-", 2)
+		ms, _ = strconv.ParseUint(parts[0], 10, 64)
+		seq = defaultSeq
+		if len(parts) == 2 {
+			seq, _ = strconv.ParseUint(parts[1], 10, 64)
+		}
+		return ms, seq
+	}
+	var defaultSeq uint64
+	if isEnd {
+		defaultSeq = math.MaxUint64
+	}
+	ms1, seq1 := parse(id, 0)
+	ms2, seq2 := parse(bound, defaultSeq)
+	switch {
+	case ms1 < ms2 || ms1 == ms2 && seq1 < seq2:
+		return -1
+	case ms1 == ms2 && seq1 == seq2:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func (r *memoryRepo) SaveConfigUpdateStatus(_ context.Context, key string, fields map[string]string, _ time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.statuses[key]; !ok {
+		r.statuses[key] = make(map[string]string)
+	}
+	for k, v := range fields {
+		r.statuses[key][k] = v
+	}
+	return nil
+}
+
+func (r *memoryRepo) GetConfigUpdateStatus(_ context.Context, key string) (map[string]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.statuses[key]; !ok {
+		return nil, errors.NotFound("Repo_Config_NotFound", "status not found")
+	}
+	fields := make(map[string]string, len(r.statuses[key]))
+	for k, v := range r.statuses[key] {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+func (r *memoryRepo) SaveBulkUpdateJob(_ context.Context, key string, fields map[string]string, _ time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.jobs[key]; !ok {
+		r.jobs[key] = make(map[string]string)
+	}
+	for k, v := range fields {
+		r.jobs[key][k] = v
+	}
+	return nil
+}
+
+func (r *memoryRepo) GetBulkUpdateJob(_ context.Context, key string) (map[string]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.jobs[key]; !ok {
+		return nil, errors.NotFound("Repo_Config_NotFound", "job not found")
+	}
+	fields := make(map[string]string, len(r.jobs[key]))
+	for k, v := range r.jobs[key] {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+func (r *memoryRepo) SaveDeviceLabels(_ context.Context, key, field, labels string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hash[key+field] = labels
+	return nil
+}
+
+func (r *memoryRepo) BatchGetDeviceLabels(_ context.Context, key string, fields ...string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	labels := make([]string, len(fields))
+	for i, field := range fields {
+		labels[i] = r.hash[key+field]
+	}
+	return labels, nil
+}
+
+func (r *memoryRepo) SaveRollout(_ context.Context, key string, fields map[string]string, _ time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.rollouts[key]; !ok {
+		r.rollouts[key] = make(map[string]string)
+	}
+	for k, v := range fields {
+		r.rollouts[key][k] = v
+	}
+	return nil
+}
+
+func (r *memoryRepo) GetRollout(_ context.Context, key string) (map[string]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.rollouts[key]; !ok {
+		return nil, errors.NotFound("Repo_Config_NotFound", "rollout not found")
+	}
+	fields := make(map[string]string, len(r.rollouts[key]))
+	for k, v := range r.rollouts[key] {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+func (r *memoryRepo) DeleteRolloutField(_ context.Context, key, field, value string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.rollouts[key][field] == value {
+		delete(r.rollouts[key], field)
+	}
+	return nil
+}
+
+func (r *memoryRepo) MarkDeviceSeen(_ context.Context, key, field string) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.sets[key]; !ok {
+		r.sets[key] = make(map[string]bool)
+	}
+	if r.sets[key][field] {
+		return false, nil
+	}
+	r.sets[key][field] = true
+	return true, nil
+}
+
+func (r *memoryRepo) UnmarkDeviceSeen(_ context.Context, key, field string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.sets[key], field)
+	return nil
+}
+
+func (r *memoryRepo) AddActiveRollout(_ context.Context, key, rolloutID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.sets[key]; !ok {
+		r.sets[key] = make(map[string]bool)
+	}
+	r.sets[key][rolloutID] = true
+	return nil
+}
+
+func (r *memoryRepo) RemoveActiveRollout(_ context.Context, key, rolloutID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.sets[key], rolloutID)
+	return nil
+}
+
+func (r *memoryRepo) ListActiveRollouts(_ context.Context, key string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ids := make([]string, 0, len(r.sets[key]))
+	for id := range r.sets[key] {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// AcquireLock 锁不会过期，由ReleaseLock释放
+func (r *memoryRepo) AcquireLock(_ context.Context, key, owner string, _ time.Duration) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if o, ok := r.locks[key]; ok && o != owner {
+		return false, nil
+	}
+	r.locks[key] = owner
+	return true, nil
+}
+
+func (r *memoryRepo) ReleaseLock(_ context.Context, key, owner string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.locks[key] == owner {
+		delete(r.locks, key)
+	}
+	return nil
+}
+
+func (r *memoryRepo) IncrDeviceConfigSeq(_ context.Context, key, field string) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// 与redis的hincrby相同，序号以十进制字符串保存在hash中
+	seq, _ := strconv.ParseInt(string(r.configs[key][field]), 10, 64)
+	seq++
+	if _, ok := r.configs[key]; !ok {
+		r.configs[key] = make(map[string][]byte)
+	}
+	r.configs[key][field] = []byte(strconv.FormatInt(seq, 10))
+	return seq, nil
+}
+
+func (r *memoryRepo) GetDeviceConfigSeq(_ context.Context, key, field string) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	seq, _ := strconv.ParseInt(string(r.configs[key][field]), 10, 64)
+	return seq, nil
+}
+
+func (r *memoryRepo) SetDeviceConfigSeq(_ context.Context, key, field string, seq int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.configs[key]; !ok {
+		r.configs[key] = make(map[string][]byte)
+	}
+	r.configs[key][field] = []byte(strconv.FormatInt(seq, 10))
+	return nil
+}
+
+func (r *memoryRepo) CompareAndSetDeviceConfigSeq(_ context.Context, key, field string, old, seq int64) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if current, _ := strconv.ParseInt(string(r.configs[key][field]), 10, 64); current != old {
+		return false, nil
+	}
+	if _, ok := r.configs[key]; !ok {
+		r.configs[key] = make(map[string][]byte)
+	}
+	r.configs[key][field] = []byte(strconv.FormatInt(seq, 10))
+	return true, nil
+}
+
+func (r *memoryRepo) CheckRedis(context.Context) error { return nil }
+
+func (r *memoryRepo) CheckInfluxdb(context.Context) error { return nil }
@@ -0,0 +1,172 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+// 测试客户端断开期间的配置更新在重新连接后重发，确认后不再重发，以及队列长度与有效期的限制
+func TestDeviceConfigUpdater_PendingUpdates(t *testing.T) {
+	var (
+		repo, updater, _ = newTestConfigUsecase(t, &conf.Data{PendingUpdates: &conf.Data_PendingUpdates{
+			MaxLen: 2,
+			Ttl:    durationpb.New(time.Hour),
+		}})
+		info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(context.Background(), "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	update := func(status bool) {
+		_, _, err := updater.UpdateDeviceConfig(
+			context.Background(), info, &v1.DeviceConfig0{Id: info.DeviceID, Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	connect := func() (<-chan *biz.DeviceConfigUpdate, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updates, cancel
+	}
+	// 断开连接，并等待转发配置更新的goroutine退出
+	disconnect := func(updates <-chan *biz.DeviceConfigUpdate, cancel context.CancelFunc) {
+		cancel()
+		for range updates {
+		}
+	}
+	receive := func(updates <-chan *biz.DeviceConfigUpdate) *biz.DeviceConfigUpdate {
+		select {
+		case u := <-updates:
+			return u
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for config update")
+			return nil
+		}
+	}
+	expectNothing := func(updates <-chan *biz.DeviceConfigUpdate) {
+		select {
+		case u := <-updates:
+			t.Fatalf("unexpected update:%v", u.Config)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// 一条已经过期的配置更新
+	key := biz.GetPendingUpdatesKey("test_1")
+	repo.streams[key] = append(repo.streams[key], &biz.StreamMsg{ID: "1-1", Value: "0a0864657669636531"})
+
+	// 客户端断开期间下发三个配置更新，队列只保留最新的两个
+	update(true)
+	update(false)
+	update(true)
+
+	// 重新连接后按顺序重发队列中的配置更新，且频道中重复的配置更新被去除
+	updates, cancel := connect()
+	first, second := receive(updates), receive(updates)
+	if first.Config.(*v1.DeviceConfig0).Status || !second.Config.(*v1.DeviceConfig0).Status {
+		t.Fatalf("unexpected replay order:%v,%v", first.Config, second.Config)
+	}
+	expectNothing(updates)
+	if err := updater.AckDeviceConfigUpdate(context.Background(), "test_1", first); err != nil {
+		t.Fatal(err)
+	}
+	disconnect(updates, cancel)
+
+	// 只有未确认的配置更新会再次重发
+	updates, cancel = connect()
+	defer cancel()
+	if u := receive(updates); !u.Config.(*v1.DeviceConfig0).Status {
+		t.Fatalf("unexpected replay:%v", u.Config)
+	}
+	expectNothing(updates)
+	if len(repo.streams[key]) != 1 {
+		t.Fatalf("expected the expired update to be removed,got %d pending updates", len(repo.streams[key]))
+	}
+
+	// 连接期间下发的配置更新实时转发
+	update(false)
+	if u := receive(updates); u.Config.(*v1.DeviceConfig0).Status {
+		t.Fatalf("unexpected live update:%v", u.Config)
+	}
+}
+
+// 测试超出队列长度上限而被丢弃的配置更新被标记为失败，以及设备以新的clientID重新连接后待确认的配置更新被转移
+func TestDeviceConfigUpdater_PendingUpdatesMigration(t *testing.T) {
+	var (
+		repo, updater, _ = newTestConfigUsecase(t, &conf.Data{PendingUpdates: &conf.Data_PendingUpdates{
+			MaxLen: 2,
+			Ttl:    durationpb.New(time.Hour),
+		}})
+		info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+		ctx  = context.Background()
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	var updateIDs []string
+	for _, status := range []bool{true, false, true} {
+		_, updateID, err := updater.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		updateIDs = append(updateIDs, updateID)
+	}
+	if status, err := updater.GetConfigUpdateStatus(ctx, updateIDs[0]); err != nil || status.Status != biz.UpdateStatusFailed {
+		t.Fatalf("expected the dropped update to be failed,got %+v %v", status, err)
+	}
+
+	// 以新的clientID重新连接后，尚未确认的配置更新转移到新clientID的待确认队列中
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_2", info); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(repo.streams[biz.GetPendingUpdatesKey("test_1")]); n != 0 {
+		t.Fatalf("expected the old pending queue to be empty,got %d pending updates", n)
+	}
+	updateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates, err := updater.GetDeviceUpdateMsgChannel(updateCtx, "test_2", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, updateID := range updateIDs[1:] {
+		select {
+		case u := <-updates:
+			if u.UpdateID() != updateID {
+				t.Fatalf("expected update %s,got %s", updateID, u.UpdateID())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for config update")
+		}
+		if status, err := updater.GetConfigUpdateStatus(ctx, updateID); err != nil || status.ClientID != "test_2" {
+			t.Fatalf("unexpected update status %+v %v", status, err)
+		}
+	}
+}
+
+// 测试配置更新频道的订阅被关闭时，配置更新channel随之关闭，而不是不断接收零值的消息
+func TestDeviceConfigUpdater_SubscriptionClosed(t *testing.T) {
+	repo, updater, _ := newTestConfigUsecase(t, nil)
+	updates, err := updater.GetDeviceUpdateMsgChannel(context.Background(), "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(repo.channel("test_1"))
+
+	select {
+	case update, ok := <-updates:
+		if ok {
+			t.Fatalf("unexpected update:%v", update.Config)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the update channel to be closed")
+	}
+}
@@ -0,0 +1,255 @@
+package test
+
+import (
+	"context"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/data"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 测试remote_write客户端能否输出正确编码的样本，并在接收端返回5xx时进行重试
+func TestRemoteWriteData_Append(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+		samples  = make(map[string]map[string]string)
+		values   = make(map[string]float64)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		requests++
+		// 第一次请求返回5xx，以测试重试
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		parseWriteRequest(t, r, samples, values)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rw, cleanup, err := data.NewRemoteWriteData(&conf.Data{RemoteWrite: &conf.Data_RemoteWrite{
+		Url:               server.URL,
+		MinBackoff:        durationpb.New(time.Millisecond),
+		BatchSendDeadline: durationpb.New(10 * time.Millisecond),
+	}}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rw.Append(&biz.DeviceStateMeasurement{
+		Name:   "device_1",
+		Time:   time.Now(),
+		Tags:   map[string]string{"deviceClassID": "0", "location": "substation-1"},
+		Fields: map[string]float64{"Voltage": 220, "Current": 5},
+	})
+	// 等待队列中的样本发送完毕
+	cleanup()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if requests != 2 {
+		t.Fatalf("expected 2 requests,got %d", requests)
+	}
+	for name, value := range map[string]float64{"device_state_Voltage": 220, "device_state_Current": 5} {
+		labels, ok := samples[name]
+		if !ok {
+			t.Fatalf("sample %s not found", name)
+		}
+		if values[name] != value {
+			t.Errorf("sample %s: expected %v,got %v", name, value, values[name])
+		}
+		if labels["deviceID"] != "device_1" || labels["deviceClassID"] != "0" ||
+			labels["location"] != "substation-1" || labels["user"] != conf.Username {
+			t.Errorf("unexpected labels of sample %s:%v", name, labels)
+		}
+	}
+}
+
+// 测试接收端持续不可用时，关闭remote_write客户端等待剩余样本发送的时间不超过drainTimeout
+func TestRemoteWriteData_DrainTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rw, cleanup, err := data.NewRemoteWriteData(&conf.Data{RemoteWrite: &conf.Data_RemoteWrite{
+		Url:               server.URL,
+		MaxRetries:        1000,
+		MinBackoff:        durationpb.New(10 * time.Millisecond),
+		MaxBackoff:        durationpb.New(time.Second),
+		BatchSendDeadline: durationpb.New(time.Hour),
+		DrainTimeout:      durationpb.New(200 * time.Millisecond),
+	}}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw.Append(&biz.DeviceStateMeasurement{
+		Name:   "device_1",
+		Time:   time.Now(),
+		Fields: map[string]float64{"Voltage": 220},
+	})
+
+	start := time.Now()
+	cleanup()
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the drain to be bounded by the drain timeout,took %s", elapsed)
+	}
+}
+
+// 测试经过Repo.SaveDeviceState保存的设备状态在写入influxdb后以remote_write协议输出，
+// 并且tag不能覆盖用户以及设备id等保留标签
+func TestRepo_SaveDeviceState_RemoteWrite(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		writes  int
+		samples = make(map[string]map[string]string)
+		values  = make(map[string]float64)
+	)
+	// 模拟influxdb的ping以及写入接口
+	influxdb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.URL.Path == "/api/v2/write" {
+			writes++
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer influxdb.Close()
+	remoteWrite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		parseWriteRequest(t, r, samples, values)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer remoteWrite.Close()
+
+	bc := &conf.Data{
+		Influxdb: &conf.Data_Influxdb{ServerUrl: influxdb.URL, AuthToken: "token", Org: "test"},
+		RemoteWrite: &conf.Data_RemoteWrite{
+			Url:               remoteWrite.URL,
+			BatchSendDeadline: durationpb.New(10 * time.Millisecond),
+		},
+	}
+	influxdbData, influxdbCleanup, err := data.NewInfluxdbData(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer influxdbCleanup()
+	rw, cleanup, err := data.NewRemoteWriteData(bc, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := data.NewRepo(nil, influxdbData, rw, nil, nil, log.DefaultLogger)
+
+	err = repo.SaveDeviceState(context.Background(), &biz.DeviceStateMeasurement{
+		Name: "device_1",
+		Time: time.Now(),
+		Tags: map[string]string{
+			"deviceClassID": "0", "location": "substation-1",
+			"user": "other_user", "deviceID": "device_2", "__name__": "other_metric",
+		},
+		Fields: map[string]float64{"Voltage": 220},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 等待队列中的样本发送完毕
+	cleanup()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if writes != 1 {
+		t.Fatalf("expected 1 influxdb write,got %d", writes)
+	}
+	labels, ok := samples["device_state_Voltage"]
+	if !ok || values["device_state_Voltage"] != 220 {
+		t.Fatalf("unexpected samples %v %v", samples, values)
+	}
+	if labels["user"] != conf.Username || labels["deviceID"] != "device_1" ||
+		labels["deviceClassID"] != "0" || labels["location"] != "substation-1" {
+		t.Fatalf("unexpected labels %v", labels)
+	}
+}
+
+// parseWriteRequest 解析remote_write请求中的各个时间序列，以指标名为键记录样本的标签以及值
+func parseWriteRequest(t *testing.T, r *http.Request, samples map[string]map[string]string, values map[string]float64) {
+	if r.Header.Get("Content-Encoding") != "snappy" {
+		t.Errorf("unexpected content encoding:%s", r.Header.Get("Content-Encoding"))
+	}
+
+	compressed, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	forEachField(t, body, func(_ protowire.Number, ts []byte) {
+		labels := make(map[string]string)
+		forEachField(t, ts, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var name string
+				forEachField(t, v, func(n protowire.Number, l []byte) {
+					if n == 1 {
+						name = string(l)
+					} else {
+						labels[name] = string(l)
+					}
+				})
+			case 2:
+				bits, n := protowire.ConsumeFixed64(v[1:])
+				if n < 0 {
+					t.Error(protowire.ParseError(n))
+				}
+				values[labels["__name__"]] = math.Float64frombits(bits)
+			}
+		})
+		samples[labels["__name__"]] = labels
+	})
+}
+
+// forEachField 遍历protobuf二进制信息中的长度前缀字段与定长字段
+func forEachField(t *testing.T, b []byte, f func(num protowire.Number, v []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				t.Fatal(protowire.ParseError(n))
+			}
+			f(num, v)
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				t.Fatal(protowire.ParseError(n))
+			}
+			f(num, append(protowire.AppendTag(nil, num, typ), b[:n]...))
+			b = b[n:]
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试灰度发布的分阶段下发、失败或者产生警告信息时的自动暂停、继续以及取消
+func TestRolloutUsecase(t *testing.T) {
+	var (
+		data = &conf.Data{
+			Rollout: &conf.Data_Rollout{CheckInterval: durationpb.New(10 * time.Millisecond)},
+			DeviceClasses: map[int32]*conf.Data_DeviceClass{0: {WarningLimits: map[string]*conf.Data_DeviceClass_WarningLimit{
+				"voltage": {Max: wrapperspb.Double(250)},
+			}}},
+		}
+		repo, updater, uc = newTestConfigUsecase(t, data)
+		detector          = biz.NewWarningDetectUsecase(data, repo, biz.NewDeviceReadingCache(&conf.Server{}), log.DefaultLogger)
+		// 客户端拒绝a_1的配置更新，b_1应用配置更新后上报的电压超出合法范围
+		devices = []string{"a_1", "a_2", "a_3", "a_4", "b_1"}
+	)
+	rollouts, cleanup := biz.NewRolloutUsecase(data, repo, uc, log.DefaultLogger)
+	defer cleanup()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, id := range devices {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+		err := uc.SaveDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: id}, biz.RevisionSourceInitial, "test_1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update := <-updates:
+				switch id := update.Config.(*v1.DeviceConfig0).Id; id {
+				case "a_1":
+					updater.SetUpdateStatus(ctx, update, biz.UpdateStatusFailed, errors.New(400, "", "rejected"))
+				case "b_1":
+					info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: id}
+					err := detector.SaveDeviceState(ctx, info, time.Now(), map[string]float64{"voltage": 300}, map[string]string{})
+					if err != nil {
+						t.Error(err)
+					}
+					fallthrough
+				default:
+					updater.SetUpdateStatus(ctx, update, biz.UpdateStatusApplied, nil)
+				}
+			}
+		}
+	}()
+	wait := func(rolloutID, status string) *biz.ConfigRollout {
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			rollout, err := rollouts.GetRollout(ctx, rolloutID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rollout.Status == status {
+				return rollout
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected rollout to be %s,got %+v", status, rollout)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	config := &v1.DeviceConfig0{Status: true}
+
+	_, err = rollouts.CreateRollout(ctx, &biz.ConfigRollout{
+		Selector: &biz.DeviceSelector{All: true},
+		Stages:   []*biz.RolloutStage{{Percent: 50}},
+	}, config)
+	if errors.Code(err) != 400 {
+		t.Fatalf("expected 400 when the last stage is not 100%%,got %v", err)
+	}
+
+	// a_1在第一阶段被拒绝，灰度发布暂停，继续后第二阶段正常完成
+	rollout, err := rollouts.CreateRollout(ctx, &biz.ConfigRollout{
+		Selector: &biz.DeviceSelector{IDPrefix: "a_"},
+		Stages:   []*biz.RolloutStage{{Percent: 50}, {Percent: 100}},
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	halted := wait(rollout.RolloutID, biz.RolloutStatusHalted)
+	if halted.CurrentStage != 0 || halted.Stages[0].Devices != 2 || halted.HaltReason == "" {
+		t.Fatalf("unexpected halted rollout:%+v", halted)
+	}
+	if _, err := rollouts.ResumeRollout(ctx, rollout.RolloutID); err != nil {
+		t.Fatal(err)
+	}
+	completed := wait(rollout.RolloutID, biz.RolloutStatusCompleted)
+	if completed.CurrentStage != 1 || completed.Stages[1].Devices != 2 || completed.Stages[1].JobID == "" {
+		t.Fatalf("unexpected completed rollout:%+v", completed)
+	}
+	if _, err := rollouts.CancelRollout(ctx, rollout.RolloutID); errors.Code(err) != 409 {
+		t.Fatalf("expected 409 when cancelling a completed rollout,got %v", err)
+	}
+
+	// b_1在应用配置后读数越限，WarningDetectUsecase产生的警告信息使灰度发布暂停
+	rollout, err = rollouts.CreateRollout(ctx, &biz.ConfigRollout{
+		Selector: &biz.DeviceSelector{IDPrefix: "b_"},
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if halted := wait(rollout.RolloutID, biz.RolloutStatusHalted); !strings.Contains(halted.HaltReason, "警告") {
+		t.Fatalf("expected the rollout to halt because of the warning,got %q", halted.HaltReason)
+	}
+
+	// 尚未开始的灰度发布可以取消，但不能继续
+	rollout, err = rollouts.CreateRollout(ctx, &biz.ConfigRollout{
+		Selector:  &biz.DeviceSelector{All: true},
+		StartTime: time.Now().Add(time.Hour),
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rollouts.ResumeRollout(ctx, rollout.RolloutID); errors.Code(err) != 409 {
+		t.Fatalf("expected 409 when resuming a scheduled rollout,got %v", err)
+	}
+	if _, err := rollouts.CancelRollout(ctx, rollout.RolloutID); err != nil {
+		t.Fatal(err)
+	}
+	if cancelled := wait(rollout.RolloutID, biz.RolloutStatusCancelled); len(cancelled.DeviceIDs) != 0 {
+		t.Fatalf("expected no device to be updated,got %v", cancelled.DeviceIDs)
+	}
+	// 已经执行的控制命令在灰度发布保存后被删除
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		fields, err := repo.GetRollout(ctx, biz.GetConfigRolloutKey(rollout.RolloutID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := fields["control"]; !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the executed control command to be deleted,got %v", fields)
+		}
+	}
+}
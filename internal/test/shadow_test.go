@@ -0,0 +1,182 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/service"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"testing"
+	"time"
+)
+
+// 测试设备影子中desired与reported的分离、delta的计算以及设备重新连接后的同步
+func TestConfigUsecase_Shadow(t *testing.T) {
+	var (
+		_, updater, uc = newTestConfigUsecase(t, nil)
+		ctx            = context.Background()
+		info           = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	getShadow := func() *biz.DeviceShadow {
+		shadow, err := uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return shadow
+	}
+
+	_, err = uc.GetDeviceShadow(ctx, info, new(v1.DeviceConfig0))
+	if errors.Code(err) != 404 {
+		t.Fatalf("expected 404 before any config is saved,got %v", err)
+	}
+
+	// 设备上报初始配置，尚未下发配置更新时影子视为一致
+	reported := &v1.DeviceConfig0{Id: info.DeviceID, Status: false}
+	if err := uc.SaveDeviceConfig(ctx, info, reported, biz.RevisionSourceInitial, "test_1"); err != nil {
+		t.Fatal(err)
+	}
+	if shadow := getShadow(); !shadow.InSync || shadow.Desired != nil || shadow.Reported == nil {
+		t.Fatalf("unexpected shadow:%+v", shadow)
+	}
+
+	// 下发配置更新，设备确认接收但未应用，reported保持不变
+	desired := &v1.DeviceConfig0{Id: info.DeviceID, Status: true}
+	if _, err := uc.UpdateDeviceConfig(ctx, info, desired); err != nil {
+		t.Fatal(err)
+	}
+	if err := updater.AckDeviceConfigUpdate(ctx, "test_1", <-updates); err != nil {
+		t.Fatal(err)
+	}
+	shadow := getShadow()
+	if shadow.InSync || len(shadow.Delta) != 1 || shadow.Delta[0].Field != "status" ||
+		shadow.Delta[0].From != "false" || shadow.Delta[0].To != "true" {
+		t.Fatalf("unexpected shadow:%+v", shadow)
+	}
+	if shadow.Reported.(*v1.DeviceConfig0).Status {
+		t.Fatal("reported config should not be overwritten by the desired config")
+	}
+
+	// 设备重新连接并上报旧配置后，desired配置被重新下发
+	if err := uc.SaveDeviceConfig(ctx, info, reported, biz.RevisionSourceInitial, "test_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.SyncDeviceShadow(ctx, info, new(v1.DeviceConfig0)); err != nil {
+		t.Fatal(err)
+	}
+	if config := (<-updates).Config.(*v1.DeviceConfig0); !config.Status {
+		t.Fatalf("unexpected resent config:%v", config)
+	}
+
+	// 设备确认接收配置更新后影子恢复一致，此时同步不会再次下发配置
+	if err := uc.SaveDeviceConfig(ctx, info, desired, biz.RevisionSourceAck, "test_1"); err != nil {
+		t.Fatal(err)
+	}
+	if shadow := getShadow(); !shadow.InSync || len(shadow.Delta) != 0 {
+		t.Fatalf("unexpected shadow:%+v", shadow)
+	}
+	if err := uc.SyncDeviceShadow(ctx, info, new(v1.DeviceConfig0)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected update:%v", update.Config)
+	default:
+	}
+}
+
+// testUpdateStream0 模拟设备的配置更新流，记录下发的配置，并在接收答复时以io.EOF表示设备断开连接
+type testUpdateStream0 struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*v1.DeviceConfig0
+}
+
+func (s *testUpdateStream0) Context() context.Context { return s.ctx }
+
+func (s *testUpdateStream0) SendHeader(metadata.MD) error { return nil }
+
+func (s *testUpdateStream0) Send(config *v1.DeviceConfig0) error {
+	s.sent = append(s.sent, config)
+	return nil
+}
+
+func (s *testUpdateStream0) Recv() (*v1.ConfigUpdateReply, error) { return nil, io.EOF }
+
+// 测试设备的配置更新流建立时，desired配置在待确认的配置更新重发之后下发给影子不一致的设备，且不会重复下发
+func TestConfigService_UpdateStreamShadowSync(t *testing.T) {
+	var (
+		repo, updater, uc = newTestConfigUsecase(t, nil)
+		ctx               = context.Background()
+		info              = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	s, err := service.NewConfigService(uc, updater, nil, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	reported := &v1.DeviceConfig0{Id: info.DeviceID}
+	if err := uc.SaveDeviceConfig(ctx, info, reported, biz.RevisionSourceInitial, "test_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 设备确认接收了配置更新但未应用，待确认队列为空而影子不一致
+	subCtx, cancel := context.WithCancel(ctx)
+	updates, err := updater.GetDeviceUpdateMsgChannel(subCtx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updater.AckDeviceConfigUpdate(ctx, "test_1", <-updates); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for range updates {
+	}
+
+	// 没有配置更新可以下发时，流在超时后结束
+	connect := func() *testUpdateStream0 {
+		streamCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		stream := &testUpdateStream0{
+			ctx: metadata.NewIncomingContext(streamCtx, metadata.Pairs(service.CLIENT_ID_HEADER, "test_1")),
+		}
+		if err := s.CreateConfigUpdateStream0(stream); err != nil {
+			t.Fatal(err)
+		}
+		return stream
+	}
+
+	// 连接时desired配置被重新下发
+	stream := connect()
+	if len(stream.sent) != 1 || !stream.sent[0].Status {
+		t.Fatalf("expected the desired config to be sent on connect,got %v", stream.sent)
+	}
+
+	// 重新下发的配置更新尚未确认，再次连接时只重发待确认的配置更新
+	stream = connect()
+	if len(stream.sent) != 1 || !stream.sent[0].Status {
+		t.Fatalf("expected the pending desired config to be replayed,got %v", stream.sent)
+	}
+	pending, err := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected the desired config not to be sent again,got %d pending updates", len(pending))
+	}
+}
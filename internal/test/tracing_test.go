@@ -0,0 +1,163 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"gitee.com/moyusir/data-collection/internal/monitor"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试配置更新消息能否携带追踪上下文，使接收方的span与发起更新的span属于同一条链路，
+// 并测试file导出方式输出的OTLP JSON
+func TestTracing_ConfigUpdatePropagation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := monitor.InitTracer(
+		&conf.Trace{Exporter: monitor.ExporterFile, FilePath: path}, "data-collection", "test", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, updater, _ := newTestConfigUsecase(t, nil)
+	info := &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	if err := updater.ConnectDeviceAndClientID(context.Background(), "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootCtx, root := monitor.StartSpan(context.Background(), "UpdateDeviceConfig")
+	_, _, err = updater.UpdateDeviceConfig(rootCtx, info, &v1.DeviceConfig0{Id: "device_1", Status: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.End()
+
+	update := <-updates
+	if config := update.Config.(*v1.DeviceConfig0); config.Id != "device_1" || !config.Status {
+		t.Fatalf("unexpected config:%v", config)
+	}
+	_, deliver := monitor.StartSpan(update.Context(context.Background()), "Deliver")
+	deliver.End()
+	if deliver.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Fatalf("expected trace id %s,got %s",
+			root.SpanContext().TraceID(), deliver.SpanContext().TraceID())
+	}
+
+	// 关闭导出器，确保所有span写入了文件
+	shutdown()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	type span struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+	}
+	spans := make(map[string]span)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var request struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []span `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			t.Fatal(err)
+		}
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{"UpdateDeviceConfig", "DeviceConfigUpdater.UpdateDeviceConfig", "Deliver"} {
+		s, ok := spans[name]
+		if !ok {
+			t.Fatalf("span %s not exported", name)
+		}
+		if s.TraceID != root.SpanContext().TraceID().String() {
+			t.Errorf("span %s: unexpected trace id %s", name, s.TraceID)
+		}
+	}
+	if spans["Deliver"].ParentSpanID != spans["DeviceConfigUpdater.UpdateDeviceConfig"].SpanID {
+		t.Errorf("expected the parent of Deliver to be the publishing span")
+	}
+}
+
+// 测试otlp导出方式能否以OTLP/HTTP协议将span发送到接收端
+func TestTracing_OTLPHTTPExporter(t *testing.T) {
+	requests := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		request := new(coltracepb.ExportTraceServiceRequest)
+		if err := proto.Unmarshal(body, request); err != nil {
+			t.Error(err)
+			return
+		}
+		requests <- request
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	shutdown, err := monitor.InitTracer(&conf.Trace{
+		Exporter: monitor.ExporterOTLP,
+		Protocol: monitor.OTLPProtocolHTTP,
+		Endpoint: server.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, "data-collection", "test", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := monitor.StartSpan(context.Background(), "UpdateDeviceConfig")
+	span.End()
+	// 关闭导出器，确保span被发送到接收端
+	shutdown()
+
+	select {
+	case request := <-requests:
+		spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+		if len(spans) != 1 || spans[0].Name != "UpdateDeviceConfig" {
+			t.Fatalf("unexpected spans %v", spans)
+		}
+	default:
+		t.Fatal("span not exported")
+	}
+
+	if _, err := monitor.InitTracer(&conf.Trace{
+		Exporter: monitor.ExporterOTLP, Protocol: "thrift", Endpoint: server.URL}, "data-collection", "test", "test"); err == nil {
+		t.Fatal("expected an error for unsupported protocol")
+	}
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+// 测试超过截止时间的配置更新在待确认队列、下发队列以及重发时被丢弃并标记为expired
+func TestDeviceConfigUpdater_Expiry(t *testing.T) {
+	var (
+		repo, updater, _ = newTestConfigUsecase(t, &conf.Data{UpdateRetry: &conf.Data_UpdateRetry{
+			MaxAttempts: 3,
+			MinBackoff:  durationpb.New(time.Second),
+		}})
+		info = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_1"}
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	update := func(expiresIn time.Duration, status bool) string {
+		updateCtx := ctx
+		if expiresIn > 0 {
+			updateCtx = biz.WithUpdateExpiry(ctx, time.Now().Add(expiresIn))
+		}
+		_, updateID, err := updater.UpdateDeviceConfig(updateCtx, info, &v1.DeviceConfig0{Id: info.DeviceID, Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updateID
+	}
+	expectStatus := func(updateID, expected string) {
+		status, err := updater.GetConfigUpdateStatus(ctx, updateID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status != expected {
+			t.Fatalf("expected update %s to be %s,got %+v", updateID, expected, status)
+		}
+	}
+	waitStatus := func(updateID, expected string) {
+		deadline := time.Now().Add(time.Second)
+		for {
+			status, err := updater.GetConfigUpdateStatus(ctx, updateID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Status == expected {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected update %s to be %s,got %+v", updateID, expected, status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// 客户端重新连接前超过截止时间的配置更新不再重发，并从待确认队列中删除
+	expired := update(50*time.Millisecond, false)
+	update(0, true)
+	time.Sleep(100 * time.Millisecond)
+	expectStatus(expired, biz.UpdateStatusExpired)
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := <-updates
+	if !u.Config.(*v1.DeviceConfig0).Status {
+		t.Fatalf("expected the expired update to be dropped,got %v", u.Config)
+	}
+	if err := updater.AckDeviceConfigUpdate(ctx, "test_1", u); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1")); len(pending) != 0 {
+		t.Fatalf("expected no pending updates,got %d", len(pending))
+	}
+
+	// 客户端忙于处理其他配置更新时超过截止时间的配置更新同样被丢弃
+	update(0, true)
+	expired = update(50*time.Millisecond, false)
+	waitStatus(expired, biz.UpdateStatusExpired)
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		pending, _ := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1"))
+		if len(pending) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the expired update to be removed,got %d pending updates", len(pending))
+		}
+	}
+	<-updates
+	update(0, true)
+	if u := <-updates; !u.Config.(*v1.DeviceConfig0).Status {
+		t.Fatalf("expected the expired update to be dropped,got %v", u.Config)
+	}
+
+	// 重发前就会超过截止时间的配置更新不再重发
+	expired = update(500*time.Millisecond, false)
+	u = <-updates
+	if _, retry := updater.RetryDeviceConfigUpdate(ctx, "test_1", info, u, errors.New(400, "", "rejected")); retry {
+		t.Fatal("expected the update not to be retried after its deadline")
+	}
+	expectStatus(expired, biz.UpdateStatusExpired)
+}
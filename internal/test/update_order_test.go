@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/golang/protobuf/proto"
+	"testing"
+	"time"
+)
+
+// 测试配置更新的序号、过期配置更新的丢弃，以及开启合并时只下发同一设备最新的配置更新
+func TestDeviceConfigUpdater_Order(t *testing.T) {
+	var (
+		repo, updater, _ = newTestConfigUsecase(t, &conf.Data{DeviceClasses: map[int32]*conf.Data_DeviceClass{
+			0: {CoalesceUpdates: true},
+		}})
+		coalesced = &biz.DeviceGeneralInfo{DeviceClassID: 0, DeviceID: "device_0"}
+		ordered   = &biz.DeviceGeneralInfo{DeviceClassID: 1, DeviceID: "device_1"}
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, info := range []*biz.DeviceGeneralInfo{coalesced, ordered} {
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_"+fmt.Sprint(info.DeviceClassID), info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	update := func(info *biz.DeviceGeneralInfo, config proto.Message) string {
+		_, updateID, err := updater.UpdateDeviceConfig(ctx, info, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updateID
+	}
+	status := func(updateID string) string {
+		s, err := updater.GetConfigUpdateStatus(ctx, updateID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.Status
+	}
+
+	// 客户端连接前的三个配置更新只下发最新的一个，其余被取代并从待确认队列中删除
+	var ids []string
+	for i := 0; i < 3; i++ {
+		ids = append(ids, update(coalesced, &v1.DeviceConfig0{Id: coalesced.DeviceID}))
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_0", new(v1.DeviceConfig0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := <-updates; u.Config.(*v1.DeviceConfig0).Version != 3 {
+		t.Fatalf("expected the newest update to be delivered,got %v", u.Config)
+	}
+	for _, id := range ids[:2] {
+		if s := status(id); s != biz.UpdateStatusSuperseded {
+			t.Fatalf("expected update %s to be superseded,got %s", id, s)
+		}
+	}
+	if pending, _ := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_0")); len(pending) != 1 {
+		t.Fatalf("expected 1 pending update,got %d", len(pending))
+	}
+
+	// 客户端忙于处理其他配置更新时到达的配置更新同样被合并
+	first := update(coalesced, &v1.DeviceConfig0{Id: coalesced.DeviceID})
+	update(coalesced, &v1.DeviceConfig0{Id: coalesced.DeviceID, Status: true})
+	deadline := time.Now().Add(time.Second)
+	for status(first) != biz.UpdateStatusSuperseded {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected update %s to be superseded", first)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if c := (<-updates).Config.(*v1.DeviceConfig0); c.Version != 5 || !c.Status {
+		t.Fatalf("expected the newest update to be delivered,got %v", c)
+	}
+
+	// 未开启合并时按照序号依次下发，序号不大于已下发序号的配置更新被丢弃
+	update(ordered, &v1.DeviceConfig1{Id: ordered.DeviceID})
+	update(ordered, &v1.DeviceConfig1{Id: ordered.DeviceID})
+	updates, err = updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []int64{1, 2} {
+		if c := (<-updates).Config.(*v1.DeviceConfig1); c.Version != expected {
+			t.Fatalf("expected version %d,got %v", expected, c)
+		}
+	}
+	stale, _ := proto.Marshal(&v1.DeviceConfig1{Id: ordered.DeviceID, Version: 1})
+	msg, _ := json.Marshal(&biz.ConfigUpdateMessage{
+		Config:        fmt.Sprintf("%x", stale),
+		DeviceClassID: ordered.DeviceClassID,
+		DeviceID:      ordered.DeviceID,
+		Seq:           1,
+	})
+	if err := repo.PublishMsg(ctx, "test_1", string(msg)); err != nil {
+		t.Fatal(err)
+	}
+	update(ordered, &v1.DeviceConfig1{Id: ordered.DeviceID})
+	if c := (<-updates).Config.(*v1.DeviceConfig1); c.Version != 3 {
+		t.Fatalf("expected the stale update to be dropped,got %v", c)
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	utilApi "gitee.com/moyusir/util/api/util/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+// 测试客户端多次拒绝配置更新时的退避重发，以及达到最大发送次数后的失败状态和警告信息
+func TestDeviceConfigUpdater_Retry(t *testing.T) {
+	var (
+		repo, updater, _ = newTestConfigUsecase(t, &conf.Data{UpdateRetry: &conf.Data_UpdateRetry{
+			MaxAttempts: 3,
+			MinBackoff:  durationpb.New(10 * time.Millisecond),
+			MaxBackoff:  durationpb.New(15 * time.Millisecond),
+		}})
+		info   = &biz.DeviceGeneralInfo{DeviceClassID: 1, DeviceID: "device_1"}
+		reason = errors.New(400, "", "rejected")
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, updateID, err := updater.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig1{Id: info.DeviceID, Status: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := <-updates
+
+	// 退避时间每次翻倍，且不超过上限
+	for i, expected := range []time.Duration{10 * time.Millisecond, 15 * time.Millisecond} {
+		backoff, retry := updater.RetryDeviceConfigUpdate(ctx, "test_1", info, update, reason)
+		if !retry || backoff != expected {
+			t.Fatalf("attempt %d:expected retry after %v,got %v %v", i+1, expected, retry, backoff)
+		}
+		status, err := updater.GetConfigUpdateStatus(ctx, updateID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status != biz.UpdateStatusRetrying || status.Attempts != int64(i+1) || status.Error == "" {
+			t.Fatalf("unexpected status:%+v", status)
+		}
+	}
+
+	// 客户端重新连接后重发的配置更新沿用此前的发送次数，达到最大发送次数后配置更新失败，并从待确认队列中删除
+	cancel()
+	for range updates {
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	if updates, err = updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig1)); err != nil {
+		t.Fatal(err)
+	}
+	if update = <-updates; update.UpdateID() != updateID {
+		t.Fatalf("expected update %s to be replayed,got %s", updateID, update.UpdateID())
+	}
+	if _, retry := updater.RetryDeviceConfigUpdate(ctx, "test_1", info, update, reason); retry {
+		t.Fatal("expected no retry after the max attempts")
+	}
+	status, err := updater.GetConfigUpdateStatus(ctx, updateID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != biz.UpdateStatusFailed || status.Attempts != 3 {
+		t.Fatalf("unexpected status:%+v", status)
+	}
+	if pending, _ := repo.GetStreamMsgs(ctx, biz.GetPendingUpdatesKey("test_1")); len(pending) != 0 {
+		t.Fatalf("expected the failed update to be removed from the pending queue,got %d", len(pending))
+	}
+
+	warnings, _ := repo.GetStreamMsgs(ctx, biz.GetConfigUpdateWarningsKey())
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning,got %d", len(warnings))
+	}
+	warning := new(utilApi.Warning)
+	if err := protojson.Unmarshal([]byte(warnings[0].Value), warning); err != nil {
+		t.Fatal(err)
+	}
+	if warning.DeviceClassId != 1 || warning.DeviceId != info.DeviceID || warning.WarningMessage == "" ||
+		warning.Start.AsTime().After(warning.End.AsTime()) {
+		t.Fatalf("unexpected warning:%+v", warning)
+	}
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/conf"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+// 测试配置更新从加入队列到被设备应用或失败、过期的状态变化
+func TestDeviceConfigUpdater_UpdateStatus(t *testing.T) {
+	var (
+		_, updater, _ = newTestConfigUsecase(t, &conf.Data{PendingUpdates: &conf.Data_PendingUpdates{
+			Ttl: durationpb.New(100 * time.Millisecond),
+		}})
+		ctx  = context.Background()
+		info = &biz.DeviceGeneralInfo{DeviceClassID: 1, DeviceID: "device_1"}
+	)
+	if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+		t.Fatal(err)
+	}
+	expectStatus := func(updateID, expected string) *biz.ConfigUpdateStatus {
+		status, err := updater.GetConfigUpdateStatus(ctx, updateID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status != expected {
+			t.Fatalf("expected status %s,got %s", expected, status.Status)
+		}
+		return status
+	}
+
+	if _, err := updater.GetConfigUpdateStatus(ctx, "unknown"); errors.Code(err) != 404 {
+		t.Fatalf("expected 404 for an unknown update,got %v", err)
+	}
+
+	_, updateID, err := updater.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig1{Id: info.DeviceID, Status: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := expectStatus(updateID, biz.UpdateStatusQueued)
+	if status.DeviceClassID != 1 || status.DeviceID != "device_1" || status.ClientID != "test_1" {
+		t.Fatalf("unexpected status:%+v", status)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates, err := updater.GetDeviceUpdateMsgChannel(ctx, "test_1", new(v1.DeviceConfig1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := <-updates
+	for _, s := range []string{biz.UpdateStatusSent, biz.UpdateStatusAcked, biz.UpdateStatusApplied} {
+		updater.SetUpdateStatus(ctx, update, s, nil)
+		expectStatus(updateID, s)
+	}
+
+	// 设备答复失败时记录失败的原因
+	_, updateID, err = updater.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig1{Id: info.DeviceID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater.SetUpdateStatus(ctx, <-updates, biz.UpdateStatusFailed, errors.New(400, "", "rejected"))
+	if status := expectStatus(updateID, biz.UpdateStatusFailed); status.Error == "" {
+		t.Fatal("expected the failure reason to be recorded")
+	}
+
+	// 超过有效期仍未被确认的配置更新视为过期
+	_, updateID, err = updater.UpdateDeviceConfig(ctx, info, &v1.DeviceConfig1{Id: info.DeviceID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	expectStatus(updateID, biz.UpdateStatusExpired)
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
 	"gitee.com/moyusir/data-collection/internal/conf"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
@@ -66,6 +67,19 @@ func generalInit(path string, envs map[string]string) (*conf.Bootstrap, error) {
 	return bc, nil
 }
 
+// newTestConfigUsecase 以内存中的repo实例化测试所需的设备配置更新器以及设备配置用例，c为nil时使用缺省的配置
+func newTestConfigUsecase(t *testing.T, c *conf.Data) (*memoryRepo, *biz.DeviceConfigUpdater, *biz.ConfigUsecase) {
+	t.Helper()
+	if c == nil {
+		c = new(conf.Data)
+	}
+	repo := newMemoryRepo()
+	updater := biz.NewDeviceConfigUpdater(c, repo, log.DefaultLogger)
+	uc, cleanup := biz.NewConfigUsecase(c, repo, updater, log.DefaultLogger)
+	t.Cleanup(cleanup)
+	return repo, updater, uc
+}
+
 // StartDataCollectionTestServer 开启提供dataCollection服务的测试服务器，并返回相应服务的客户端
 func StartDataCollectionTestServer(t *testing.T, bootstrap *conf.Bootstrap) (
 	v1.ConfigClient, v1.ConfigHTTPClient, v1.WarningDetectClient) {
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	v1 "gitee.com/moyusir/data-collection/api/dataCollection/v1"
+	"gitee.com/moyusir/data-collection/internal/biz"
+	"gitee.com/moyusir/data-collection/internal/service"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"testing"
+)
+
+// testHeader 以map实现的transport.Header
+type testHeader map[string]string
+
+func (h testHeader) Get(key string) string { return h[key] }
+func (h testHeader) Set(key, value string) { h[key] = value }
+func (h testHeader) Keys() []string        { return nil }
+
+// testTransport 只包含请求头的transport.Transporter，用于模拟http请求
+type testTransport struct {
+	header testHeader
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "" }
+func (t *testTransport) RequestHeader() transport.Header { return t.header }
+func (t *testTransport) ReplyHeader() transport.Header   { return testHeader{} }
+
+// 测试请求头中包含x-wait-for-ack时，配置更新请求阻塞至设备确认或者等待超时
+func TestConfigService_WaitForAck(t *testing.T) {
+	var (
+		_, updater, uc = newTestConfigUsecase(t, nil)
+		ctx            = context.Background()
+	)
+	s, err := service.NewConfigService(uc, updater, nil, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// device_1的客户端在线，device_2的客户端不答复配置更新
+	for _, id := range []string{"device_1", "device_2"} {
+		info := &biz.DeviceGeneralInfo{DeviceClassID: 1, DeviceID: id}
+		if err := updater.ConnectDeviceAndClientID(ctx, "test_1", info); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deviceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates, err := updater.GetDeviceUpdateMsgChannel(deviceCtx, "test_1", new(v1.DeviceConfig1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 模拟客户端，status为true的配置被应用，否则答复失败
+	go func() {
+		for update := range updates {
+			config := update.Config.(*v1.DeviceConfig1)
+			if config.Id != "device_1" {
+				continue
+			}
+			if config.Status {
+				updater.SetUpdateStatus(deviceCtx, update, biz.UpdateStatusApplied, nil)
+			} else {
+				updater.SetUpdateStatus(deviceCtx, update, biz.UpdateStatusFailed, errors.New(400, "", "rejected"))
+			}
+		}
+	}()
+
+	withHeader := func(value string) context.Context {
+		return transport.NewServerContext(ctx, &testTransport{header: testHeader{
+			service.WAIT_FOR_ACK_HEADER: value,
+		}})
+	}
+
+	// 未设置请求头时立即返回
+	reply, err := s.UpdateDeviceConfig1(ctx, &v1.DeviceConfig1{Id: "device_2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success || reply.UpdateId == "" || reply.Status != "" {
+		t.Fatalf("unexpected reply:%+v", reply)
+	}
+
+	reply, err = s.UpdateDeviceConfig1(withHeader("5s"), &v1.DeviceConfig1{Id: "device_1", Status: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success || reply.Status != biz.UpdateStatusApplied || reply.Error != "" {
+		t.Fatalf("unexpected reply:%+v", reply)
+	}
+
+	reply, err = s.UpdateDeviceConfig1(withHeader("5s"), &v1.DeviceConfig1{Id: "device_1", Status: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Success || reply.Status != biz.UpdateStatusFailed || reply.Error == "" {
+		t.Fatalf("unexpected reply:%+v", reply)
+	}
+
+	// 设备未答复时等待超时，返回配置更新当前的状态
+	reply, err = s.UpdateDeviceConfig1(withHeader("100ms"), &v1.DeviceConfig1{Id: "device_2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Success || reply.Status != biz.UpdateStatusQueued || reply.Error == "" {
+		t.Fatalf("unexpected reply:%+v", reply)
+	}
+
+	_, err = s.UpdateDeviceConfig1(withHeader("soon"), &v1.DeviceConfig1{Id: "device_2"})
+	if errors.Code(err) != 400 {
+		t.Fatalf("expected 400 for an invalid header,got %v", err)
+	}
+}
@@ -23,10 +23,10 @@ func initApp(*conf.Server, *conf.Data, log.Logger) (*kratos.App, func(), error)
 
 // InitConfigUsecase 测试用的辅助函数
 func InitConfigUsecase(*conf.Data, log.Logger) (*biz.ConfigUsecase, func(), error) {
-	panic(wire.Build(data.ProviderSet, biz.NewConfigUsecase))
+	panic(wire.Build(data.ProviderSet, biz.NewConfigUsecase, biz.NewDeviceConfigUpdater))
 }
 
 // InitWarningDetectUsecase 测试用的辅助函数
-func InitWarningDetectUsecase(*conf.Data, log.Logger) (*biz.WarningDetectUsecase, func(), error) {
-	panic(wire.Build(data.ProviderSet, biz.NewWarningDetectUsecase))
+func InitWarningDetectUsecase(*conf.Server, *conf.Data, log.Logger) (*biz.WarningDetectUsecase, func(), error) {
+	panic(wire.Build(data.ProviderSet, biz.NewWarningDetectUsecase, biz.NewDeviceReadingCache))
 }